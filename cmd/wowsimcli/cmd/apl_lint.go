@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var aplCmd = &cobra.Command{
+	Use:   "apl",
+	Short: "APL rotation tools",
+}
+
+var aplLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "check APL rotations for problems without running the sim",
+	Run:   aplLintMain,
+}
+
+func init() {
+	aplLintCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	aplLintCmd.MarkFlagRequired("infile")
+	aplCmd.AddCommand(aplLintCmd)
+}
+
+func aplLintMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	result := core.LintAPL(&proto.APLLintRequest{
+		Raid:      input.Raid,
+		Encounter: input.Encounter,
+	})
+	if result.ErrorResult != "" {
+		log.Fatalf("failed to lint APL: %s", result.ErrorResult)
+	}
+
+	numIssues := 0
+	for _, player := range result.Players {
+		for _, issue := range player.Issues {
+			list := "priority"
+			if issue.Prepull {
+				list = "prepull"
+			}
+			fmt.Printf("%s: %s #%d: %s\n", player.Name, list, issue.ListIndex+1, issue.Message)
+			numIssues++
+		}
+	}
+
+	if numIssues > 0 {
+		os.Exit(1)
+	}
+}
@@ -8,9 +8,17 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wowsims/sod/sim/core"
 	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/encounters"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+var (
+	aplTrace          bool
+	presetEncounter   string
+	fightStyle        string
+	fightStyleTargets int32
+)
+
 var simCmd = &cobra.Command{
 	Use:   "sim",
 	Short: "simulate items & settings",
@@ -21,6 +29,10 @@ func init() {
 	simCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
 	simCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
 	simCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	simCmd.Flags().BoolVar(&aplTrace, "apl-trace", false, "record APL decisions and include a per-item APL profile in the results")
+	simCmd.Flags().StringVar(&presetEncounter, "encounter", "", "replace the input encounter targets with the preset encounter at this path, e.g. one loaded with --presets-dir")
+	simCmd.Flags().StringVar(&fightStyle, "fight-style", "", "replace the input encounter with a generated fight style (patchwerk, cleave, aoe-waves, heavy-movement, execute, target-switching, intermission), keeping its first target and duration")
+	simCmd.Flags().Int32Var(&fightStyleTargets, "fight-style-targets", 0, "number of targets for the cleave fight style, or adds per wave for the aoe-waves and target-switching fight styles")
 	simCmd.MarkFlagRequired("infile")
 }
 
@@ -35,6 +47,34 @@ func simMain(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
+	if aplTrace {
+		if input.SimOptions == nil {
+			input.SimOptions = &proto.SimOptions{}
+		}
+		input.SimOptions.AplTrace = true
+	}
+	if presetEncounter != "" {
+		preset := core.GetPresetEncounterWithPath(presetEncounter)
+		if preset == nil {
+			log.Fatalf("no preset encounter with path %q", presetEncounter)
+		}
+		input.Encounter = core.PresetEncounterProto(preset, input.Encounter)
+	}
+	if fightStyle != "" {
+		options := encounters.FightStyleOptions{
+			NumTargets: fightStyleTargets,
+		}
+		if input.Encounter != nil {
+			options.Duration = input.Encounter.Duration
+			if len(input.Encounter.Targets) > 0 {
+				options.Target = input.Encounter.Targets[0]
+			}
+		}
+		input.Encounter, err = encounters.GenerateFightStyleEncounter(fightStyle, options)
+		if err != nil {
+			log.Fatalf("failed to generate fight style: %s", err)
+		}
+	}
 
 	var output []byte
 	reporter := make(chan *proto.ProgressMetrics, 10)
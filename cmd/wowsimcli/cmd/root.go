@@ -5,12 +5,25 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/encounters"
 )
 
+var presetsDir string
+
 var rootCmd = &cobra.Command{
 	Use:   "wowsimcli",
 	Short: "wowsims command line tool",
 	Long:  "wowsims command line tool",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if presetsDir == "" {
+			return nil
+		}
+		return encounters.LoadPresetPacks(presetsDir)
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&presetsDir, "presets-dir", "", "directory of preset packs (JSON/HuJSON files with extra preset targets and encounters) to load at startup")
 }
 
 func Execute(version string) {
@@ -18,6 +31,7 @@ func Execute(version string) {
 	rootCmd.AddCommand(simCmd)
 	rootCmd.AddCommand(bulkCmd)
 	rootCmd.AddCommand(decodeLinkCmd)
+	rootCmd.AddCommand(aplCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -68,7 +68,7 @@ func StormhammerChainLightningProcAura(agent core.Agent, itemID int32) {
 		DamageMultiplier: 1,
 		ThreatMultiplier: 1,
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for numHits := 0; numHits < maxHits; numHits++ {
+			for numHits := 0; numHits < min(maxHits, int(sim.GetNumTargets())); numHits++ {
 				spell.CalcAndDealDamage(sim, target, sim.Roll(105, 145), spell.OutcomeMagicHitAndCrit)
 				target = character.Env.NextTargetUnit(target)
 			}
@@ -78,7 +78,7 @@ func init() {
 			DamageMultiplier: 1,
 			ThreatMultiplier: 1,
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				for numHits := 0; numHits < maxHits; numHits++ {
+				for numHits := 0; numHits < min(maxHits, int(sim.GetNumTargets())); numHits++ {
 					spell.CalcAndDealDamage(sim, target, sim.Roll(180, 230), spell.OutcomeMagicHitAndCrit)
 					target = character.Env.NextTargetUnit(target)
 				}
@@ -354,6 +354,7 @@ func fishTerminatorEffect(agent core.Agent, itemID int32) {
 		DamageMultiplier: 1,
 		ThreatMultiplier: 1,
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			results := core.LimitToSpawnedTargets(sim, results)
 			for idx := range results {
 				results[idx] = spell.CalcDamage(sim, target, sim.Roll(175, 225), spell.OutcomeMagicHitAndCrit)
 				target = character.Env.NextTargetUnit(target)
@@ -373,7 +373,7 @@ func init() {
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 				curTarget := target
-				for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
+				for hitIndex := int32(0); hitIndex < min(numHits, sim.GetNumTargets()); hitIndex++ {
 					damage := sim.Roll(386, 472)
 					spell.CalcAndDealDamage(sim, curTarget, damage, spell.OutcomeMagicCrit)
 					curTarget = sim.Environment.NextTargetUnit(curTarget)
@@ -616,7 +616,7 @@ func init() {
 
 					// Delay the reset to simulate real player reaction time
 					sim.AddPendingAction(&core.PendingAction{
-						NextActionAt: sim.CurrentTime + character.Unit.ReactionTime,
+						NextActionAt: sim.CurrentTime + character.SampleReactionTime(sim),
 						OnAction: func(sim *core.Simulation) {
 							spell.CD.Reset()
 						},
@@ -563,6 +563,7 @@ func init() {
 			ThreatMultiplier: 1,
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				targetCount := min(targetCount, int(sim.GetNumTargets()))
 				for i := 0; i < targetCount; i++ {
 					result := spell.CalcAndDealDamage(sim, target, sim.Roll(475, 525), spell.OutcomeAlwaysHit)
 					character.GainHealth(sim, result.Damage, healthMetrics)
@@ -1335,7 +1336,7 @@ func init() {
 			DamageMultiplier: 1,
 			ThreatMultiplier: 1,
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				for numHits := 0; numHits < maxHits; numHits++ {
+				for numHits := 0; numHits < min(maxHits, int(sim.GetNumTargets())); numHits++ {
 					spell.CalcAndDealDamage(sim, target, sim.Roll(105, 145), spell.OutcomeMagicHitAndCrit)
 					target = character.Env.NextTargetUnit(target)
 				}
@@ -2042,6 +2043,7 @@ func init() {
 			DamageMultiplier: 1,
 			ThreatMultiplier: 1,
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				results := core.LimitToSpawnedTargets(sim, results)
 				for idx := range results {
 					results[idx] = spell.CalcDamage(sim, target, 7, spell.OutcomeMagicHitAndCrit)
 					target = character.Env.NextTargetUnit(target)
@@ -2154,6 +2156,7 @@ func init() {
 			FlatThreatBonus:  126,
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				results := core.LimitToSpawnedTargets(sim, results)
 				for idx := range results {
 					results[idx] = spell.CalcDamage(sim, target, 0, spell.OutcomeMagicHit)
 					target = sim.Environment.NextTargetUnit(target)
@@ -45,6 +45,13 @@ type Agent interface {
 	ExecuteCustomRotation(sim *Simulation)
 }
 
+// Optionally implemented by Agents whose spells apply raid buffs, party buffs or debuffs.
+// With Raid.SimulateRaidBuffs, the static toggles covered by those spells are cleared so
+// that the effects only come from the Agent's rotation.
+type BuffProviderAgent interface {
+	RemoveSimulatedBuffs(raidBuffs *proto.RaidBuffs, partyBuffs *proto.PartyBuffs, debuffs *proto.Debuffs)
+}
+
 type ActionID struct {
 	// Only one of these should be set.
 	SpellID int32
@@ -23,6 +23,37 @@ func ComputeStats(csr *proto.ComputeStatsRequest) *proto.ComputeStatsResult {
 	}
 }
 
+/**
+ * Runs static analysis on each player's APL rotation, without running the sim.
+ */
+func LintAPL(request *proto.APLLintRequest) *proto.APLLintResult {
+	return lintAPLs(request)
+}
+
+/**
+ * Returns all preset encounters, including ones loaded from preset packs.
+ */
+func GetPresetEncounters(_ *proto.PresetEncountersRequest) *proto.PresetEncountersResult {
+	return &proto.PresetEncountersResult{
+		Encounters: PresetEncounters,
+	}
+}
+
+/**
+ * Returns a target with level-appropriate stats for an NPC.
+ */
+func TargetTemplate(request *proto.TargetTemplateRequest) *proto.TargetTemplateResult {
+	target, err := NewTargetTemplate(request)
+	if err != nil {
+		return &proto.TargetTemplateResult{
+			ErrorResult: err.Error(),
+		}
+	}
+	return &proto.TargetTemplateResult{
+		Target: target,
+	}
+}
+
 /**
  * Returns stat weights and EP values, with standard deviations, for all stats.
  */
@@ -131,6 +162,13 @@ func RunBulkSimAsync(request *proto.BulkSimRequest, progress chan *proto.Progres
 	}()
 }
 
+/**
+ * Searches party layouts for a roster, returning the ones with the best raid DPS.
+ */
+func RunPartyOptimizer(request *proto.PartyOptimizerRequest) *proto.PartyOptimizerResult {
+	return OptimizeParties(simsignals.CreateSignals(), request)
+}
+
 var runningInWasm = false
 
 func SetRunningInWasm() {
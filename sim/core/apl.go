@@ -14,6 +14,12 @@ type APLRotation struct {
 	prepullActions []*APLAction
 	priorityList   []*APLAction
 
+	// Index of each priorityList action within the original config, which may contain hidden or invalid items.
+	configIdxs []int
+
+	// Decision trace, only set when SimOptions.AplTrace is enabled.
+	trace *aplTrace
+
 	// Action currently controlling this rotation (only used for certain actions, such as StrictSequence).
 	controllingActions []APLActionImpl
 
@@ -111,14 +117,13 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 	}
 
 	// Parse priority list
-	var configIdxs []int
 	for i, aplItem := range config.PriorityList {
 		rotation.doAndRecordWarnings(&rotation.priorityListWarnings[i], false, func() {
 			if !aplItem.Hide {
 				action := rotation.newAPLAction(aplItem.Action)
 				if action != nil {
 					rotation.priorityList = append(rotation.priorityList, action)
-					configIdxs = append(configIdxs, i)
+					rotation.configIdxs = append(rotation.configIdxs, i)
 				}
 			}
 		})
@@ -185,6 +190,11 @@ func (rot *APLRotation) reset(sim *Simulation) {
 	for _, action := range rot.allAPLActions() {
 		action.impl.Reset(sim)
 	}
+
+	// The trace is intentionally kept across iterations, so results are aggregated.
+	if sim.Options.AplTrace && rot.trace == nil {
+		rot.trace = rot.newAPLTrace()
+	}
 }
 
 // We intentionally try to mimic the behavior of simc APL to avoid confusion
@@ -207,10 +217,16 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 		return
 	}
 
+	getNextAction := apl.getNextAction
+	if apl.trace != nil {
+		getNextAction = apl.getNextActionTraced
+		apl.trace.evaluations++
+	}
+
 	i := 0
 	apl.inLoop = true
 
-	for nextAction := apl.getNextAction(sim); nextAction != nil; i, nextAction = i+1, apl.getNextAction(sim) {
+	for nextAction := getNextAction(sim); nextAction != nil; i, nextAction = i+1, getNextAction(sim) {
 		if i > 1000 {
 			panic(fmt.Sprintf("[USER_ERROR] Infinite loop detected, current action:\n%s", nextAction))
 		}
@@ -219,6 +235,10 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 	}
 	apl.inLoop = false
 
+	if apl.trace != nil && i == 0 {
+		apl.trace.noAction++
+	}
+
 	if sim.Log != nil && i == 0 {
 		apl.unit.Log(sim, "No available actions!")
 	}
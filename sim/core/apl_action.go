@@ -191,6 +191,10 @@ func (rot *APLRotation) newAPLActionImpl(config *proto.APLAction) APLActionImpl
 		return rot.newActionCustomRotation(config.GetCustomRotation())
 	case *proto.APLAction_AddComboPoints:
 		return rot.newActionAddComboPoints(config.GetAddComboPoints())
+	case *proto.APLAction_Interrupt:
+		return rot.newActionInterrupt(config.GetInterrupt())
+	case *proto.APLAction_Dispel:
+		return rot.newActionDispel(config.GetDispel())
 	default:
 		return nil
 	}
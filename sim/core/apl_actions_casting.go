@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/wowsims/sod/sim/core/proto"
 )
@@ -17,7 +18,7 @@ func (rot *APLRotation) newActionCastSpell(config *proto.APLActionCastSpell) APL
 	if spell == nil {
 		return nil
 	}
-	target := rot.GetTargetUnit(config.Target)
+	target := rot.GetSpellTargetUnit(spell, config.Target)
 	if target.Get() == nil {
 		return nil
 	}
@@ -63,7 +64,7 @@ func (rot *APLRotation) newActionChannelSpell(config *proto.APLActionChannelSpel
 		return nil
 	}
 
-	target := rot.GetTargetUnit(config.Target)
+	target := rot.GetSpellTargetUnit(spell, config.Target)
 	if target.Get() == nil {
 		return nil
 	}
@@ -156,7 +157,7 @@ func (action *APLActionMultidot) IsReady(sim *Simulation) bool {
 			}
 		}
 	} else {
-		for i := int32(0); i < action.maxDots; i++ {
+		for i := int32(0); i < min(action.maxDots, sim.GetNumTargets()); i++ {
 			target := sim.Encounter.TargetUnits[i]
 			dot := action.spell.Dot(target)
 			if (!dot.IsActive() || dot.RemainingDuration(sim) < maxOverlap) && action.spell.CanCast(sim, target) {
@@ -266,3 +267,103 @@ func (action *APLActionAutocastOtherCooldowns) Execute(sim *Simulation) {
 func (action *APLActionAutocastOtherCooldowns) String() string {
 	return "Autocast Other Cooldowns"
 }
+
+type APLActionInterrupt struct {
+	defaultAPLActionImpl
+	spell  *Spell
+	target UnitReference
+}
+
+func (rot *APLRotation) newActionInterrupt(config *proto.APLActionInterrupt) APLActionImpl {
+	spell := rot.GetAPLSpell(config.SpellId)
+	if spell == nil {
+		return nil
+	}
+	target := rot.GetTargetUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	return &APLActionInterrupt{
+		spell:  spell,
+		target: target,
+	}
+}
+func (action *APLActionInterrupt) IsReady(sim *Simulation) bool {
+	target := action.target.Get()
+	return target.IsCastingInterruptible(sim) && action.spell.CanCast(sim, target)
+}
+func (action *APLActionInterrupt) Execute(sim *Simulation) {
+	target := action.target.Get()
+	if action.spell.Cast(sim, target) {
+		target.CancelHardcast(sim)
+	}
+}
+func (action *APLActionInterrupt) String() string {
+	return fmt.Sprintf("Interrupt(%s)", action.spell.ActionID)
+}
+
+// Dispel types checked when no type is given.
+var allDispelTypes = []DispelType{DispelType_Magic, DispelType_Curse, DispelType_Poison, DispelType_Disease}
+
+// Returns an active aura on the unit which can be removed by one of the dispel types, or nil.
+func getDispellableAura(unit *Unit, dispelTypes []DispelType) *Aura {
+	for _, dispelType := range dispelTypes {
+		if aura := unit.GetActiveAuraWithDispelType(dispelType); aura != nil {
+			return aura
+		}
+	}
+	return nil
+}
+
+type APLActionDispel struct {
+	defaultAPLActionImpl
+	spell       *Spell
+	target      UnitReference
+	dispelTypes []DispelType
+}
+
+func (rot *APLRotation) newActionDispel(config *proto.APLActionDispel) APLActionImpl {
+	spell := rot.GetAPLSpell(config.SpellId)
+	if spell == nil {
+		return nil
+	}
+	dispelTypes := utilitySpellDispelTypes(spell.ActionID)
+	if len(dispelTypes) == 0 {
+		rot.ValidationWarning("%s is not a dispel spell", spell.ActionID)
+		return nil
+	}
+	if config.DispelType != proto.DispelType_DispelTypeNone {
+		dispelType := DispelTypeFromProto(config.DispelType)
+		if !slices.Contains(dispelTypes, dispelType) {
+			rot.ValidationWarning("%s cannot remove %s debuffs", spell.ActionID, config.DispelType)
+			return nil
+		}
+		dispelTypes = []DispelType{dispelType}
+	}
+	target := rot.GetSourceUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	return &APLActionDispel{
+		spell:       spell,
+		target:      target,
+		dispelTypes: dispelTypes,
+	}
+}
+func (action *APLActionDispel) IsReady(sim *Simulation) bool {
+	target := action.target.Get()
+	return getDispellableAura(target, action.dispelTypes) != nil && action.spell.CanCast(sim, target)
+}
+func (action *APLActionDispel) Execute(sim *Simulation) {
+	target := action.target.Get()
+	aura := getDispellableAura(target, action.dispelTypes)
+	if action.spell.Cast(sim, target) {
+		if sim.Log != nil {
+			action.spell.Unit.Log(sim, "Dispelled %s from %s", aura.ActionID, target.Label)
+		}
+		aura.Deactivate(sim)
+	}
+}
+func (action *APLActionDispel) String() string {
+	return fmt.Sprintf("Dispel(%s)", action.spell.ActionID)
+}
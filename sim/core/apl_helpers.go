@@ -7,8 +7,9 @@ import (
 // Struct for handling unit references, to account for values that can
 // change dynamically (e.g. CurrentTarget).
 type UnitReference struct {
-	fixedUnit       *Unit
-	curTargetSource *Unit
+	fixedUnit        *Unit
+	curTargetSource  *Unit
+	lowestHealthRaid *Raid
 }
 
 func (ur UnitReference) Get() *Unit {
@@ -16,6 +17,8 @@ func (ur UnitReference) Get() *Unit {
 		return ur.fixedUnit
 	} else if ur.curTargetSource != nil {
 		return ur.curTargetSource.CurrentTarget
+	} else if ur.lowestHealthRaid != nil {
+		return ur.lowestHealthRaid.GetLowestHealthAlly()
 	} else {
 		return nil
 	}
@@ -32,6 +35,10 @@ func NewUnitReference(ref *proto.UnitReference, contextUnit *Unit) UnitReference
 		return UnitReference{
 			curTargetSource: contextUnit,
 		}
+	} else if ref.Type == proto.UnitReference_LowestHealthAlly {
+		return UnitReference{
+			lowestHealthRaid: contextUnit.Env.Raid,
+		}
 	} else {
 		return UnitReference{
 			fixedUnit: contextUnit.GetUnit(ref),
@@ -57,18 +64,26 @@ func (rot *APLRotation) GetTargetUnit(ref *proto.UnitReference) UnitReference {
 	return rot.getUnit(ref, &proto.UnitReference{Type: proto.UnitReference_CurrentTarget})
 }
 
+// Helpful spells, e.g. heals, default to the caster instead of its current target.
+func (rot *APLRotation) GetSpellTargetUnit(spell *Spell, ref *proto.UnitReference) UnitReference {
+	if spell.Flags.Matches(SpellFlagHelpful) {
+		return rot.GetSourceUnit(ref)
+	}
+	return rot.GetTargetUnit(ref)
+}
+
 type AuraReference struct {
 	fixedAura *Aura
 
-	curTargetSource *Unit
-	curTargetAuras  AuraArray
+	dynamicSource UnitReference
+	dynamicAuras  AuraArray
 }
 
 func (ar *AuraReference) Get() *Aura {
 	if ar.fixedAura != nil {
 		return ar.fixedAura
-	} else if ar.curTargetSource != nil {
-		return ar.curTargetAuras.Get(ar.curTargetSource.CurrentTarget)
+	} else if ar.dynamicAuras != nil {
+		return ar.dynamicAuras.Get(ar.dynamicSource.Get())
 	} else {
 		return nil
 	}
@@ -91,8 +106,8 @@ func newAuraReferenceHelper(sourceUnit UnitReference, auraId *proto.ActionID, au
 			auras[unit.UnitIndex] = auraGetter(unit, ProtoToActionID(auraId))
 		}
 		return AuraReference{
-			curTargetSource: sourceUnit.curTargetSource,
-			curTargetAuras:  auras,
+			dynamicSource: sourceUnit,
+			dynamicAuras:  auras,
 		}
 	}
 }
@@ -0,0 +1,251 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Static analysis for APL rotations. Runs against a finalized rotation, without simming,
+// and reports problems that are not caught by the validation done while parsing.
+type aplLinter struct {
+	rot    *APLRotation
+	issues []*proto.APLLintIssue
+}
+
+func (linter *aplLinter) addIssue(prepull bool, listIndex int, message string, vals ...interface{}) {
+	linter.issues = append(linter.issues, &proto.APLLintIssue{
+		Prepull:   prepull,
+		ListIndex: int32(listIndex),
+		Message:   fmt.Sprintf(message, vals...),
+	})
+}
+
+func (rot *APLRotation) lint() []*proto.APLLintIssue {
+	linter := &aplLinter{rot: rot}
+
+	// Warnings from parsing already cover references to unknown spells, auras and sequences.
+	for i, warnings := range rot.prepullWarnings {
+		for _, warning := range warnings {
+			linter.addIssue(true, i, "%s", warning)
+		}
+	}
+	for i, warnings := range rot.priorityListWarnings {
+		for _, warning := range warnings {
+			linter.addIssue(false, i, "%s", warning)
+		}
+	}
+
+	var blocker *APLAction
+	blockerIdx := 0
+	for i, action := range rot.priorityList {
+		listIdx := rot.configIdxs[i]
+
+		if blocker != nil && aplActionBlocks(blocker, action) {
+			linter.addIssue(false, listIdx, "Unreachable: action #%d (%s) is always ready and is checked first", blockerIdx+1, blocker.impl)
+		}
+
+		linter.lintAction(listIdx, action)
+
+		if blocker == nil && aplActionIsAlwaysReady(action) {
+			blocker = action
+			blockerIdx = listIdx
+		}
+	}
+
+	return linter.issues
+}
+
+func (linter *aplLinter) lintAction(listIdx int, action *APLAction) {
+	for _, subaction := range action.GetAllActions() {
+		if subaction.condition != nil {
+			if val, isConst := constantAPLBool(subaction.condition); isConst {
+				linter.addIssue(false, listIdx, "Condition is always %t: %s", val, subaction.condition)
+			}
+		}
+
+		if strictSequence, ok := subaction.impl.(*APLActionStrictSequence); ok {
+			linter.lintStrictSequence(listIdx, strictSequence)
+		}
+	}
+
+	for _, value := range action.GetAllAPLValues() {
+		if compare, ok := value.(*APLValueCompare); ok {
+			lhsType, rhsType := aplValueSourceType(compare.lhs), aplValueSourceType(compare.rhs)
+			if !aplValueTypesCompatible(lhsType, rhsType) {
+				linter.addIssue(false, listIdx, "Comparison between incompatible types %s and %s: %s", lhsType, rhsType, compare)
+			}
+		}
+	}
+}
+
+// Strict sequences require every spell in the sequence to be ready before starting, so a
+// step that can never become ready causes the sequence to stall until the GCD resets it.
+func (linter *aplLinter) lintStrictSequence(listIdx int, sequence *APLActionStrictSequence) {
+	for i, subaction := range sequence.subactions {
+		if subaction.condition != nil {
+			if val, isConst := constantAPLBool(subaction.condition); isConst && !val {
+				linter.addIssue(false, listIdx, "Strict Sequence can never complete: step %d has a condition that is always false", i+1)
+			}
+		}
+	}
+
+	usedBy := make(map[*Timer]*Spell)
+	for _, spell := range sequence.subactionSpells {
+		for _, cd := range []*SpellCooldown{spell.CdSpell.CD, spell.CdSpell.SharedCD} {
+			// A timer only blocks the next cast with a non-zero duration, e.g. after talents or set bonuses.
+			timer := cd.Timer
+			if timer == nil || cd.GetCurrentDuration() == 0 {
+				continue
+			}
+			if other, ok := usedBy[timer]; ok {
+				linter.addIssue(false, listIdx, "Strict Sequence can never complete: %s is on cooldown after %s is cast", spell.ActionID, other.ActionID)
+				return
+			}
+			usedBy[timer] = spell
+		}
+	}
+}
+
+// Whether the action has no condition and a spell with no cooldown, cost or cast condition.
+func aplActionIsAlwaysReady(action *APLAction) bool {
+	if action.condition != nil {
+		return false
+	}
+	castSpell, ok := action.impl.(*APLActionCastSpell)
+	if !ok || castSpell.spell == nil {
+		return false
+	}
+	spell := castSpell.spell
+	return spell.CdSpell.CD.Timer == nil && spell.CdSpell.SharedCD.Timer == nil &&
+		spell.Cost == nil && spell.ExtraCastCondition == nil && spell.DefaultCast.CastTime == 0
+}
+
+// Whether an always-ready blocker action prevents the later action from ever being reached.
+// A blocker on the GCD still leaves room for off-GCD actions.
+func aplActionBlocks(blocker *APLAction, later *APLAction) bool {
+	if blocker.impl.(*APLActionCastSpell).spell.DefaultCast.GCD == 0 {
+		return true
+	}
+	spells := later.GetAllSpells()
+	if len(spells) == 0 {
+		return false
+	}
+	for _, spell := range spells {
+		if spell.DefaultCast.GCD == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns whether the value never depends on sim state.
+func isConstantAPLValue(value APLValue) bool {
+	switch value.(type) {
+	case *APLValueConst:
+		return true
+	case *APLValueCoerced, *APLValueCompare, *APLValueMath, *APLValueMax, *APLValueMin, *APLValueAnd, *APLValueOr, *APLValueNot:
+		inner := value.GetInnerValues()
+		if len(inner) == 0 {
+			return false
+		}
+		for _, innerValue := range inner {
+			if innerValue == nil || !isConstantAPLValue(innerValue) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Returns the value of a bool APLValue and whether it is known without simming.
+// And/Or are short-circuited, so a single constant false/true operand is enough.
+func constantAPLBool(value APLValue) (bool, bool) {
+	switch v := value.(type) {
+	case *APLValueAnd:
+		allConst := true
+		for _, inner := range v.vals {
+			if val, isConst := constantAPLBool(inner); !isConst {
+				allConst = false
+			} else if !val {
+				return false, true
+			}
+		}
+		return true, allConst
+	case *APLValueOr:
+		allConst := true
+		for _, inner := range v.vals {
+			if val, isConst := constantAPLBool(inner); !isConst {
+				allConst = false
+			} else if val {
+				return true, true
+			}
+		}
+		return false, allConst
+	}
+
+	if isConstantAPLValue(value) {
+		return value.GetBool(nil), true
+	}
+	return false, false
+}
+
+// Returns the type of a value before any coercion was applied. Constants are
+// parsed from strings and are valid as any type, so they are reported as unknown.
+func aplValueSourceType(value APLValue) proto.APLValueType {
+	switch v := value.(type) {
+	case *APLValueConst:
+		return proto.APLValueType_ValueTypeUnknown
+	case *APLValueCoerced:
+		return aplValueSourceType(v.inner)
+	}
+	return value.Type()
+}
+
+func aplValueTypesCompatible(type1 proto.APLValueType, type2 proto.APLValueType) bool {
+	if type1 == proto.APLValueType_ValueTypeUnknown || type2 == proto.APLValueType_ValueTypeUnknown || type1 == type2 {
+		return true
+	}
+	// Numeric types (int, float and duration) can be compared with each other.
+	for _, t := range []proto.APLValueType{type1, type2} {
+		if t == proto.APLValueType_ValueTypeBool || t == proto.APLValueType_ValueTypeString {
+			return false
+		}
+	}
+	return true
+}
+
+// Runs the APL linter for every player in the raid. Only constructs the environment, does not sim.
+func lintAPLs(request *proto.APLLintRequest) (result *proto.APLLintResult) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.APLLintResult{
+				ErrorResult: fmt.Sprintf("%v", err),
+			}
+		}
+	}()
+
+	encounter := request.Encounter
+	if encounter == nil {
+		encounter = &proto.Encounter{}
+	}
+
+	env, _, _ := NewEnvironment(request.Raid, encounter, true)
+
+	result = &proto.APLLintResult{}
+	for _, party := range env.Raid.Parties {
+		for _, player := range party.Players {
+			character := player.GetCharacter()
+			if character.Rotation == nil {
+				continue
+			}
+			result.Players = append(result.Players, &proto.APLLintPlayerResult{
+				Name:      character.Name,
+				UnitIndex: character.UnitIndex,
+				Issues:    character.Rotation.lint(),
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestAPLLintConstantConditions(t *testing.T) {
+	rot := &APLRotation{
+		unit: &Unit{},
+	}
+	cmp := &APLValueCompare{
+		op:  proto.APLValueCompare_OpGt,
+		lhs: rot.newValueConst(&proto.APLValueConst{Val: "2"}),
+		rhs: rot.newValueConst(&proto.APLValueConst{Val: "1"}),
+	}
+	if val, isConst := constantAPLBool(cmp); !isConst || !val {
+		t.Fatalf("Expected constant true comparison, got %t %t", val, isConst)
+	}
+
+	and := &APLValueAnd{vals: []APLValue{&APLValueCurrentTime{}, rot.newValueConst(&proto.APLValueConst{Val: "false"})}}
+	if val, isConst := constantAPLBool(and); !isConst || val {
+		t.Fatalf("Expected constant false And, got %t %t", val, isConst)
+	}
+
+	or := &APLValueOr{vals: []APLValue{&APLValueCurrentTime{}, rot.newValueConst(&proto.APLValueConst{Val: "false"})}}
+	if _, isConst := constantAPLBool(or); isConst {
+		t.Fatalf("Expected non-constant Or")
+	}
+}
+
+func TestAPLLintTypeCompatibility(t *testing.T) {
+	if aplValueTypesCompatible(proto.APLValueType_ValueTypeDuration, proto.APLValueType_ValueTypeBool) {
+		t.Fatalf("Duration and bool should be incompatible")
+	}
+	if !aplValueTypesCompatible(proto.APLValueType_ValueTypeDuration, proto.APLValueType_ValueTypeFloat) {
+		t.Fatalf("Duration and float should be compatible")
+	}
+	if !aplValueTypesCompatible(proto.APLValueType_ValueTypeUnknown, proto.APLValueType_ValueTypeString) {
+		t.Fatalf("Constants should be compatible with any type")
+	}
+}
+
+func TestAPLLintStrictSequenceCooldowns(t *testing.T) {
+	newLintSpell := func(spellID int32, cd Cooldown, sharedCD Cooldown) *Spell {
+		spell := &Spell{ActionID: ActionID{SpellID: spellID}, CD: newSpellCooldown(cd), SharedCD: newSpellCooldown(sharedCD)}
+		spell.CdSpell = spell
+		return spell
+	}
+	sharedTimer := new(Timer)
+	reducedSharedCD := newLintSpell(3, Cooldown{}, Cooldown{Timer: sharedTimer, Duration: time.Second * 10})
+	reducedSharedCD.SharedCD.ApplyFlatCooldownMod(-time.Second * 10)
+	ownCD := newLintSpell(5, Cooldown{Timer: new(Timer), Duration: time.Second * 10}, Cooldown{})
+
+	tests := []struct {
+		name        string
+		spells      []*Spell
+		expectIssue bool
+	}{
+		{
+			name: "shared cooldown",
+			spells: []*Spell{
+				newLintSpell(1, Cooldown{}, Cooldown{Timer: sharedTimer, Duration: time.Second * 10}),
+				newLintSpell(2, Cooldown{}, Cooldown{Timer: sharedTimer, Duration: time.Second * 10}),
+			},
+			expectIssue: true,
+		},
+		{
+			name: "shared timer without a cooldown",
+			spells: []*Spell{
+				newLintSpell(1, Cooldown{}, Cooldown{Timer: sharedTimer}),
+				reducedSharedCD,
+			},
+		},
+		{
+			name: "separate timers",
+			spells: []*Spell{
+				newLintSpell(1, Cooldown{}, Cooldown{Timer: new(Timer), Duration: time.Second * 10}),
+				newLintSpell(2, Cooldown{}, Cooldown{Timer: new(Timer), Duration: time.Second * 10}),
+			},
+		},
+		{name: "repeated spell with a cooldown", spells: []*Spell{ownCD, ownCD}, expectIssue: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			linter := &aplLinter{}
+			linter.lintStrictSequence(0, &APLActionStrictSequence{subactionSpells: test.spells})
+			if hasIssue := len(linter.issues) > 0; hasIssue != test.expectIssue {
+				t.Fatalf("Expected an issue: %t, got %v", test.expectIssue, linter.issues)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var numCastFailureReasons = len(proto.CastFailureReason_name)
+
+// Per-item decision counters, accumulated across all iterations of a sim.
+type aplItemTrace struct {
+	listIndex     int32
+	action        string
+	evaluations   int64
+	conditionTrue int64
+	executions    int64
+	blocked       []int64 // Indexed by proto.CastFailureReason.
+}
+
+// Records how the priority list is evaluated, so that users can see why a given
+// line did or did not fire. Only created when SimOptions.AplTrace is set.
+type aplTrace struct {
+	evaluations int64
+	noAction    int64
+	items       []aplItemTrace
+}
+
+func (rot *APLRotation) newAPLTrace() *aplTrace {
+	trace := &aplTrace{
+		items: make([]aplItemTrace, len(rot.priorityList)),
+	}
+	for i, action := range rot.priorityList {
+		trace.items[i] = aplItemTrace{
+			listIndex: int32(rot.configIdxs[i]),
+			action:    fmt.Sprintf("%s", action.impl),
+			blocked:   make([]int64, numCastFailureReasons),
+		}
+	}
+	return trace
+}
+
+// Traced equivalent of getNextAction. Evaluates the priority list in the same order,
+// recording the result of each condition and the reason any action was blocked.
+func (apl *APLRotation) getNextActionTraced(sim *Simulation) *APLAction {
+	if len(apl.controllingActions) != 0 {
+		return apl.getNextAction(sim)
+	}
+
+	for i, action := range apl.priorityList {
+		item := &apl.trace.items[i]
+		item.evaluations++
+
+		if action.condition != nil && !action.condition.GetBool(sim) {
+			if sim.Log != nil {
+				apl.unit.Log(sim, "APL #%d %s: condition false", item.listIndex+1, item.action)
+			}
+			continue
+		}
+		item.conditionTrue++
+
+		if action.impl.IsReady(sim) {
+			item.executions++
+			if sim.Log != nil {
+				apl.unit.Log(sim, "APL #%d %s: executing", item.listIndex+1, item.action)
+			}
+			return action
+		}
+
+		reason := apl.castFailureReason(sim, action)
+		item.blocked[reason]++
+		if sim.Log != nil {
+			apl.unit.Log(sim, "APL #%d %s: blocked by %s", item.listIndex+1, item.action, reason)
+		}
+	}
+
+	return nil
+}
+
+// Determines why an action whose condition passed was still not ready.
+func (apl *APLRotation) castFailureReason(sim *Simulation, action *APLAction) proto.CastFailureReason {
+	var spell *Spell
+	var target *Unit
+	switch impl := action.impl.(type) {
+	case *APLActionCastSpell:
+		spell, target = impl.spell, impl.target.Get()
+	case *APLActionChannelSpell:
+		spell, target = impl.spell, impl.target.Get()
+	default:
+		spell, target = impl.GetSpellFromAction(), apl.unit.CurrentTarget
+	}
+
+	if spell == nil {
+		return proto.CastFailureReason_CastFailureOther
+	}
+
+	if reason := spell.CastFailureReason(sim, target); reason != proto.CastFailureReason_CastFailureNone {
+		return reason
+	}
+	return proto.CastFailureReason_CastFailureOther
+}
+
+func (trace *aplTrace) ToProto() *proto.APLProfile {
+	profile := &proto.APLProfile{
+		Evaluations:  trace.evaluations,
+		NoAction:     trace.noAction,
+		PriorityList: make([]*proto.APLItemProfile, len(trace.items)),
+	}
+
+	for i, item := range trace.items {
+		itemProfile := &proto.APLItemProfile{
+			ListIndex:     item.listIndex,
+			Action:        item.action,
+			Evaluations:   item.evaluations,
+			ConditionTrue: item.conditionTrue,
+			Executions:    item.executions,
+		}
+		if item.evaluations > 0 {
+			itemProfile.ConditionTrueRate = float64(item.conditionTrue) / float64(item.evaluations)
+		}
+		for reason, count := range item.blocked {
+			if count > 0 {
+				itemProfile.Blocked = append(itemProfile.Blocked, &proto.APLBlockedCount{
+					Reason: proto.CastFailureReason(reason),
+					Count:  count,
+				})
+			}
+		}
+		profile.PriorityList[i] = itemProfile
+	}
+
+	return profile
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+type testAPLActionImpl struct {
+	defaultAPLActionImpl
+	ready bool
+}
+
+func (impl *testAPLActionImpl) IsReady(*Simulation) bool { return impl.ready }
+func (impl *testAPLActionImpl) Execute(*Simulation)      {}
+func (impl *testAPLActionImpl) String() string           { return "Test" }
+
+func TestAPLTrace(t *testing.T) {
+	sim := &Simulation{}
+	rot := &APLRotation{
+		unit: &Unit{},
+	}
+	rot.priorityList = []*APLAction{
+		{condition: rot.newValueConst(&proto.APLValueConst{Val: "false"}), impl: &testAPLActionImpl{ready: true}},
+		{impl: &testAPLActionImpl{ready: false}},
+		{impl: &testAPLActionImpl{ready: true}},
+	}
+	rot.configIdxs = []int{0, 2, 3}
+	rot.trace = rot.newAPLTrace()
+
+	for i := 0; i < 4; i++ {
+		if action := rot.getNextActionTraced(sim); action != rot.priorityList[2] {
+			t.Fatalf("Unexpected action selected: %s", action)
+		}
+	}
+
+	profile := rot.trace.ToProto()
+	first, second, third := profile.PriorityList[0], profile.PriorityList[1], profile.PriorityList[2]
+
+	if first.Evaluations != 4 || first.ConditionTrue != 0 || first.ConditionTrueRate != 0 || first.Executions != 0 {
+		t.Fatalf("Unexpected profile for first item: %s", first)
+	}
+	if second.ListIndex != 2 || second.ConditionTrueRate != 1 || len(second.Blocked) != 1 ||
+		second.Blocked[0].Reason != proto.CastFailureReason_CastFailureOther || second.Blocked[0].Count != 4 {
+		t.Fatalf("Unexpected profile for second item: %s", second)
+	}
+	if third.Executions != 4 {
+		t.Fatalf("Unexpected profile for third item: %s", third)
+	}
+}
@@ -95,12 +95,38 @@ func (rot *APLRotation) NewAPLValue(config *proto.APLValue) APLValue {
 		return rot.newValueNumberTargets(config.GetNumberTargets())
 	case *proto.APLValue_TargetMobType:
 		return rot.newValueTargetMobType(config.GetTargetMobType())
+	case *proto.APLValue_BossTimerRemaining:
+		return rot.newValueBossTimerRemaining(config.GetBossTimerRemaining())
+	case *proto.APLValue_BossPhase:
+		return rot.newValueBossPhase(config.GetBossPhase())
+	case *proto.APLValue_TargetIsCasting:
+		return rot.newValueTargetIsCasting(config.GetTargetIsCasting())
+	case *proto.APLValue_TargetTimeToDie:
+		return rot.newValueTargetTimeToDie(config.GetTargetTimeToDie())
+	case *proto.APLValue_TargetTimeToPercent:
+		return rot.newValueTargetTimeToPercent(config.GetTargetTimeToPercent())
+	case *proto.APLValue_TargetIsAlive:
+		return rot.newValueTargetIsAlive(config.GetTargetIsAlive())
+	case *proto.APLValue_IsTanking:
+		return rot.newValueIsTanking(config.GetIsTanking())
+	case *proto.APLValue_ThreatPercentOfTank:
+		return rot.newValueThreatPercentOfTank(config.GetThreatPercentOfTank())
+	case *proto.APLValue_ExternalBuffTimeToNext:
+		return rot.newValueExternalBuffTimeToNext(config.GetExternalBuffTimeToNext())
+	case *proto.APLValue_TargetIsCastingInterruptible:
+		return rot.newValueTargetIsCastingInterruptible(config.GetTargetIsCastingInterruptible())
+	case *proto.APLValue_HasDispellableDebuff:
+		return rot.newValueHasDispellableDebuff(config.GetHasDispellableDebuff())
 
 	// Resources
 	case *proto.APLValue_CurrentHealth:
 		return rot.newValueCurrentHealth(config.GetCurrentHealth())
 	case *proto.APLValue_CurrentHealthPercent:
 		return rot.newValueCurrentHealthPercent(config.GetCurrentHealthPercent())
+	case *proto.APLValue_MissingHealth:
+		return rot.newValueMissingHealth(config.GetMissingHealth())
+	case *proto.APLValue_NumInjuredAllies:
+		return rot.newValueNumInjuredAllies(config.GetNumInjuredAllies())
 	case *proto.APLValue_CurrentMana:
 		return rot.newValueCurrentMana(config.GetCurrentMana())
 	case *proto.APLValue_CurrentManaPercent:
@@ -57,7 +57,7 @@ func (value *APLValueAuraIsActive) String() string {
 type APLValueAuraIsActiveWithReactionTime struct {
 	DefaultAPLValueImpl
 	aura         AuraReference
-	reactionTime time.Duration
+	reactionTime *auraReactionTime
 }
 
 func (rot *APLRotation) newValueAuraIsActiveWithReactionTime(config *proto.APLValueAuraIsActiveWithReactionTime) APLValue {
@@ -67,7 +67,7 @@ func (rot *APLRotation) newValueAuraIsActiveWithReactionTime(config *proto.APLVa
 	}
 	return &APLValueAuraIsActiveWithReactionTime{
 		aura:         aura,
-		reactionTime: rot.unit.ReactionTime,
+		reactionTime: newAuraReactionTime(rot.unit),
 	}
 }
 func (value *APLValueAuraIsActiveWithReactionTime) Type() proto.APLValueType {
@@ -75,7 +75,7 @@ func (value *APLValueAuraIsActiveWithReactionTime) Type() proto.APLValueType {
 }
 func (value *APLValueAuraIsActiveWithReactionTime) GetBool(sim *Simulation) bool {
 	aura := value.aura.Get()
-	return aura.IsActive() && aura.TimeActive(sim) >= value.reactionTime
+	return aura.IsActive() && aura.TimeActive(sim) >= value.reactionTime.get(sim, aura)
 }
 func (value *APLValueAuraIsActiveWithReactionTime) String() string {
 	return fmt.Sprintf("Aura Active With Reaction Time(%s)", value.aura.String())
@@ -160,7 +160,7 @@ func (value *APLValueAuraInternalCooldown) String() string {
 type APLValueAuraICDIsReadyWithReactionTime struct {
 	DefaultAPLValueImpl
 	aura         AuraReference
-	reactionTime time.Duration
+	reactionTime *auraReactionTime
 }
 
 func (rot *APLRotation) newValueAuraICDIsReadyWithReactionTime(config *proto.APLValueAuraICDIsReadyWithReactionTime) APLValue {
@@ -170,7 +170,7 @@ func (rot *APLRotation) newValueAuraICDIsReadyWithReactionTime(config *proto.APL
 	}
 	return &APLValueAuraICDIsReadyWithReactionTime{
 		aura:         aura,
-		reactionTime: rot.unit.ReactionTime,
+		reactionTime: newAuraReactionTime(rot.unit),
 	}
 }
 func (value *APLValueAuraICDIsReadyWithReactionTime) Type() proto.APLValueType {
@@ -178,7 +178,7 @@ func (value *APLValueAuraICDIsReadyWithReactionTime) Type() proto.APLValueType {
 }
 func (value *APLValueAuraICDIsReadyWithReactionTime) GetBool(sim *Simulation) bool {
 	aura := value.aura.Get()
-	return aura.Icd.IsReady(sim) || (aura.IsActive() && aura.TimeActive(sim) < value.reactionTime)
+	return aura.Icd.IsReady(sim) || (aura.IsActive() && aura.TimeActive(sim) < value.reactionTime.get(sim, aura))
 }
 func (value *APLValueAuraICDIsReadyWithReactionTime) String() string {
 	return fmt.Sprintf("Aura ICD Is Ready with Reaction Time(%s)", value.aura.String())
@@ -218,3 +218,38 @@ func (value *APLValueAuraShouldRefresh) GetBool(sim *Simulation) bool {
 func (value *APLValueAuraShouldRefresh) String() string {
 	return fmt.Sprintf("Should Refresh Aura(%s)", value.aura.String())
 }
+
+type APLValueExternalBuffTimeToNext struct {
+	DefaultAPLValueImpl
+	actionID    ActionID
+	assignments []*CooldownAssignment
+}
+
+func (rot *APLRotation) newValueExternalBuffTimeToNext(config *proto.APLValueExternalBuffTimeToNext) APLValue {
+	actionID := ProtoToActionID(config.SpellId)
+	assignments := rot.unit.Env.Raid.GetCooldownAssignmentsOn(rot.unit, actionID)
+	if len(assignments) == 0 {
+		rot.ValidationWarning("No raid cooldown assignment casts %s on %s", actionID, rot.unit.Label)
+		return nil
+	}
+	return &APLValueExternalBuffTimeToNext{
+		actionID:    actionID,
+		assignments: assignments,
+	}
+}
+func (value *APLValueExternalBuffTimeToNext) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueExternalBuffTimeToNext) GetDuration(sim *Simulation) time.Duration {
+	nextCastAt := NeverExpires
+	for _, assignment := range value.assignments {
+		nextCastAt = min(nextCastAt, assignment.NextCastAt(sim))
+	}
+	if nextCastAt == NeverExpires {
+		return NeverExpires
+	}
+	return nextCastAt - sim.CurrentTime
+}
+func (value *APLValueExternalBuffTimeToNext) String() string {
+	return fmt.Sprintf("External Buff Time To Next(%s)", value.actionID)
+}
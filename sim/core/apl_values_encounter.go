@@ -152,3 +152,317 @@ func (value *APLValueTargetMobType) GetBool(sim *Simulation) bool {
 func (value *APLValueTargetMobType) String() string {
 	return fmt.Sprintf("Target Matches Mob Type (%s)", value.MobType)
 }
+
+type APLValueBossTimerRemaining struct {
+	DefaultAPLValueImpl
+	env       *Environment
+	target    UnitReference
+	timerName string
+}
+
+func (rot *APLRotation) newValueBossTimerRemaining(config *proto.APLValueBossTimerRemaining) APLValue {
+	if config.TimerName == "" {
+		rot.ValidationWarning("Boss Timer Remaining must provide a timer name")
+		return nil
+	}
+	target := rot.GetTargetUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	return &APLValueBossTimerRemaining{
+		env:       rot.unit.Env,
+		target:    target,
+		timerName: config.TimerName,
+	}
+}
+func (value *APLValueBossTimerRemaining) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueBossTimerRemaining) GetDuration(sim *Simulation) time.Duration {
+	if state := value.env.GetTargetAIEncounterState(value.target.Get()); state != nil {
+		if remaining, ok := state.GetTimerRemaining(sim, value.timerName); ok {
+			return max(0, remaining)
+		}
+	}
+	// Unknown timers won't happen during the rest of the fight.
+	return sim.GetRemainingDuration()
+}
+func (value *APLValueBossTimerRemaining) String() string {
+	return fmt.Sprintf("Boss Timer Remaining(%s)", value.timerName)
+}
+
+type APLValueBossPhase struct {
+	DefaultAPLValueImpl
+	env    *Environment
+	target UnitReference
+}
+
+func (rot *APLRotation) newValueBossPhase(config *proto.APLValueBossPhase) APLValue {
+	target := rot.GetTargetUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	return &APLValueBossPhase{
+		env:    rot.unit.Env,
+		target: target,
+	}
+}
+func (value *APLValueBossPhase) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeInt
+}
+func (value *APLValueBossPhase) GetInt(sim *Simulation) int32 {
+	if value.env.Encounter.HasPhases() {
+		return value.env.Encounter.CurrentPhase()
+	}
+	if state := value.env.GetTargetAIEncounterState(value.target.Get()); state != nil {
+		return state.GetPhase(sim)
+	}
+	return 1
+}
+func (value *APLValueBossPhase) String() string {
+	return "Boss Phase"
+}
+
+type APLValueTargetIsCasting struct {
+	DefaultAPLValueImpl
+	target   UnitReference
+	actionID ActionID
+}
+
+func (rot *APLRotation) newValueTargetIsCasting(config *proto.APLValueTargetIsCasting) APLValue {
+	target := rot.GetTargetUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	var actionID ActionID
+	if config.SpellId != nil {
+		actionID = ProtoToActionID(config.SpellId)
+	}
+	return &APLValueTargetIsCasting{
+		target:   target,
+		actionID: actionID,
+	}
+}
+func (value *APLValueTargetIsCasting) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueTargetIsCasting) GetBool(sim *Simulation) bool {
+	unit := value.target.Get()
+	if unit.IsCasting(sim) {
+		return value.actionID.IsEmptyAction() || unit.Hardcast.ActionID.SameActionIgnoreTag(value.actionID)
+	}
+	if unit.IsChanneling(sim) {
+		return value.actionID.IsEmptyAction() || unit.ChanneledDot.Spell.ActionID.SameActionIgnoreTag(value.actionID)
+	}
+	return false
+}
+func (value *APLValueTargetIsCasting) String() string {
+	return fmt.Sprintf("Target Is Casting(%s)", value.actionID)
+}
+
+type APLValueTargetTimeToDie struct {
+	DefaultAPLValueImpl
+	env    *Environment
+	target UnitReference
+}
+
+func (rot *APLRotation) newValueTargetTimeToDie(config *proto.APLValueTargetTimeToDie) APLValue {
+	target := rot.GetTargetUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	return &APLValueTargetTimeToDie{
+		env:    rot.unit.Env,
+		target: target,
+	}
+}
+func (value *APLValueTargetTimeToDie) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueTargetTimeToDie) GetDuration(sim *Simulation) time.Duration {
+	unit := value.target.Get()
+	if unit.Type != EnemyUnit {
+		return sim.GetRemainingDuration()
+	}
+	return value.env.GetTarget(unit.Index).TimeToDie(sim)
+}
+func (value *APLValueTargetTimeToDie) String() string {
+	return "Target Time To Die"
+}
+
+type APLValueTargetTimeToPercent struct {
+	DefaultAPLValueImpl
+	env     *Environment
+	target  UnitReference
+	percent float64
+}
+
+func (rot *APLRotation) newValueTargetTimeToPercent(config *proto.APLValueTargetTimeToPercent) APLValue {
+	if config.HealthPercent < 0 || config.HealthPercent > 100 {
+		rot.ValidationWarning("Health percent must be between 0 and 100")
+		return nil
+	}
+	target := rot.GetTargetUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	return &APLValueTargetTimeToPercent{
+		env:     rot.unit.Env,
+		target:  target,
+		percent: config.HealthPercent / 100,
+	}
+}
+func (value *APLValueTargetTimeToPercent) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueTargetTimeToPercent) GetDuration(sim *Simulation) time.Duration {
+	unit := value.target.Get()
+	if unit.Type != EnemyUnit {
+		return sim.GetRemainingDuration()
+	}
+	return value.env.GetTarget(unit.Index).TimeToPercent(sim, value.percent)
+}
+func (value *APLValueTargetTimeToPercent) String() string {
+	return fmt.Sprintf("Target Time To %.0f%%", value.percent*100)
+}
+
+type APLValueTargetIsAlive struct {
+	DefaultAPLValueImpl
+	env    *Environment
+	target UnitReference
+}
+
+func (rot *APLRotation) newValueTargetIsAlive(config *proto.APLValueTargetIsAlive) APLValue {
+	target := rot.GetTargetUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	return &APLValueTargetIsAlive{
+		env:    rot.unit.Env,
+		target: target,
+	}
+}
+func (value *APLValueTargetIsAlive) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueTargetIsAlive) GetBool(sim *Simulation) bool {
+	unit := value.target.Get()
+	if unit.Type != EnemyUnit {
+		return true
+	}
+	target := value.env.GetTarget(unit.Index)
+	return target.IsSpawned() && !target.IsDead()
+}
+func (value *APLValueTargetIsAlive) String() string {
+	return "Target Is Alive"
+}
+
+type APLValueTargetIsCastingInterruptible struct {
+	DefaultAPLValueImpl
+	target UnitReference
+}
+
+func (rot *APLRotation) newValueTargetIsCastingInterruptible(config *proto.APLValueTargetIsCastingInterruptible) APLValue {
+	target := rot.GetTargetUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	return &APLValueTargetIsCastingInterruptible{
+		target: target,
+	}
+}
+func (value *APLValueTargetIsCastingInterruptible) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueTargetIsCastingInterruptible) GetBool(sim *Simulation) bool {
+	return value.target.Get().IsCastingInterruptible(sim)
+}
+func (value *APLValueTargetIsCastingInterruptible) String() string {
+	return "Target Is Casting Interruptible"
+}
+
+type APLValueHasDispellableDebuff struct {
+	DefaultAPLValueImpl
+	target      UnitReference
+	dispelTypes []DispelType
+}
+
+func (rot *APLRotation) newValueHasDispellableDebuff(config *proto.APLValueHasDispellableDebuff) APLValue {
+	target := rot.GetSourceUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	dispelTypes := allDispelTypes
+	if config.DispelType != proto.DispelType_DispelTypeNone {
+		dispelTypes = []DispelType{DispelTypeFromProto(config.DispelType)}
+	}
+	return &APLValueHasDispellableDebuff{
+		target:      target,
+		dispelTypes: dispelTypes,
+	}
+}
+func (value *APLValueHasDispellableDebuff) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueHasDispellableDebuff) GetBool(sim *Simulation) bool {
+	return getDispellableAura(value.target.Get(), value.dispelTypes) != nil
+}
+func (value *APLValueHasDispellableDebuff) String() string {
+	return "Has Dispellable Debuff"
+}
+
+type APLValueIsTanking struct {
+	DefaultAPLValueImpl
+	unit   *Unit
+	target UnitReference
+}
+
+func (rot *APLRotation) newValueIsTanking(config *proto.APLValueIsTanking) APLValue {
+	target := rot.GetTargetUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	return &APLValueIsTanking{
+		unit:   rot.unit,
+		target: target,
+	}
+}
+func (value *APLValueIsTanking) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueIsTanking) GetBool(sim *Simulation) bool {
+	return value.target.Get().CurrentTarget == value.unit
+}
+func (value *APLValueIsTanking) String() string {
+	return "Is Tanking"
+}
+
+type APLValueThreatPercentOfTank struct {
+	DefaultAPLValueImpl
+	unit   *Unit
+	target UnitReference
+}
+
+func (rot *APLRotation) newValueThreatPercentOfTank(config *proto.APLValueThreatPercentOfTank) APLValue {
+	target := rot.GetTargetUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	return &APLValueThreatPercentOfTank{
+		unit:   rot.unit,
+		target: target,
+	}
+}
+func (value *APLValueThreatPercentOfTank) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueThreatPercentOfTank) GetFloat(sim *Simulation) float64 {
+	unit := value.target.Get()
+	if unit.Type != EnemyUnit {
+		return 0
+	}
+	return value.unit.Env.GetTarget(unit.Index).ThreatPercentOfTank(sim, value.unit)
+}
+func (value *APLValueThreatPercentOfTank) String() string {
+	return "Threat Percent of Tank"
+}
@@ -63,6 +63,65 @@ func (value *APLValueCurrentHealthPercent) String() string {
 	return fmt.Sprintf("Current Health %%")
 }
 
+type APLValueMissingHealth struct {
+	DefaultAPLValueImpl
+	unit UnitReference
+}
+
+func (rot *APLRotation) newValueMissingHealth(config *proto.APLValueMissingHealth) APLValue {
+	unit := rot.GetSourceUnit(config.SourceUnit)
+	if unit.Get() == nil {
+		return nil
+	}
+	if !unit.Get().HasHealthBar() {
+		rot.ValidationWarning("%s does not use Health", unit.Get().Label)
+		return nil
+	}
+	return &APLValueMissingHealth{
+		unit: unit,
+	}
+}
+func (value *APLValueMissingHealth) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueMissingHealth) GetFloat(_ *Simulation) float64 {
+	unit := value.unit.Get()
+	return unit.MaxHealth() - unit.CurrentHealth()
+}
+func (value *APLValueMissingHealth) String() string {
+	return "Missing Health"
+}
+
+type APLValueNumInjuredAllies struct {
+	DefaultAPLValueImpl
+	raid    *Raid
+	percent float64
+}
+
+func (rot *APLRotation) newValueNumInjuredAllies(config *proto.APLValueNumInjuredAllies) APLValue {
+	if config.HealthPercent < 0 || config.HealthPercent > 100 {
+		rot.ValidationWarning("Health percent must be between 0 and 100")
+		return nil
+	}
+	percent := config.HealthPercent / 100
+	if percent == 0 {
+		percent = 1
+	}
+	return &APLValueNumInjuredAllies{
+		raid:    rot.unit.Env.Raid,
+		percent: percent,
+	}
+}
+func (value *APLValueNumInjuredAllies) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeInt
+}
+func (value *APLValueNumInjuredAllies) GetInt(_ *Simulation) int32 {
+	return value.raid.NumInjuredAllies(value.percent)
+}
+func (value *APLValueNumInjuredAllies) String() string {
+	return fmt.Sprintf("Num Allies Below %.0f%% Health", value.percent*100)
+}
+
 type APLValueCurrentMana struct {
 	DefaultAPLValueImpl
 	unit UnitReference
@@ -351,6 +351,14 @@ func (wa *WeaponAttack) swing(sim *Simulation) time.Duration {
 
 	wa.castExtraAttacksStored(sim)
 
+	if wa.unit.Type == EnemyUnit {
+		wa.unit.Env.GetTarget(wa.unit.Index).updateAggro(sim)
+		if wa.unit.CurrentTarget == nil {
+			// Returned to the virtual tank, which stopped the auto attacks.
+			return NeverExpires
+		}
+	}
+
 	attackSpell := wa.spell
 
 	if wa.replaceSwing != nil {
@@ -764,6 +772,26 @@ func (aa *AutoAttacks) EnableAutoSwing(sim *Simulation) {
 	aa.EnableRangedSwing(sim)
 }
 
+// Re-enables the auto swing action the way a /startattack macro would, once the
+// command has reached the server.
+func (aa *AutoAttacks) StartAttack(sim *Simulation) {
+	if aa.mh.unit == nil {
+		return
+	}
+
+	latency := aa.mh.unit.NetworkLatency(sim)
+	if latency == 0 {
+		aa.EnableAutoSwing(sim)
+		return
+	}
+
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     sim.CurrentTime + latency,
+		Priority: ActionPriorityAuto,
+		OnAction: aa.EnableAutoSwing,
+	})
+}
+
 func (aa *AutoAttacks) EnableMeleeSwing(sim *Simulation) {
 	if !aa.AutoSwingMelee {
 		return
@@ -765,11 +765,8 @@ func applyBuffEffects(agent Agent, playerFaction proto.Faction, raidBuffs *proto
 		}
 		character.AddStats(updateStats)
 	} else if raidBuffs.ManaSpringTotem > 0 && isHorde {
-		updateStats := BuffSpellByLevel[ManaSpring][level]
-		if raidBuffs.ManaSpringTotem == proto.TristateEffect_TristateEffectImproved {
-			updateStats = updateStats.Multiply(1.25)
-		}
-		character.AddStats(updateStats)
+		multiplier := GetTristateValueFloat(raidBuffs.ManaSpringTotem, 1, 1.25)
+		MakePermanent(ManaSpringTotemAura(&character.Unit, level, multiplier, CharacterBuildPhaseBuffs))
 	}
 
 	if raidBuffs.VampiricTouch > 0 {
@@ -1975,6 +1972,35 @@ func GraceOfAirTotemAura(unit *Unit, level int32, multiplier float64, buildPhase
 	})
 }
 
+func ManaSpringTotemAura(unit *Unit, level int32, multiplier float64, buildPhase CharacterBuildPhase) *Aura {
+	label := "Mana Spring Totem"
+	spellID := map[int32]int32{
+		25: 5675,
+		40: 10495,
+		50: 10496,
+		60: 10497,
+	}[level]
+	updateStats := BuffSpellByLevel[ManaSpring][level].Multiply(multiplier)
+
+	aura := unit.GetAura(label)
+	if aura != nil {
+		return aura
+	}
+
+	return unit.RegisterAura(Aura{
+		Label:      label,
+		ActionID:   ActionID{SpellID: spellID},
+		Duration:   time.Minute,
+		BuildPhase: buildPhase,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			unit.AddBuildPhaseStatsDynamic(sim, updateStats)
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			unit.AddBuildPhaseStatsDynamic(sim, updateStats.Multiply(-1))
+		},
+	})
+}
+
 const BattleShoutRanks = 7
 
 var BattleShoutSpellId = [BattleShoutRanks + 1]int32{0, 6673, 5242, 6192, 11549, 11550, 11551, 25289}
@@ -2178,9 +2204,16 @@ func BattleSquawkAura(character *Unit, stackcount int32) *Aura {
 // }
 
 func CreateExtraAttackAuraCommon(character *Character, buffActionID ActionID, auraLabel string, rank int32, getBonusAP func(aura *Aura, rank int32) float64) *Aura {
+	apBuffAura, procAura := newExtraAttackAuras(&character.Unit, buffActionID, auraLabel, rank, getBonusAP)
+	MakePermanent(procAura)
+	return apBuffAura
+}
+
+// Returns the AP buff aura and the aura which procs it.
+func newExtraAttackAuras(unit *Unit, buffActionID ActionID, auraLabel string, rank int32, getBonusAP func(aura *Aura, rank int32) float64) (*Aura, *Aura) {
 	var bonusAP float64
 
-	apBuffAura := character.GetOrRegisterAura(Aura{
+	apBuffAura := unit.GetOrRegisterAura(Aura{
 		Label:     auraLabel + " Buff",
 		ActionID:  buffActionID,
 		Duration:  time.Millisecond * 1510,
@@ -2195,13 +2228,13 @@ func CreateExtraAttackAuraCommon(character *Character, buffActionID ActionID, au
 	})
 
 	icd := Cooldown{
-		Timer:    character.NewTimer(),
+		Timer:    unit.NewTimer(),
 		Duration: time.Millisecond * 1500,
 	}
 
 	apBuffAura.Icd = &icd
 
-	MakePermanent(character.GetOrRegisterAura(Aura{
+	procAura := unit.GetOrRegisterAura(Aura{
 		Label: auraLabel,
 		OnSpellHitDealt: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
 			// charges are removed by every auto or next melee, whether it lands or not
@@ -2228,9 +2261,9 @@ func CreateExtraAttackAuraCommon(character *Character, buffActionID ActionID, au
 				aura.Unit.AutoAttacks.ExtraMHAttackProc(sim, 1, buffActionID, spell)
 			}
 		},
-	}))
+	})
 
-	return apBuffAura
+	return apBuffAura, procAura
 }
 
 func GetWildStrikesAP(aura *Aura, rank int32) float64 {
@@ -2268,6 +2301,23 @@ func ApplyWindfury(character *Character) *Aura {
 
 }
 
+// Windfury from a totem dropped by a simulated shaman, which only lasts as long as the totem.
+func WindfuryTotemAura(unit *Unit, duration time.Duration) *Aura {
+	level := unit.Level
+	if level < 32 {
+		return nil
+	}
+
+	rank := LevelToBuffRank[Windfury][level]
+	buffActionID := ActionID{SpellID: WindfuryBuffSpellId[rank]}
+
+	_, procAura := newExtraAttackAuras(unit, buffActionID, "Windfury", rank, GetWindfuryAP)
+	if procAura.Duration != NeverExpires {
+		procAura.Duration = duration
+	}
+	return procAura
+}
+
 ///////////////////////////////////////////////////////////////////////////
 //                            World Buffs
 ///////////////////////////////////////////////////////////////////////////
@@ -13,10 +13,35 @@ import (
 type OnCastComplete func(aura *Aura, sim *Simulation, spell *Spell)
 
 type Hardcast struct {
-	Expires    time.Duration
-	ActionID   ActionID
-	OnComplete func(*Simulation, *Unit)
-	Target     *Unit
+	Expires       time.Duration
+	ActionID      ActionID
+	OnComplete    func(*Simulation, *Unit)
+	Target        *Unit
+	Interruptible bool
+}
+
+// Whether the unit is casting a spell which players can interrupt.
+func (unit *Unit) IsCastingInterruptible(sim *Simulation) bool {
+	return unit.IsCasting(sim) && unit.Hardcast.Interruptible
+}
+
+// Interrupts the current hardcast, if any. The spell has no effect and its cost isn't spent.
+func (unit *Unit) CancelHardcast(sim *Simulation) {
+	if !unit.IsCasting(sim) {
+		return
+	}
+
+	if sim.Log != nil {
+		unit.Log(sim, "Cast %s interrupted", unit.Hardcast.ActionID)
+	}
+
+	unit.Hardcast.Expires = startingCDTime
+	unit.Hardcast.OnComplete = nil
+	if unit.hardcastAction != nil && !unit.hardcastAction.consumed {
+		unit.hardcastAction.Cancel(sim)
+		unit.hardcastAction = nil
+	}
+	unit.SetGCDTimer(sim, sim.CurrentTime)
 }
 
 // Input for constructing the CastSpell function for a spell.
@@ -186,13 +211,16 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 			spell.CurCast.CastTime = config.CastTime(spell)
 		}
 
+		// The cast only starts once it reaches the server, which delays everything below.
+		castDelay := spell.castLatency(sim)
+
 		if config.CD.Timer != nil {
 			// By panicking if spell is on CD, we force each sim to properly check for their own CDs.
 			if !spell.CD.IsReady(sim) {
 				return spell.castFailureHelper(sim, "still on cooldown for %s, curTime = %s", spell.CD.TimeToReady(sim), sim.CurrentTime)
 			}
 
-			spell.CD.Set(sim.CurrentTime + spell.CurCast.CastTime + spell.CD.GetCurrentDuration())
+			spell.CD.Set(sim.CurrentTime + castDelay + spell.CurCast.CastTime + spell.CD.GetCurrentDuration())
 		}
 
 		if config.SharedCD.Timer != nil {
@@ -201,7 +229,7 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 				return spell.castFailureHelper(sim, "still on shared cooldown for %s, curTime = %s", spell.SharedCD.TimeToReady(sim), sim.CurrentTime)
 			}
 
-			spell.SharedCD.Set(sim.CurrentTime + spell.CurCast.CastTime + spell.SharedCD.Duration)
+			spell.SharedCD.Set(sim.CurrentTime + castDelay + spell.CurCast.CastTime + spell.SharedCD.Duration)
 		}
 
 		// By panicking if spell is on CD, we force each sim to properly check for their own CDs.
@@ -226,7 +254,9 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 			if !spell.Flags.Matches(SpellFlagChanneled) {
 				spell.SpellMetrics[target.UnitIndex].TotalCastTime += effectiveTime
 			}
-			spell.Unit.SetGCDTimer(sim, sim.CurrentTime+effectiveTime)
+			spell.Unit.SetGCDTimer(sim, sim.CurrentTime+castDelay+effectiveTime)
+		} else {
+			spell.delayNextAction(sim, castDelay)
 		}
 
 		if (spell.CurCast.CastTime > 0) && spell.Unit.IsMoving() {
@@ -235,7 +265,7 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 
 		// Non melee casts
 		if spell.Flags.Matches(SpellFlagResetAttackSwing) && spell.Unit.AutoAttacks.anyEnabled() {
-			restartSwingAt := sim.CurrentTime + spell.CurCast.CastTime
+			restartSwingAt := sim.CurrentTime + castDelay + spell.CurCast.CastTime
 			spell.Unit.AutoAttacks.StopMeleeUntil(sim, restartSwingAt, false)
 			spell.Unit.AutoAttacks.StopRangedUntil(sim, restartSwingAt)
 		}
@@ -248,7 +278,7 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 			}
 
 			spell.Unit.Hardcast = Hardcast{
-				Expires:  sim.CurrentTime + spell.CurCast.CastTime,
+				Expires:  sim.CurrentTime + castDelay + spell.CurCast.CastTime,
 				ActionID: spell.ActionID,
 				OnComplete: func(sim *Simulation, target *Unit) {
 					spell.LastCastAt = sim.CurrentTime
@@ -275,7 +305,8 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 						spell.Unit.Rotation.DoNextAction(sim)
 					}
 				},
-				Target: target,
+				Target:        target,
+				Interruptible: spell.Flags.Matches(SpellFlagInterruptible),
 			}
 
 			spell.Unit.newHardcastAction(sim)
@@ -317,13 +348,15 @@ func (spell *Spell) makeCastFuncSimple() CastSuccessFunc {
 			}
 		}
 
+		castDelay := spell.castLatency(sim)
+
 		if spell.CD.Timer != nil {
 			// By panicking if spell is on CD, we force each sim to properly check for their own CDs.
 			if !spell.CD.IsReady(sim) {
 				return spell.castFailureHelper(sim, "still on cooldown for %s, curTime = %s", spell.CD.TimeToReady(sim), sim.CurrentTime)
 			}
 
-			spell.CD.Set(sim.CurrentTime + spell.CD.GetCurrentDuration())
+			spell.CD.Set(sim.CurrentTime + castDelay + spell.CD.GetCurrentDuration())
 		}
 
 		if spell.SharedCD.Timer != nil {
@@ -332,7 +365,7 @@ func (spell *Spell) makeCastFuncSimple() CastSuccessFunc {
 				return spell.castFailureHelper(sim, "still on shared cooldown for %s, curTime = %s", spell.SharedCD.TimeToReady(sim), sim.CurrentTime)
 			}
 
-			spell.SharedCD.Set(sim.CurrentTime + spell.SharedCD.Duration)
+			spell.SharedCD.Set(sim.CurrentTime + castDelay + spell.SharedCD.Duration)
 		}
 
 		if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
@@ -341,6 +374,7 @@ func (spell *Spell) makeCastFuncSimple() CastSuccessFunc {
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
 
+		spell.delayNextAction(sim, castDelay)
 		spell.applyEffects(sim, target)
 
 		if !spell.Flags.Matches(SpellFlagNoOnCastComplete) {
@@ -359,6 +393,7 @@ func (spell *Spell) makeCastFuncAutosOrProcs() CastSuccessFunc {
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
 
+		spell.delayNextAction(sim, spell.castLatency(sim))
 		spell.applyEffects(sim, target)
 
 		if !spell.Flags.Matches(SpellFlagNoOnCastComplete) {
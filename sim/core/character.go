@@ -113,6 +113,7 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 
 			ReactionTime:            max(0, time.Duration(player.ReactionTimeMs)*time.Millisecond),
 			ChannelClipDelay:        max(0, time.Duration(player.ChannelClipDelayMs)*time.Millisecond),
+			LatencyModel:            newLatencyModel(player.LatencyModel),
 			DistanceFromTarget:      player.DistanceFromTarget,
 			StartDistanceFromTarget: player.DistanceFromTarget,
 		},
@@ -747,6 +748,10 @@ func (character *Character) GetMetricsProto() *proto.UnitMetrics {
 	metrics.UnitIndex = character.UnitIndex
 	metrics.Auras = character.auraTracker.GetMetricsProto()
 
+	if character.Rotation != nil && character.Rotation.trace != nil {
+		metrics.AplProfile = character.Rotation.trace.ToProto()
+	}
+
 	metrics.Pets = make([]*proto.UnitMetrics, len(character.Pets))
 	for i, pet := range character.Pets {
 		metrics.Pets[i] = pet.GetMetricsProto()
@@ -309,127 +309,6 @@ func exclusiveNatureDamageTakenAura(unit *Unit, label string, actionID ActionID)
 	return aura
 }
 
-func ExternalIsbCaster(_ *proto.Debuffs, target *Unit) {
-	isbConfig := target.Env.Raid.Parties[0].Players[0].GetCharacter().IsbConfig
-	baseStacks := TernaryInt32(isbConfig.hasShadowflameRune, ISBNumStacksShadowflame, ISBNumStacksBase)
-	isbAura := ImprovedShadowBoltAura(target, 5)
-	isbCrit := isbConfig.casterCrit / 100.0
-	var pa *PendingAction
-	MakePermanent(target.GetOrRegisterAura(Aura{
-		Label: "Isb External Proc Aura",
-		OnGain: func(aura *Aura, sim *Simulation) {
-			pa = NewPeriodicAction(sim, PeriodicActionOptions{
-				Period: DurationFromSeconds(isbConfig.shadowBoltFrequency),
-				OnAction: func(s *Simulation) {
-					for i := 0; i < int(isbConfig.isbWarlocks); i++ {
-						if sim.Proc(isbCrit, "External Isb Crit") {
-							isbAura.Activate(sim)
-							isbAura.SetStacks(sim, baseStacks)
-						} else if isbAura.IsActive() {
-							isbAura.RemoveStack(sim)
-						}
-					}
-				},
-			})
-			sim.AddPendingAction(pa)
-		},
-		OnExpire: func(aura *Aura, sim *Simulation) {
-			pa.Cancel(sim)
-		},
-	}))
-}
-
-type IsbConfig struct {
-	hasShadowflameRune  bool
-	shadowBoltFrequency float64
-	casterCrit          float64
-	isbWarlocks         int32
-	isbShadowPriests    int32
-}
-
-func (character *Character) createIsbConfig(player *proto.Player) {
-	character.IsbConfig = IsbConfig{
-		hasShadowflameRune:  player.IsbUsingShadowflame,
-		shadowBoltFrequency: player.IsbSbFrequency,
-		casterCrit:          player.IsbCrit,
-		isbWarlocks:         player.IsbWarlocks,
-		isbShadowPriests:    player.IsbSpriests,
-	}
-	//Defaults if not configured
-	if character.IsbConfig.shadowBoltFrequency == 0.0 {
-		character.IsbConfig.shadowBoltFrequency = 3.0
-	}
-	if character.IsbConfig.casterCrit == 0.0 {
-		character.IsbConfig.casterCrit = 25.0
-	}
-	if character.IsbConfig.isbWarlocks == 0 {
-		character.IsbConfig.isbWarlocks = 1
-	}
-}
-
-const (
-	ISBNumStacksBase        = 4
-	ISBNumStacksShadowflame = 30
-)
-
-func ImprovedShadowBoltAura(unit *Unit, rank int32) *Aura {
-	isbLabel := "Improved Shadow Bolt"
-	if unit.GetAura(isbLabel) != nil {
-		return unit.GetAura(isbLabel)
-	}
-
-	isbConfig := unit.Env.Raid.Parties[0].Players[0].GetCharacter().IsbConfig
-
-	priestGcds := []bool{false, true, true, true, true, true}
-	priestCurGcd := 0
-	externalShadowPriests := isbConfig.isbShadowPriests
-	var priestPa *PendingAction
-
-	damageMulti := 1. + 0.04*float64(rank)
-	aura := unit.GetOrRegisterAura(Aura{
-		Label:     isbLabel,
-		ActionID:  ActionID{SpellID: 17800},
-		Duration:  12 * time.Second,
-		MaxStacks: 30,
-		OnReset: func(aura *Aura, sim *Simulation) {
-			// External shadow priests simulation
-			if externalShadowPriests > 0 {
-				priestCurGcd = 0
-				priestPa = NewPeriodicAction(sim, PeriodicActionOptions{
-					Period: GCDDefault,
-					OnAction: func(s *Simulation) {
-						if priestGcds[priestCurGcd] {
-							for i := 0; i < int(externalShadowPriests); i++ {
-								if aura.IsActive() {
-									aura.RemoveStack(sim)
-								}
-							}
-						}
-						priestCurGcd++
-						if priestCurGcd >= len(priestGcds) {
-							priestCurGcd = 0
-						}
-					},
-				})
-				sim.AddPendingAction(priestPa)
-			}
-		},
-		OnGain: func(aura *Aura, sim *Simulation) {
-			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexShadow] *= damageMulti
-		},
-		OnExpire: func(aura *Aura, sim *Simulation) {
-			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexShadow] /= damageMulti
-		},
-		OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
-			if spell.SpellSchool.Matches(SpellSchoolShadow) && result.Landed() && result.Damage > 0 {
-				aura.RemoveStack(sim)
-			}
-		},
-	})
-
-	return aura
-}
-
 var ShadowWeavingSpellIDs = [6]int32{0, 15257, 15331, 15332, 15333, 15334}
 
 func ShadowWeavingAura(unit *Unit, rank int) *Aura {
@@ -218,12 +218,12 @@ func (dot *Dot) TickOnce(sim *Simulation) {
 		// Note: even if the clip delay is 0ms, need a WaitUntil so that APL is called after the channel aura fully fades.
 		if dot.MaxTicksRemaining() == 0 {
 			if dot.Spell.Unit.GCD.IsReady(sim) {
-				dot.Spell.Unit.WaitUntil(sim, sim.CurrentTime+dot.Spell.Unit.ChannelClipDelay)
+				dot.Spell.Unit.WaitUntil(sim, sim.CurrentTime+dot.Spell.Unit.channelClipDelay(sim))
 			}
 		} else if dot.Spell.Unit.Rotation.shouldInterruptChannel(sim) {
 			dot.Cancel(sim)
 			if dot.Spell.Unit.GCD.IsReady(sim) {
-				dot.Spell.Unit.WaitUntil(sim, sim.CurrentTime+dot.Spell.Unit.ChannelClipDelay)
+				dot.Spell.Unit.WaitUntil(sim, sim.CurrentTime+dot.Spell.Unit.channelClipDelay(sim))
 			}
 		}
 	}
@@ -25,8 +25,12 @@ func init() {
 }
 
 type FakeAgent struct {
-	Spell *Spell
-	Dot   *Dot
+	Spell   *Spell
+	Dot     *Dot
+	Nuke    *Spell
+	Channel *Spell
+	Heal    *Spell
+	Shield  *Spell
 	Character
 	Init func()
 }
@@ -88,13 +92,110 @@ func NewFakeElementalShaman(char *Character, _ *proto.Player) Agent {
 			},
 		})
 		fa.Dot = fa.Spell.CurDot()
+
+		fa.Nuke = fa.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: 43},
+			SpellSchool: SpellSchoolShadow,
+			ProcMask:    ProcMaskSpellDamage,
+			Flags:       SpellFlagIgnoreResists,
+			Cast: CastConfig{
+				DefaultCast: Cast{
+					CastTime: time.Second * 2,
+				},
+			},
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.CalcAndDealDamage(sim, target, 100, spell.OutcomeAlwaysHit)
+			},
+		})
+
+		fa.Channel = fa.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: 44},
+			SpellSchool: SpellSchoolShadow,
+			ProcMask:    ProcMaskSpellDamage,
+			Flags:       SpellFlagIgnoreResists | SpellFlagChanneled,
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+
+			Dot: DotConfig{
+				Aura: Aura{
+					Label: "fakechannel",
+				},
+				NumberOfTicks: 3,
+				TickLength:    time.Second,
+				OnTick: func(sim *Simulation, target *Unit, dot *Dot) {
+					dot.Spell.CalcAndDealPeriodicDamage(sim, target, 50, dot.OutcomeTick)
+				},
+			},
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.Dot(target).Apply(sim)
+			},
+		})
+
+		fa.Heal = fa.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: 45},
+			SpellSchool: SpellSchoolNature,
+			ProcMask:    ProcMaskSpellHealing,
+			Flags:       SpellFlagHelpful,
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.CalcAndDealHealing(sim, target, 1000, spell.OutcomeHealing)
+			},
+		})
+
+		fa.Shield = fa.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: 46},
+			SpellSchool: SpellSchoolHoly,
+			ProcMask:    ProcMaskSpellHealing,
+			Flags:       SpellFlagHelpful,
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+
+			Shield: ShieldConfig{
+				SelfOnly:     true,
+				AbsorbDamage: true,
+				Aura: Aura{
+					Label:    "fakeshield",
+					Duration: time.Second * 10,
+				},
+			},
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.SelfShield().Apply(sim, 1000)
+			},
+		})
 	}
 
 	return fa
 }
 
 func SetupFakeSim() *Simulation {
-	sim := NewSim(&proto.RaidSimRequest{
+	return SetupFakeSimWithEncounter(&proto.Encounter{
+		Targets: []*proto.Target{
+			{Name: "target", Level: 63, MobType: proto.MobType_MobTypeDemon},
+		},
+		Duration: 180,
+	})
+}
+
+func SetupFakeSimWithEncounter(encounter *proto.Encounter) *Simulation {
+	sim := NewSim(fakeSimRequest(encounter), simsignals.CreateSignals())
+	sim.Reset()
+
+	return sim
+}
+
+func fakeSimRequest(encounter *proto.Encounter) *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
 		SimOptions: &proto.SimOptions{
 			RandomSeed: 100,
 		},
@@ -115,16 +216,57 @@ func SetupFakeSim() *Simulation {
 				},
 			},
 		},
-		Encounter: &proto.Encounter{
-			Targets: []*proto.Target{
-				{Name: "target", Level: 63, MobType: proto.MobType_MobTypeDemon},
-			},
-			Duration: 180,
-		},
-	}, simsignals.CreateSignals())
+		Encounter: encounter,
+	}
+}
+
+// Like fakeSimRequest, with numPlayers fake agents in the first party.
+func fakeRaidSimRequest(encounter *proto.Encounter, numPlayers int) *proto.RaidSimRequest {
+	rsr := fakeSimRequest(encounter)
+	party := rsr.Raid.Parties[0]
+	for len(party.Players) < numPlayers {
+		party.Players = append(party.Players, fakeSimRequest(nil).Raid.Parties[0].Players[0])
+	}
+	return rsr
+}
+
+// Makes the first numTanks players in the first party tanks.
+func addFakeSimTanks(rsr *proto.RaidSimRequest, numTanks int) {
+	for i := 0; i < numTanks; i++ {
+		rsr.Raid.Tanks = append(rsr.Raid.Tanks, &proto.UnitReference{Type: proto.UnitReference_Player, Index: int32(i)})
+	}
+}
+
+// Creates and resets a sim for the request. Also returns the fake agents in the first party.
+func setupFakeSimWithRequest(rsr *proto.RaidSimRequest) (*Simulation, []*FakeAgent) {
+	sim := NewSim(rsr, simsignals.CreateSignals())
 	sim.Reset()
 
-	return sim
+	var agents []*FakeAgent
+	for _, player := range sim.Raid.Parties[0].Players {
+		if fa, ok := player.(*FakeAgent); ok {
+			agents = append(agents, fa)
+		}
+	}
+	return sim, agents
+}
+
+func newFakeSimTarget(abilities ...*proto.TargetAbility) *proto.Target {
+	return &proto.Target{Name: "target", Level: 63, MobType: proto.MobType_MobTypeDemon, Abilities: abilities}
+}
+
+// Runs the sim's pending actions up to and including those at the given time.
+func runFakeSimUntil(sim *Simulation, until time.Duration) {
+	done := false
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     until,
+		Priority: ActionPriorityLow,
+		OnAction: func(sim *Simulation) {
+			done = true
+		},
+	})
+	for !done && !sim.Step() {
+	}
 }
 
 func expectDotTickDamage(t *testing.T, sim *Simulation, dot *Dot, expectedDamage float64) {
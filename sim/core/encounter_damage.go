@@ -0,0 +1,147 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Damage dealt to players by the encounter, as opposed to the targets' attacks which
+// mostly hit the tanks.
+type encounterDamage struct {
+	events []*proto.EncounterDamageEvent
+	spells []*Spell
+	units  []*Unit
+
+	// Scratch space for picking random players.
+	shuffled []*Unit
+}
+
+func newEncounterDamage(options *proto.Encounter) *encounterDamage {
+	events := append(damageProfileEvents(options.DamageProfile), options.DamageEvents...)
+	if len(events) == 0 {
+		return nil
+	}
+	return &encounterDamage{
+		events: events,
+	}
+}
+
+func damageProfileEvents(profile proto.EncounterDamageProfile) []*proto.EncounterDamageEvent {
+	switch profile {
+	case proto.EncounterDamageProfile_DamageProfileLight:
+		return []*proto.EncounterDamageEvent{
+			{TimeSeconds: 10, IntervalSeconds: 20, School: proto.SpellSchool_SpellSchoolNature, MinDamage: 135, MaxDamage: 165},
+		}
+	case proto.EncounterDamageProfile_DamageProfileMedium:
+		return []*proto.EncounterDamageEvent{
+			{TimeSeconds: 10, IntervalSeconds: 15, School: proto.SpellSchool_SpellSchoolShadow, MinDamage: 225, MaxDamage: 275},
+			{TimeSeconds: 5, IntervalSeconds: 10, Targets: proto.EncounterDamageTargets_DamageTargetsRandomPlayers, School: proto.SpellSchool_SpellSchoolPhysical, MinDamage: 720, MaxDamage: 880},
+		}
+	case proto.EncounterDamageProfile_DamageProfileHeavy:
+		return []*proto.EncounterDamageEvent{
+			{TimeSeconds: 5, IntervalSeconds: 10, School: proto.SpellSchool_SpellSchoolFire, MinDamage: 360, MaxDamage: 440},
+			{TimeSeconds: 4, IntervalSeconds: 8, Targets: proto.EncounterDamageTargets_DamageTargetsRandomPlayers, NumPlayers: 2, School: proto.SpellSchool_SpellSchoolPhysical, MinDamage: 720, MaxDamage: 880},
+			{TimeSeconds: 60, IntervalSeconds: 90, School: proto.SpellSchool_SpellSchoolShadow, MinDamage: 1800, MaxDamage: 2200},
+		}
+	}
+	return nil
+}
+
+// Whether players take damage from the encounter besides the targets' attacks.
+func (encounter *Encounter) HasDamageEvents() bool {
+	return encounter.damage != nil
+}
+
+func (encounter *Encounter) initializeDamage(env *Environment) {
+	damage := encounter.damage
+	if damage == nil {
+		return
+	}
+
+	damage.units = env.Raid.AllPlayerUnits
+	damage.shuffled = make([]*Unit, len(damage.units))
+
+	// The damage comes from the primary target, so that its level is used for hit and resist rolls.
+	source := &encounter.AllTargets[0].Unit
+	for i, event := range damage.events {
+		actionID := ActionID{OtherID: proto.OtherAction_OtherActionEncounterDamage, Tag: int32(i + 1)}
+		if event.SpellId != 0 {
+			actionID = ActionID{SpellID: event.SpellId}
+		}
+
+		school := SpellSchoolFromProto(event.School)
+		defenseType := DefenseTypeMagic
+		procMask := ProcMaskSpellDamage
+		if school == SpellSchoolPhysical {
+			defenseType = DefenseTypeMelee
+			procMask = ProcMaskMeleeMHSpecial
+		}
+
+		damage.spells = append(damage.spells, source.GetOrRegisterSpell(SpellConfig{
+			ActionID:         actionID,
+			SpellSchool:      school,
+			DefenseType:      defenseType,
+			ProcMask:         procMask,
+			Flags:            SpellFlagMeleeMetrics | SpellFlagNoOnCastComplete | SpellFlagPassiveSpell,
+			DamageMultiplier: 1,
+		}))
+	}
+}
+
+func (encounter *Encounter) resetDamage(sim *Simulation) {
+	damage := encounter.damage
+	if damage == nil {
+		return
+	}
+
+	for i, event := range damage.events {
+		damage.scheduleEvent(sim, event, damage.spells[i], DurationFromSeconds(max(0, event.TimeSeconds)))
+	}
+}
+
+func (damage *encounterDamage) scheduleEvent(sim *Simulation, event *proto.EncounterDamageEvent, spell *Spell, at time.Duration) {
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     at,
+		Priority: ActionPriorityDOT,
+		OnAction: func(sim *Simulation) {
+			if event.IntervalSeconds > 0 {
+				damage.scheduleEvent(sim, event, spell, sim.CurrentTime+DurationFromSeconds(event.IntervalSeconds))
+			}
+			damage.dealEvent(sim, event, spell)
+		},
+	})
+}
+
+func (damage *encounterDamage) dealEvent(sim *Simulation, event *proto.EncounterDamageEvent, spell *Spell) {
+	outcome := spell.OutcomeMagicHit
+	if spell.SpellSchool == SpellSchoolPhysical {
+		// Raid damage can't be dodged or parried, but is still reduced by armor.
+		outcome = spell.OutcomeAlwaysHit
+	}
+
+	for _, unit := range damage.eventTargets(sim, event) {
+		spell.CalcAndDealDamage(sim, unit, sim.Roll(event.MinDamage, max(event.MinDamage, event.MaxDamage)), outcome)
+	}
+}
+
+// Dead players are skipped, so random picks always land on living players.
+func (damage *encounterDamage) eventTargets(sim *Simulation, event *proto.EncounterDamageEvent) []*Unit {
+	alive := damage.shuffled[:0]
+	for _, unit := range damage.units {
+		if !unit.IsDead() {
+			alive = append(alive, unit)
+		}
+	}
+	if event.Targets != proto.EncounterDamageTargets_DamageTargetsRandomPlayers {
+		return alive
+	}
+
+	numPlayers := min(max(int(event.NumPlayers), 1), len(alive))
+	for i := 0; i < numPlayers; i++ {
+		j := i + int(sim.RandomFloat("Encounter Damage")*float64(len(alive)-i))
+		j = min(j, len(alive)-1)
+		alive[i], alive[j] = alive[j], alive[i]
+	}
+	return alive[:numPlayers]
+}
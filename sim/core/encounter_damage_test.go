@@ -0,0 +1,183 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func setupEncounterDamageSim(numPlayers int, events ...*proto.EncounterDamageEvent) (*Simulation, []*FakeAgent) {
+	return setupFakeSimWithRequest(fakeRaidSimRequest(&proto.Encounter{
+		Targets:      []*proto.Target{newFakeSimTarget()},
+		Duration:     180,
+		DamageEvents: events,
+	}, numPlayers))
+}
+
+func encounterDamageTaken(sim *Simulation, eventIdx int, fa *FakeAgent) (float64, int32) {
+	metrics := sim.Encounter.damage.spells[eventIdx].SpellMetrics[fa.UnitIndex]
+	return metrics.TotalDamage, metrics.Hits
+}
+
+func TestEncounterDamageProfiles(t *testing.T) {
+	for profile, numEvents := range map[proto.EncounterDamageProfile]int{
+		proto.EncounterDamageProfile_DamageProfileNone:   0,
+		proto.EncounterDamageProfile_DamageProfileLight:  1,
+		proto.EncounterDamageProfile_DamageProfileMedium: 2,
+		proto.EncounterDamageProfile_DamageProfileHeavy:  3,
+	} {
+		if events := damageProfileEvents(profile); len(events) != numEvents {
+			t.Fatalf("Expected %d events for %s, got %d", numEvents, profile, len(events))
+		}
+	}
+
+	if newEncounterDamage(&proto.Encounter{}) != nil {
+		t.Fatalf("Expected no encounter damage without a profile or events")
+	}
+
+	// Custom events are added to the profile's events.
+	damage := newEncounterDamage(&proto.Encounter{
+		DamageProfile: proto.EncounterDamageProfile_DamageProfileMedium,
+		DamageEvents:  []*proto.EncounterDamageEvent{{TimeSeconds: 30}},
+	})
+	if len(damage.events) != 3 || damage.events[2].TimeSeconds != 30 {
+		t.Fatalf("Expected the custom event after the profile events")
+	}
+}
+
+func TestEncounterDamageEvents(t *testing.T) {
+	sim, agents := setupEncounterDamageSim(2, &proto.EncounterDamageEvent{
+		TimeSeconds:     5,
+		IntervalSeconds: 10,
+		School:          proto.SpellSchool_SpellSchoolPhysical,
+		MinDamage:       500,
+		MaxDamage:       500,
+	})
+
+	runFakeSimUntil(sim, time.Second*25)
+	for _, fa := range agents {
+		// Physical damage always hits, but is reduced by the player's armor.
+		armor := fa.Armor()
+		expectedDamage := 3 * 500 * (1 - armor/(armor+400+85*63))
+
+		damageTaken, hits := encounterDamageTaken(sim, 0, fa)
+		if hits != 3 || !WithinToleranceFloat64(expectedDamage, damageTaken, 0.01) {
+			t.Fatalf("Expected 3 hits for %0.3f damage on %s, got %d for %0.3f", expectedDamage, fa.Label, hits, damageTaken)
+		}
+		// Players who aren't tanking still lose health to encounter damage.
+		if !WithinToleranceFloat64(fa.MaxHealth()-damageTaken, fa.CurrentHealth(), 0.01) {
+			t.Fatalf("Expected %s to lose %0.3f health, has %0.3f of %0.3f", fa.Label, damageTaken, fa.CurrentHealth(), fa.MaxHealth())
+		}
+	}
+
+	fa := agents[0]
+	damageBefore, _ := encounterDamageTaken(sim, 0, fa)
+	fa.AddStatDynamic(sim, stats.Armor, 3000)
+	runFakeSimUntil(sim, time.Second*35)
+	damageAfter, _ := encounterDamageTaken(sim, 0, fa)
+	if damageAfter-damageBefore >= damageBefore/3 {
+		t.Fatalf("Expected more armor to reduce the damage taken, took %0.3f", damageAfter-damageBefore)
+	}
+}
+
+func TestEncounterDamageResistances(t *testing.T) {
+	event := &proto.EncounterDamageEvent{
+		IntervalSeconds: 1,
+		School:          proto.SpellSchool_SpellSchoolFire,
+		MinDamage:       1000,
+		MaxDamage:       1000,
+	}
+
+	sim, agents := setupEncounterDamageSim(1, event)
+	runFakeSimUntil(sim, time.Second*99)
+	unresisted, hits := encounterDamageTaken(sim, 0, agents[0])
+	if hits == 0 || unresisted <= 0 {
+		t.Fatalf("Expected fire damage without any resistance")
+	}
+
+	// 315 fire resistance is the cap against a level 63 target, which resists 75% on average.
+	sim, agents = setupEncounterDamageSim(1, event)
+	agents[0].AddStatDynamic(sim, stats.FireResistance, 315)
+	runFakeSimUntil(sim, time.Second*99)
+	// Players stop taking damage once dead, so compare the damage per hit.
+	resisted, resistedHits := encounterDamageTaken(sim, 0, agents[0])
+	perHit, resistedPerHit := unresisted/float64(hits), resisted/float64(resistedHits)
+	if resistedPerHit <= 0 || resistedPerHit > perHit*0.35 {
+		t.Fatalf("Expected fire resistance to reduce the damage taken to about 25%%, took %0.3f of %0.3f per hit", resistedPerHit, perHit)
+	}
+}
+
+func TestEncounterDamageRandomPlayers(t *testing.T) {
+	sim, agents := setupEncounterDamageSim(5, &proto.EncounterDamageEvent{
+		TimeSeconds: 1000,
+		Targets:     proto.EncounterDamageTargets_DamageTargetsRandomPlayers,
+	})
+	damage := sim.Encounter.damage
+
+	for numPlayers, expected := range map[int32]int{0: 1, 2: 2, 10: 5} {
+		event := &proto.EncounterDamageEvent{Targets: proto.EncounterDamageTargets_DamageTargetsRandomPlayers, NumPlayers: numPlayers}
+		if targets := damage.eventTargets(sim, event); len(targets) != expected {
+			t.Fatalf("Expected %d targets for %d players, got %d", expected, numPlayers, len(targets))
+		}
+	}
+
+	event := &proto.EncounterDamageEvent{Targets: proto.EncounterDamageTargets_DamageTargetsRandomPlayers, NumPlayers: 2}
+	timesPicked := make(map[*Unit]int)
+	for i := 0; i < 100; i++ {
+		targets := damage.eventTargets(sim, event)
+		if targets[0] == targets[1] {
+			t.Fatalf("Expected 2 different players, got %s twice", targets[0].Label)
+		}
+		for _, unit := range targets {
+			timesPicked[unit]++
+		}
+	}
+	for _, fa := range agents {
+		if timesPicked[&fa.Unit] < 20 {
+			t.Fatalf("Expected %s to be picked about 40 times out of 100, got %d", fa.Label, timesPicked[&fa.Unit])
+		}
+	}
+
+	// Events without a target type hit every player.
+	if targets := damage.eventTargets(sim, &proto.EncounterDamageEvent{}); len(targets) != 5 {
+		t.Fatalf("Expected all 5 players to be hit, got %d", len(targets))
+	}
+
+	// Dead players aren't hit.
+	for _, fa := range agents[:4] {
+		fa.Metrics.Died = true
+	}
+	if targets := damage.eventTargets(sim, &proto.EncounterDamageEvent{}); len(targets) != 1 || targets[0] != &agents[4].Unit {
+		t.Fatalf("Expected only the living player to be hit, got %d players", len(targets))
+	}
+	if targets := damage.eventTargets(sim, event); len(targets) != 1 || targets[0] != &agents[4].Unit {
+		t.Fatalf("Expected only the living player to be picked, got %d players", len(targets))
+	}
+}
+
+func TestEncounterDamageAbsorbedByShields(t *testing.T) {
+	sim, agents := setupEncounterDamageSim(1, &proto.EncounterDamageEvent{
+		TimeSeconds:     1,
+		IntervalSeconds: 1,
+		School:          proto.SpellSchool_SpellSchoolShadow,
+		MinDamage:       600,
+		MaxDamage:       600,
+	})
+	fa := agents[0]
+	fa.Shield.Cast(sim, &fa.Unit)
+
+	runFakeSimUntil(sim, time.Millisecond*1500)
+	if fa.CurrentHealth() != fa.MaxHealth() {
+		t.Fatalf("Expected the shield to absorb the first hit")
+	}
+
+	// Partial resists can leave the shield up for more hits, until it has absorbed 1000 damage.
+	runFakeSimUntil(sim, time.Millisecond*5500)
+	damageTaken, _ := encounterDamageTaken(sim, 0, fa)
+	absorbed := fa.Shield.SpellMetrics[fa.UnitIndex].TotalAbsorbedShielding
+	if absorbed != 1000 || !WithinToleranceFloat64(fa.MaxHealth()-damageTaken, fa.CurrentHealth(), 0.01) {
+		t.Fatalf("Expected 1000 damage absorbed, absorbed %0.3f and took %0.3f", absorbed, damageTaken)
+	}
+}
@@ -0,0 +1,219 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Players within this distance of the target at the start of the fight count as melee.
+const encounterMovementMeleeRange = 10
+
+// Movement and downtime forced on players by the encounter, as opposed to movement
+// chosen by the player's APL.
+type encounterMovement struct {
+	events []*proto.EncounterMovementEvent
+	units  []*Unit
+}
+
+func newEncounterMovement(options *proto.Encounter) *encounterMovement {
+	events := append(movementProfileEvents(options.MovementProfile), options.MovementEvents...)
+	if len(events) == 0 {
+		return nil
+	}
+	return &encounterMovement{
+		events: events,
+	}
+}
+
+func movementProfileEvents(profile proto.EncounterMovementProfile) []*proto.EncounterMovementEvent {
+	switch profile {
+	case proto.EncounterMovementProfile_MovementProfileLight:
+		return []*proto.EncounterMovementEvent{
+			{TimeSeconds: 30, IntervalSeconds: 60, MoveYards: 10},
+		}
+	case proto.EncounterMovementProfile_MovementProfileMedium:
+		return []*proto.EncounterMovementEvent{
+			{TimeSeconds: 20, IntervalSeconds: 30, MoveYards: 15},
+			{TimeSeconds: 60, IntervalSeconds: 90, DowntimeSeconds: 2},
+		}
+	case proto.EncounterMovementProfile_MovementProfileHeavy:
+		return []*proto.EncounterMovementEvent{
+			{TimeSeconds: 10, IntervalSeconds: 15, MoveYards: 20},
+			{TimeSeconds: 30, IntervalSeconds: 45, DowntimeSeconds: 3},
+		}
+	}
+	return nil
+}
+
+func (encounter *Encounter) initializeMovement(env *Environment) {
+	movement := encounter.movement
+	if movement == nil {
+		return
+	}
+
+	movement.units = env.Raid.AllPlayerUnits
+	// Movement handlers are only created when units are finalized.
+	env.RegisterPostFinalizeEffect(func() {
+		for _, unit := range movement.units {
+			unit.registerForcedMovementAura()
+		}
+	})
+
+	var bossEvents []*proto.EncounterMovementEvent
+	for _, event := range movement.events {
+		if event.BossSpellId != 0 {
+			bossEvents = append(bossEvents, event)
+		}
+	}
+	if len(bossEvents) == 0 {
+		return
+	}
+
+	for _, target := range encounter.AllTargets {
+		MakePermanent(target.RegisterAura(Aura{
+			Label: "Encounter Movement Triggers",
+			OnCastComplete: func(aura *Aura, sim *Simulation, spell *Spell) {
+				for _, event := range bossEvents {
+					if spell.ActionID.SpellID == event.BossSpellId {
+						movement.startEvent(sim, event)
+					}
+				}
+			},
+		}))
+	}
+}
+
+func (encounter *Encounter) resetMovement(sim *Simulation) {
+	movement := encounter.movement
+	if movement == nil {
+		return
+	}
+
+	for _, event := range movement.events {
+		if event.BossSpellId == 0 {
+			movement.scheduleEvent(sim, event, DurationFromSeconds(max(0, event.TimeSeconds)))
+		}
+	}
+}
+
+func (movement *encounterMovement) scheduleEvent(sim *Simulation, event *proto.EncounterMovementEvent, at time.Duration) {
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     at,
+		Priority: ActionPriorityDOT,
+		OnAction: func(sim *Simulation) {
+			if event.IntervalSeconds > 0 {
+				movement.scheduleEvent(sim, event, sim.CurrentTime+DurationFromSeconds(event.IntervalSeconds))
+			}
+			movement.startEvent(sim, event)
+		},
+	})
+}
+
+func (movement *encounterMovement) startEvent(sim *Simulation, event *proto.EncounterMovementEvent) {
+	downtime := DurationFromSeconds(max(0, event.DowntimeSeconds))
+	moveYards := max(0, event.MoveYards)
+	if downtime == 0 && moveYards == 0 {
+		return
+	}
+
+	switch event.Targets {
+	case proto.EncounterMovementTargets_MovementTargetsRandomPlayer:
+		idx := int(sim.RandomFloat("Encounter Movement") * float64(len(movement.units)))
+		movement.units[min(idx, len(movement.units)-1)].applyForcedMovement(sim, downtime, moveYards)
+	default:
+		for _, unit := range movement.units {
+			isMelee := unit.StartDistanceFromTarget <= encounterMovementMeleeRange
+			if (event.Targets == proto.EncounterMovementTargets_MovementTargetsMelee && !isMelee) ||
+				(event.Targets == proto.EncounterMovementTargets_MovementTargetsRanged && isMelee) {
+				continue
+			}
+			unit.applyForcedMovement(sim, downtime, moveYards)
+		}
+	}
+}
+
+func (unit *Unit) registerForcedMovementAura() {
+	mh := unit.MovementHandler
+
+	mh.forcedAura = unit.RegisterAura(Aura{
+		Label:    "Forced Movement",
+		ActionID: ActionID{OtherID: proto.OtherAction_OtherActionForcedMovement},
+		Duration: time.Second,
+
+		OnGain: func(aura *Aura, sim *Simulation) {
+			mh.damageAtStart = unit.Metrics.dps.Total
+			unit.AutoAttacks.CancelAutoSwing(sim)
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			mh.forcedMoving = false
+			mh.incapacitated = false
+			mh.downtimeUntil = 0
+
+			unit.Metrics.ForcedMovementTime += sim.CurrentTime - aura.StartedAt()
+			unit.Metrics.ForcedMovementDamage += unit.Metrics.dps.Total - mh.damageAtStart
+
+			if !mh.moveAura.IsActive() {
+				unit.AutoAttacks.EnableAutoSwing(sim)
+			}
+		},
+	})
+}
+
+// Makes the unit unable to act for the downtime, and then move the given distance. If the
+// unit is already being moved, this is added on to the current movement.
+func (unit *Unit) applyForcedMovement(sim *Simulation, downtime time.Duration, moveYards float64) {
+	mh := unit.MovementHandler
+	aura := mh.forcedAura
+
+	// Movement still to come from earlier events, which resumes after any new downtime.
+	var pendingMove time.Duration
+	if aura.IsActive() {
+		pendingMove = max(0, aura.ExpiresAt()-max(sim.CurrentTime, mh.downtimeUntil))
+	}
+
+	if downtime > 0 {
+		mh.downtimeUntil = max(mh.downtimeUntil, sim.CurrentTime+downtime)
+	}
+
+	moveStart := max(sim.CurrentTime, mh.downtimeUntil)
+	end := moveStart + pendingMove + DurationFromSeconds(moveYards/mh.MoveSpeed)
+	if end <= sim.CurrentTime {
+		return
+	}
+
+	if aura.IsActive() {
+		aura.UpdateExpires(sim, end)
+	} else {
+		aura.Duration = end - sim.CurrentTime
+		aura.Activate(sim)
+	}
+
+	if unit.IsChanneling(sim) {
+		unit.ChanneledDot.Cancel(sim)
+	}
+
+	if mh.downtimeUntil > sim.CurrentTime {
+		unit.CancelHardcast(sim)
+		mh.incapacitated = true
+		mh.forcedMoving = false
+		unit.SetGCDTimer(sim, max(unit.GCD.ReadyAt(), mh.downtimeUntil))
+
+		StartDelayedAction(sim, DelayedActionOptions{
+			DoAt:     mh.downtimeUntil,
+			Priority: ActionPriorityDOT,
+			OnAction: func(sim *Simulation) {
+				// Superseded by a longer downtime.
+				if !aura.IsActive() || sim.CurrentTime < mh.downtimeUntil {
+					return
+				}
+				mh.incapacitated = false
+				mh.forcedMoving = true
+			},
+		})
+		return
+	}
+
+	unit.CancelHardcast(sim)
+	mh.forcedMoving = true
+}
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func setupMovementSim(events ...*proto.EncounterMovementEvent) (*Simulation, *FakeAgent, *Unit) {
+	sim := SetupFakeSimWithEncounter(&proto.Encounter{
+		Targets:        []*proto.Target{newFakeSimTarget()},
+		Duration:       180,
+		MovementEvents: events,
+	})
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	fa.Rotation = &APLRotation{unit: &fa.Unit}
+
+	// Auras only expire when the sim advances, so stand in for the actions a rotation would take.
+	sim.AddPendingAction(NewPeriodicAction(sim, PeriodicActionOptions{
+		Period:   time.Millisecond * 10,
+		OnAction: func(sim *Simulation) {},
+	}))
+
+	return sim, fa, sim.Encounter.TargetUnits[0]
+}
+
+func TestForcedMovementCancelsHardcast(t *testing.T) {
+	sim, fa, target := setupMovementSim(&proto.EncounterMovementEvent{TimeSeconds: 10, MoveYards: 14})
+
+	runFakeSimUntil(sim, time.Second*9)
+	fa.Nuke.Cast(sim, target)
+	if !fa.IsCasting(sim) {
+		t.Fatalf("Expected Nuke to be casting")
+	}
+
+	runFakeSimUntil(sim, time.Millisecond*10500)
+	if !fa.IsForcedMoving() || !fa.IsMoving() {
+		t.Fatalf("Expected forced movement at 10.5s")
+	}
+	if fa.IsCasting(sim) {
+		t.Fatalf("Expected hardcast to be cancelled by forced movement")
+	}
+	if reason := fa.Nuke.CastFailureReason(sim, target); reason != proto.CastFailureReason_CastFailureMoving {
+		t.Fatalf("Expected hardcast to fail with Moving, got %s", reason)
+	}
+	if reason := fa.Channel.CastFailureReason(sim, target); reason != proto.CastFailureReason_CastFailureMoving {
+		t.Fatalf("Expected channel to fail with Moving, got %s", reason)
+	}
+
+	// 14 yards at 7 yards per second.
+	runFakeSimUntil(sim, time.Millisecond*12100)
+	if fa.IsMoving() {
+		t.Fatalf("Expected forced movement to end at 12s")
+	}
+	if fa.Nuke.SpellMetrics[target.UnitIndex].TotalDamage != 0 {
+		t.Fatalf("Expected no damage from the cancelled Nuke")
+	}
+	if fa.Metrics.ForcedMovementTime != time.Second*2 {
+		t.Fatalf("Expected 2s of forced movement, got %s", fa.Metrics.ForcedMovementTime)
+	}
+}
+
+func TestForcedMovementCancelsChannel(t *testing.T) {
+	sim, fa, target := setupMovementSim(&proto.EncounterMovementEvent{TimeSeconds: 10, MoveYards: 7})
+
+	runFakeSimUntil(sim, time.Millisecond*9500)
+	fa.Channel.Cast(sim, target)
+	if !fa.IsChanneling(sim) {
+		t.Fatalf("Expected Channel to be channeling")
+	}
+
+	runFakeSimUntil(sim, time.Millisecond*10500)
+	if fa.IsChanneling(sim) {
+		t.Fatalf("Expected channel to be cancelled by forced movement")
+	}
+}
+
+func TestPlayerMovementDoesNotBlockChannels(t *testing.T) {
+	sim, fa, target := setupMovementSim()
+
+	// Movement from the APL's Move action, rather than the encounter.
+	fa.MovementHandler.Moving = true
+
+	if reason := fa.Nuke.CastFailureReason(sim, target); reason != proto.CastFailureReason_CastFailureMoving {
+		t.Fatalf("Expected hardcast to fail with Moving, got %s", reason)
+	}
+	if reason := fa.Channel.CastFailureReason(sim, target); reason != proto.CastFailureReason_CastFailureNone {
+		t.Fatalf("Expected channel to be castable, got %s", reason)
+	}
+}
+
+func TestForcedDowntime(t *testing.T) {
+	sim, fa, target := setupMovementSim(
+		&proto.EncounterMovementEvent{TimeSeconds: 10, DowntimeSeconds: 3, MoveYards: 7},
+		// Overlaps the first downtime, extending it until 15s. The first event's movement follows.
+		&proto.EncounterMovementEvent{TimeSeconds: 12, DowntimeSeconds: 3},
+	)
+
+	runFakeSimUntil(sim, time.Second*11)
+	if !fa.IsIncapacitated() || fa.IsForcedMoving() {
+		t.Fatalf("Expected downtime without movement at 11s")
+	}
+	if reason := fa.Nuke.CastFailureReason(sim, target); reason != proto.CastFailureReason_CastFailureIncapacitated {
+		t.Fatalf("Expected cast to fail with Incapacitated, got %s", reason)
+	}
+	if fa.GCD.ReadyAt() < time.Second*13 {
+		t.Fatalf("Expected GCD to be pushed to the end of the downtime, got %s", fa.GCD.ReadyAt())
+	}
+
+	runFakeSimUntil(sim, time.Millisecond*14500)
+	if !fa.IsIncapacitated() {
+		t.Fatalf("Expected the second downtime to extend the first")
+	}
+
+	runFakeSimUntil(sim, time.Millisecond*15500)
+	if fa.IsIncapacitated() || !fa.IsForcedMoving() {
+		t.Fatalf("Expected forced movement after the downtime")
+	}
+
+	runFakeSimUntil(sim, time.Millisecond*16500)
+	if fa.IsIncapacitated() || fa.IsMoving() {
+		t.Fatalf("Expected forced movement to end at 16s")
+	}
+	if fa.Metrics.ForcedMovementTime != time.Second*6 {
+		t.Fatalf("Expected 6s of downtime and forced movement, got %s", fa.Metrics.ForcedMovementTime)
+	}
+}
+
+func TestMovementDpsLost(t *testing.T) {
+	sim, fa, target := setupMovementSim(&proto.EncounterMovementEvent{TimeSeconds: 60, IntervalSeconds: 60, DowntimeSeconds: 10})
+
+	// Chain cast Nuke whenever possible, for 50 dps outside of the downtime.
+	sim.AddPendingAction(NewPeriodicAction(sim, PeriodicActionOptions{
+		Period: time.Millisecond * 100,
+		OnAction: func(sim *Simulation) {
+			if fa.Nuke.CanCast(sim, target) {
+				fa.Nuke.Cast(sim, target)
+			}
+		},
+	}))
+	runFakeSimUntil(sim, sim.Duration)
+	sim.Cleanup()
+
+	// Downtimes at 60s and 120s, and a 3rd at 180s which doesn't get to start.
+	if fa.Metrics.ForcedMovementTime != time.Second*20 {
+		t.Fatalf("Expected 20s of downtime, got %s", fa.Metrics.ForcedMovementTime)
+	}
+
+	metrics := fa.Metrics.ToProto()
+	if metrics.SecondsForcedMovementAvg != 20 {
+		t.Fatalf("Expected 20 seconds of forced movement, got %0.3f", metrics.SecondsForcedMovementAvg)
+	}
+	// 20s at 50 dps. Each downtime also cancels a cast in progress, which isn't counted.
+	if lost := metrics.MovementDpsLost.Avg * sim.Duration.Seconds(); lost < 900 || lost > 1100 {
+		t.Fatalf("Expected around 1000 damage lost to downtime, got %0.3f", lost)
+	}
+}
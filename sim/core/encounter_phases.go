@@ -0,0 +1,223 @@
+package core
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// A scripted boss phase. Effects are applied through an aura on each target,
+// so phase uptimes also show up in the target aura metrics.
+type EncounterPhase struct {
+	// Starts at 1, in the order phases are listed in the encounter.
+	Number int32
+	Name   string
+
+	config *proto.EncounterPhase
+
+	// One per target, excluding adds.
+	auras []*Aura
+
+	// In health fights, the encounter damage taken at which a health triggered phase starts.
+	triggerDamage float64
+}
+
+func newEncounterPhases(configs []*proto.EncounterPhase) []*EncounterPhase {
+	phases := make([]*EncounterPhase, len(configs))
+	for i, config := range configs {
+		name := config.Name
+		if name == "" {
+			name = "Phase " + strconv.Itoa(i+1)
+		}
+		phases[i] = &EncounterPhase{
+			Number: int32(i + 1),
+			Name:   name,
+			config: config,
+		}
+	}
+	return phases
+}
+
+// Phases only apply to the main targets, not adds.
+func (encounter *Encounter) initializePhases() {
+	for _, phase := range encounter.phases {
+		for _, target := range encounter.AllTargets {
+			if !target.isAdd {
+				phase.auras = append(phase.auras, phase.makeTargetAura(target))
+			}
+		}
+	}
+}
+
+func (phase *EncounterPhase) makeTargetAura(target *Target) *Aura {
+	config := phase.config
+	damageTakenMultiplier := TernaryFloat64(config.TargetDamageTakenMultiplier > 0, config.TargetDamageTakenMultiplier, 1)
+	damageDealtMultiplier := TernaryFloat64(config.TargetDamageDealtMultiplier > 0, config.TargetDamageDealtMultiplier, 1)
+	immune := config.TargetsImmune || config.TargetsUntargetable
+
+	duration := NeverExpires
+	if config.DurationSeconds > 0 {
+		duration = DurationFromSeconds(config.DurationSeconds)
+	}
+
+	return target.RegisterAura(Aura{
+		Label:    "Encounter Phase " + strconv.Itoa(int(phase.Number)) + ": " + phase.Name,
+		ActionID: ActionID{OtherID: proto.OtherAction_OtherActionEncounterPhase, Tag: phase.Number},
+		Duration: duration,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier *= damageTakenMultiplier
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= damageDealtMultiplier
+			if immune {
+				aura.Unit.PseudoStats.Immune = true
+			}
+			if config.TargetsUntargetable {
+				target.setTargetable(sim, false)
+			}
+
+			// Player effects only need to be applied once per phase.
+			if target.Index == 0 {
+				phase.applyPlayerEffects(sim, true)
+			}
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier /= damageTakenMultiplier
+			aura.Unit.PseudoStats.DamageDealtMultiplier /= damageDealtMultiplier
+			if immune {
+				aura.Unit.PseudoStats.Immune = false
+			}
+			if config.TargetsUntargetable {
+				target.setTargetable(sim, true)
+			}
+
+			if target.Index == 0 {
+				phase.applyPlayerEffects(sim, false)
+			}
+		},
+	})
+}
+
+// Untargetable targets are removed from the encounter's targets, so players switch to
+// another target, or wait until one becomes targetable again. Targets which died or
+// despawned in the meantime don't come back.
+func (target *Target) setTargetable(sim *Simulation, targetable bool) {
+	target.untargetable = !targetable
+	target.Env.Encounter.updateSpawnedTargets(sim)
+}
+
+func (phase *EncounterPhase) applyPlayerEffects(sim *Simulation, isStart bool) {
+	config := phase.config
+	if !config.TargetsUntargetable && config.PlayerMoveYards == 0 {
+		return
+	}
+
+	for _, unit := range phase.auras[0].Unit.Env.Raid.AllPlayerUnits {
+		if config.TargetsUntargetable {
+			if isStart {
+				unit.AutoAttacks.CancelAutoSwing(sim)
+			} else {
+				unit.AutoAttacks.EnableAutoSwing(sim)
+			}
+		}
+
+		if config.PlayerMoveYards != 0 && unit.MovementHandler != nil {
+			if isStart {
+				unit.MoveTo(unit.DistanceFromTarget+float64(config.PlayerMoveYards), sim)
+			} else {
+				unit.MoveTo(unit.DistanceFromTarget-float64(config.PlayerMoveYards), sim)
+			}
+		}
+	}
+}
+
+// Returns the number of the current encounter phase, or 0 if no phase has started.
+func (encounter *Encounter) CurrentPhase() int32 {
+	return encounter.currentPhase
+}
+
+// Whether the encounter has any scripted phases.
+func (encounter *Encounter) HasPhases() bool {
+	return len(encounter.phases) > 0
+}
+
+func (encounter *Encounter) startPhase(sim *Simulation, phase *EncounterPhase) {
+	if phase.Number <= encounter.currentPhase {
+		return
+	}
+
+	if encounter.currentPhase > 0 {
+		for _, aura := range encounter.phases[encounter.currentPhase-1].auras {
+			aura.Deactivate(sim)
+		}
+	}
+
+	encounter.currentPhase = phase.Number
+	if sim.Log != nil {
+		sim.Log("Starting encounter phase %d: %s", phase.Number, phase.Name)
+	}
+
+	for _, aura := range phase.auras {
+		aura.Activate(sim)
+	}
+
+	encounter.updateNextPhaseDamage()
+}
+
+func (encounter *Encounter) resetPhases(sim *Simulation) {
+	encounter.currentPhase = 0
+	encounter.nextPhaseDamage = math.MaxFloat64
+
+	for _, phase := range encounter.phases {
+		phase := phase
+		config := phase.config
+
+		var startAt float64
+		switch config.Trigger {
+		case proto.EncounterPhaseTrigger_PhaseTriggerHealth:
+			if encounter.EndFightAtHealth > 0 {
+				phase.triggerDamage = (1 - config.TriggerValue/100) * encounter.EndFightAtHealth
+				continue
+			}
+			startAt = (1 - config.TriggerValue/100) * sim.Duration.Seconds()
+		default:
+			startAt = config.TriggerValue
+		}
+
+		StartDelayedAction(sim, DelayedActionOptions{
+			DoAt:     DurationFromSeconds(max(0, startAt)),
+			Priority: ActionPriorityDOT,
+			OnAction: func(sim *Simulation) {
+				encounter.startPhase(sim, phase)
+			},
+		})
+	}
+
+	encounter.updateNextPhaseDamage()
+}
+
+func (encounter *Encounter) updateNextPhaseDamage() {
+	encounter.nextPhaseDamage = math.MaxFloat64
+	if encounter.EndFightAtHealth == 0 {
+		return
+	}
+
+	for _, phase := range encounter.phases[encounter.currentPhase:] {
+		if phase.config.Trigger == proto.EncounterPhaseTrigger_PhaseTriggerHealth {
+			encounter.nextPhaseDamage = min(encounter.nextPhaseDamage, phase.triggerDamage)
+		}
+	}
+}
+
+// Starts health triggered phases in health fights, once enough damage has been taken.
+// If several thresholds were crossed at once, skips straight to the latest phase.
+func (encounter *Encounter) checkPhaseDamageTriggers(sim *Simulation) {
+	var latest *EncounterPhase
+	for _, phase := range encounter.phases[encounter.currentPhase:] {
+		if phase.config.Trigger == proto.EncounterPhaseTrigger_PhaseTriggerHealth && encounter.DamageTaken >= phase.triggerDamage {
+			latest = phase
+		}
+	}
+	if latest != nil {
+		encounter.startPhase(sim, latest)
+	}
+}
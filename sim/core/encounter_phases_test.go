@@ -0,0 +1,179 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func setupPhaseSim(numTargets int, useHealth bool, phases ...*proto.EncounterPhase) (*Simulation, *FakeAgent) {
+	targets := make([]*proto.Target, numTargets)
+	for i := range targets {
+		targets[i] = newHealthTestTarget("target", 10000)
+	}
+	sim := SetupFakeSimWithEncounter(&proto.Encounter{
+		Targets:   targets,
+		Duration:  180,
+		UseHealth: useHealth,
+		Phases:    phases,
+	})
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	// Auras only expire when the sim advances, so stand in for the actions a rotation would take.
+	sim.AddPendingAction(NewPeriodicAction(sim, PeriodicActionOptions{
+		Period:   time.Millisecond * 10,
+		OnAction: func(sim *Simulation) {},
+	}))
+
+	return sim, fa
+}
+
+func TestTimedPhases(t *testing.T) {
+	sim, _ := setupPhaseSim(1, false,
+		&proto.EncounterPhase{Name: "Enrage", TriggerValue: 10, DurationSeconds: 5, TargetDamageTakenMultiplier: 2},
+		&proto.EncounterPhase{TriggerValue: 20},
+	)
+	target := sim.Encounter.TargetUnits[0]
+
+	if sim.Encounter.CurrentPhase() != 0 {
+		t.Fatalf("Expected no phase at the pull")
+	}
+
+	runFakeSimUntil(sim, time.Second*11)
+	if sim.Encounter.CurrentPhase() != 1 || target.PseudoStats.DamageTakenMultiplier != 2 {
+		t.Fatalf("Expected phase 1 effects at 11s")
+	}
+
+	// The phase effects end, but the encounter stays in phase 1 until the next phase starts.
+	runFakeSimUntil(sim, time.Second*16)
+	if sim.Encounter.CurrentPhase() != 1 || target.PseudoStats.DamageTakenMultiplier != 1 {
+		t.Fatalf("Expected phase 1 effects to end at 15s")
+	}
+
+	runFakeSimUntil(sim, time.Second*21)
+	if sim.Encounter.CurrentPhase() != 2 {
+		t.Fatalf("Expected phase 2 at 21s, got %d", sim.Encounter.CurrentPhase())
+	}
+	if sim.Encounter.phases[0].auras[0].metrics.Uptime != time.Second*5 {
+		t.Fatalf("Expected 5s of phase 1 uptime, got %s", sim.Encounter.phases[0].auras[0].metrics.Uptime)
+	}
+}
+
+func TestPhaseTransitionEndsPreviousPhase(t *testing.T) {
+	sim, _ := setupPhaseSim(1, false,
+		&proto.EncounterPhase{TriggerValue: 10, TargetDamageTakenMultiplier: 2},
+		&proto.EncounterPhase{TriggerValue: 20, TargetDamageTakenMultiplier: 3},
+	)
+	target := sim.Encounter.TargetUnits[0]
+
+	runFakeSimUntil(sim, time.Second*21)
+	if target.PseudoStats.DamageTakenMultiplier != 3 {
+		t.Fatalf("Expected only phase 2 effects, got a multiplier of %0.3f", target.PseudoStats.DamageTakenMultiplier)
+	}
+}
+
+func TestHealthTriggeredPhases(t *testing.T) {
+	sim, fa := setupPhaseSim(1, true,
+		&proto.EncounterPhase{Trigger: proto.EncounterPhaseTrigger_PhaseTriggerHealth, TriggerValue: 80},
+		&proto.EncounterPhase{Trigger: proto.EncounterPhaseTrigger_PhaseTriggerHealth, TriggerValue: 50},
+		&proto.EncounterPhase{Trigger: proto.EncounterPhaseTrigger_PhaseTriggerHealth, TriggerValue: 30},
+	)
+	target := sim.Encounter.TargetUnits[0]
+
+	fa.Nuke.CalcAndDealDamage(sim, target, 1000, fa.Nuke.OutcomeAlwaysHit)
+	runFakeSimUntil(sim, time.Second)
+	if sim.Encounter.CurrentPhase() != 0 {
+		t.Fatalf("Expected no phase at 90%% health")
+	}
+
+	fa.Nuke.CalcAndDealDamage(sim, target, 1000, fa.Nuke.OutcomeAlwaysHit)
+	runFakeSimUntil(sim, time.Second*2)
+	if sim.Encounter.CurrentPhase() != 1 {
+		t.Fatalf("Expected phase 1 at 80%% health, got %d", sim.Encounter.CurrentPhase())
+	}
+
+	// Crossing several thresholds at once skips straight to the latest phase.
+	fa.Nuke.CalcAndDealDamage(sim, target, 5000, fa.Nuke.OutcomeAlwaysHit)
+	runFakeSimUntil(sim, time.Second*3)
+	if sim.Encounter.CurrentPhase() != 3 {
+		t.Fatalf("Expected phase 3 at 30%% health, got %d", sim.Encounter.CurrentPhase())
+	}
+}
+
+func TestUntargetablePhaseRetargets(t *testing.T) {
+	sim, fa := setupPhaseSim(2, false,
+		&proto.EncounterPhase{TriggerValue: 10, DurationSeconds: 5, TargetsUntargetable: true},
+	)
+	// Only the first target gets the phase effects, as if the second was an add.
+	sim.Encounter.phases[0].auras = sim.Encounter.phases[0].auras[:1]
+	targetA, targetB := sim.Encounter.TargetUnits[0], sim.Encounter.TargetUnits[1]
+
+	runFakeSimUntil(sim, time.Second*11)
+	if len(sim.Encounter.TargetUnits) != 1 || sim.Encounter.TargetUnits[0] != targetB {
+		t.Fatalf("Expected only the second target to be targetable")
+	}
+	if fa.CurrentTarget != targetB {
+		t.Fatalf("Expected the player to switch to the targetable target")
+	}
+	if reason := fa.Nuke.CastFailureReason(sim, targetA); reason != proto.CastFailureReason_CastFailureTarget {
+		t.Fatalf("Expected casts on the untargetable target to fail with Target, got %s", reason)
+	}
+	if reason := fa.Nuke.CastFailureReason(sim, targetB); reason != proto.CastFailureReason_CastFailureNone {
+		t.Fatalf("Expected casts on the targetable target to succeed, got %s", reason)
+	}
+
+	runFakeSimUntil(sim, time.Second*16)
+	if len(sim.Encounter.TargetUnits) != 2 || !targetA.IsEnabled() || targetA.PseudoStats.Immune {
+		t.Fatalf("Expected the first target to be targetable again")
+	}
+}
+
+func TestUntargetablePhaseWaits(t *testing.T) {
+	sim, fa := setupPhaseSim(1, false,
+		&proto.EncounterPhase{TriggerValue: 10, DurationSeconds: 5, TargetsUntargetable: true},
+	)
+	target := sim.Encounter.TargetUnits[0]
+
+	runFakeSimUntil(sim, time.Second*11)
+	if len(sim.Encounter.TargetUnits) != 0 || fa.CurrentTarget != target {
+		t.Fatalf("Expected no targetable targets, with the player keeping its target")
+	}
+	if fa.Nuke.CanCast(sim, target) {
+		t.Fatalf("Expected casts to wait for the target to become targetable")
+	}
+
+	runFakeSimUntil(sim, time.Second*16)
+	if len(sim.Encounter.TargetUnits) != 1 || !fa.Nuke.CanCast(sim, target) {
+		t.Fatalf("Expected the target to be castable again")
+	}
+}
+
+func TestUntargetablePhaseKeepsDeadTargets(t *testing.T) {
+	sim := SetupFakeSimWithEncounter(&proto.Encounter{
+		Targets:         []*proto.Target{newHealthTestTarget("a", 1000), newHealthTestTarget("b", 1000), newHealthTestTarget("c", 1000)},
+		Duration:        180,
+		UseHealth:       true,
+		UseTargetHealth: true,
+		Phases:          []*proto.EncounterPhase{{TriggerValue: 10, DurationSeconds: 5, TargetsUntargetable: true}},
+	})
+	targetA, targetB, targetC := sim.Encounter.AllTargets[0], sim.Encounter.AllTargets[1], sim.Encounter.AllTargets[2]
+
+	targetA.takeDamage(sim, targetA.CurrentHealth())
+	runFakeSimUntil(sim, time.Second*11)
+	if len(sim.Encounter.TargetUnits) != 0 {
+		t.Fatalf("Expected no targetable targets during the phase")
+	}
+
+	// Dies while untargetable, e.g. from a DoT.
+	targetB.takeDamage(sim, targetB.CurrentHealth())
+	runFakeSimUntil(sim, time.Second*16)
+	for _, target := range []*Target{targetA, targetB} {
+		if !target.IsDead() || target.IsSpawned() {
+			t.Fatalf("Expected %s to stay dead after the phase", target.Label)
+		}
+	}
+	if !targetC.IsSpawned() || len(sim.Encounter.TargetUnits) != 1 || sim.Encounter.TargetUnits[0] != &targetC.Unit {
+		t.Fatalf("Expected only the living target to be targetable again")
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/wowsims/sod/sim/core/proto"
 	"github.com/wowsims/sod/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
 )
 
 type EnvironmentState int
@@ -52,12 +53,17 @@ func NewEnvironment(raidProto *proto.Raid, encounterProto *proto.Encounter, runF
 		State: Created,
 	}
 
+	if raidProto.SimulateRaidBuffs {
+		// Simulated buff providers clear their static toggles, so work on a copy of the request.
+		raidProto = googleProto.Clone(raidProto).(*proto.Raid)
+	}
+
 	env.construct(raidProto, encounterProto)
 	raidStats := env.initialize(raidProto, encounterProto)
 	env.finalize(raidProto, encounterProto, raidStats, runFakePrepull)
 
 	encounterStats := &proto.EncounterStats{}
-	for _, target := range env.Encounter.Targets {
+	for _, target := range env.Encounter.AllTargets {
 		encounterStats.Targets = append(encounterStats.Targets, &proto.TargetStats{
 			Metadata: target.GetMetadata(),
 		})
@@ -72,30 +78,42 @@ func (env *Environment) construct(raidProto *proto.Raid, encounterProto *proto.E
 	env.BaseDuration = env.Encounter.Duration
 	env.DurationVariation = env.Encounter.DurationVariation
 	env.Raid = NewRaid(raidProto)
+	if raidProto.SimulateRaidBuffs {
+		env.Raid.removeSimulatedBuffs(raidProto)
+	}
 
 	env.Raid.updatePlayersAndPets()
 
-	env.AllUnits = append(env.Encounter.TargetUnits, env.Raid.AllUnits...)
+	env.AllUnits = append(slices.Clone(env.Encounter.AllTargetUnits), env.Raid.AllUnits...)
 
 	for unitIndex, unit := range env.AllUnits {
 		unit.Env = env
 		unit.UnitIndex = int32(unitIndex)
 	}
+	for _, target := range env.Encounter.AllTargets {
+		target.threat = make([]float64, len(env.AllUnits))
+	}
 
 	for _, unit := range env.Raid.AllUnits {
 		unit.CurrentTarget = env.Encounter.TargetUnits[0]
 	}
 
 	// Apply extra debuffs from raid.
-	if raidProto.Debuffs != nil && len(env.Encounter.TargetUnits) > 0 {
-		for targetIdx, targetUnit := range env.Encounter.TargetUnits {
+	if raidProto.Debuffs != nil && len(env.Encounter.AllTargetUnits) > 0 {
+		for targetIdx, targetUnit := range env.Encounter.AllTargetUnits {
 			level := raidProto.Parties[0].Players[0].Level
 			applyDebuffEffects(targetUnit, targetIdx, raidProto.Debuffs, level, env.Raid.AllUnits)
 		}
 	}
 
+	for _, tankProto := range raidProto.Tanks {
+		if tank := env.GetUnit(tankProto, nil); tank != nil {
+			env.Raid.Tanks = append(env.Raid.Tanks, tank)
+		}
+	}
+
 	// Assign target or target using Tanks field.
-	for _, target := range env.Encounter.Targets {
+	for _, target := range env.Encounter.AllTargets {
 		if target.Index < int32(len(encounterProto.Targets)) {
 			targetProto := encounterProto.Targets[target.Index]
 			if targetProto.TankIndex >= 0 && targetProto.TankIndex < int32(len(raidProto.Tanks)) {
@@ -115,13 +133,16 @@ func (env *Environment) construct(raidProto *proto.Raid, encounterProto *proto.E
 
 // The initialization phase.
 func (env *Environment) initialize(raidProto *proto.Raid, encounterProto *proto.Encounter) *proto.RaidStats {
-	for _, target := range env.Encounter.Targets {
+	for _, target := range env.Encounter.AllTargets {
 		if target.Index < int32(len(encounterProto.Targets)) {
 			target.initialize(encounterProto.Targets[target.Index])
 		} else {
 			target.initialize(nil)
 		}
 	}
+	env.Encounter.initializePhases()
+	env.Encounter.initializeMovement(env)
+	env.Encounter.initializeDamage(env)
 
 	for _, party := range env.Raid.Parties {
 		for _, playerOrPet := range party.PlayersAndPets {
@@ -137,6 +158,14 @@ func (env *Environment) initialize(raidProto *proto.Raid, encounterProto *proto.
 		}
 	}
 
+	// After class spells, so that classes can register their own versions.
+	hasInterrupts, dispelTypes := encounterUtilityNeeds(encounterProto)
+	for _, party := range env.Raid.Parties {
+		for _, player := range party.Players {
+			player.GetCharacter().registerUtilitySpells(hasInterrupts, dispelTypes)
+		}
+	}
+
 	env.State = Initialized
 	return raidStats
 }
@@ -148,7 +177,7 @@ func (env *Environment) finalize(raidProto *proto.Raid, _ *proto.Encounter, raid
 	}
 	env.preFinalizeEffects = nil
 
-	for _, target := range env.Encounter.Targets {
+	for _, target := range env.Encounter.AllTargets {
 		target.finalize()
 		if target.AI != nil {
 			target.Rotation = target.newCustomRotation()
@@ -166,15 +195,20 @@ func (env *Environment) finalize(raidProto *proto.Raid, _ *proto.Encounter, raid
 		}
 	}
 
+	// After characters are finalized so assigned spells can be removed from their MCDs, and
+	// before rotations so APL values can look up the assignments.
+	env.Raid.registerCooldownAssignments(env, raidProto, raidStats)
+
 	for partyIdx, party := range env.Raid.Parties {
 		partyProto := raidProto.Parties[partyIdx]
-		for playerIdx, player := range party.Players {
-			if playerIdx >= len(partyProto.Players) {
+		for _, player := range party.Players {
+			char := player.GetCharacter()
+			// Players are looked up by slot, as empty slots are skipped in party.Players.
+			if char.PartyIndex >= len(partyProto.Players) {
 				// This happens for target dummies.
 				continue
 			}
-			playerProto := partyProto.Players[playerIdx]
-			char := player.GetCharacter()
+			playerProto := partyProto.Players[char.PartyIndex]
 			char.Rotation = char.newAPLRotation(playerProto.Rotation)
 		}
 	}
@@ -259,12 +293,17 @@ func (env *Environment) IsFinalized() bool {
 func (env *Environment) reset(sim *Simulation) {
 	// Reset primary targets damage taken for tracking health fights.
 	env.Encounter.DamageTaken = 0
+	env.Encounter.damageRate.reset()
 
 	// Targets need to be reset before the raid, so that players can check for
 	// the presence of permanent target auras in their Reset handlers.
-	for _, target := range env.Encounter.Targets {
+	for _, target := range env.Encounter.AllTargets {
 		target.Reset(sim)
 	}
+	env.Encounter.resetTargets(sim)
+	env.Encounter.resetPhases(sim)
+	env.Encounter.resetMovement(sim)
+	env.Encounter.resetDamage(sim)
 
 	env.Raid.reset(sim)
 }
@@ -274,18 +313,20 @@ func (env *Environment) GetMaxDuration() time.Duration {
 	return env.BaseDuration + env.DurationVariation
 }
 
+// Returns the number of currently spawned targets.
 func (env *Environment) GetNumTargets() int32 {
 	return int32(len(env.Encounter.Targets))
 }
 
+// Returns the target with the given index, whether or not it is currently spawned.
 func (env *Environment) GetTarget(index int32) *Target {
-	return env.Encounter.Targets[index]
+	return env.Encounter.AllTargets[index]
 }
 func (env *Environment) GetTargetUnit(index int32) *Unit {
-	return &env.Encounter.Targets[index].Unit
+	return &env.Encounter.AllTargets[index].Unit
 }
 func (env *Environment) NextTarget(target *Unit) *Target {
-	return env.Encounter.Targets[target.Index].NextTarget()
+	return env.Encounter.AllTargets[target.Index].NextTarget()
 }
 func (env *Environment) NextTargetUnit(target *Unit) *Unit {
 	return &env.NextTarget(target).Unit
@@ -296,7 +337,7 @@ func (env *Environment) GetAgentFromUnit(unit *Unit) Agent {
 		return raidAgent
 	}
 
-	for _, target := range env.Encounter.Targets {
+	for _, target := range env.Encounter.AllTargets {
 		if unit == &target.Unit {
 			return target
 		}
@@ -336,8 +377,8 @@ func (env *Environment) GetUnit(ref *proto.UnitReference, contextUnit *Unit) *Un
 			return nil
 		}
 	case proto.UnitReference_Target:
-		if int(ref.Index) < len(env.Encounter.TargetUnits) {
-			return env.Encounter.TargetUnits[ref.Index]
+		if int(ref.Index) < len(env.Encounter.AllTargetUnits) {
+			return env.Encounter.AllTargetUnits[ref.Index]
 		} else {
 			return nil
 		}
@@ -348,6 +389,8 @@ func (env *Environment) GetUnit(ref *proto.UnitReference, contextUnit *Unit) *Un
 			return nil
 		}
 		return contextUnit.CurrentTarget
+	case proto.UnitReference_LowestHealthAlly:
+		return env.Raid.GetLowestHealthAlly()
 	}
 
 	return nil
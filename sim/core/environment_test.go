@@ -0,0 +1,35 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+)
+
+func TestRotationsWithEmptyPartySlots(t *testing.T) {
+	rsr := fakeSimRequest(&proto.Encounter{
+		Targets:  []*proto.Target{newFakeSimTarget()},
+		Duration: 100,
+	})
+	caster := rsr.Raid.Parties[0].Players[0]
+	caster.Rotation = &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+		PriorityList: []*proto.APLListItem{{
+			Action: &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+				SpellId: ActionID{SpellID: 43}.ToProto(),
+			}}},
+		}},
+	}
+	// Empty slots aren't added to the party, so the caster is the party's first player.
+	rsr.Raid.Parties[0].Players = []*proto.Player{{}, caster}
+
+	sim := NewSim(rsr, simsignals.CreateSignals())
+	char := sim.Raid.Parties[0].Players[0].GetCharacter()
+	if char.PartyIndex != 1 {
+		t.Fatalf("Expected the caster in party slot 1, got %d", char.PartyIndex)
+	}
+	if len(char.Rotation.priorityList) != 1 {
+		t.Fatalf("Expected the caster's own rotation, got %d actions", len(char.Rotation.priorityList))
+	}
+}
@@ -1,5 +1,7 @@
 package core
 
+import "github.com/wowsims/sod/sim/core/proto"
+
 type ProcMask uint32
 
 // Returns whether there is any overlap between the given masks.
@@ -203,6 +205,7 @@ const (
 	SpellFlagSwapped                                       // Indicates that this spell is not useable because it is from a currently swapped item
 	SpellFlagNoSpellMods                                   // Indicates that no spell mods should be applied to this spell
 	SpellFlagTreatAsPeriodic                               // Indicates that the spell deals non-DoT damage but is treated a periodic. Equivalent to the "Treat as Periodic" flag in-game
+	SpellFlagInterruptible                                 // Indicates that players can interrupt this spell's cast, e.g. for target abilities
 
 	// Used to let agents categorize their spells.
 	SpellFlagAgentReserved1
@@ -226,6 +229,20 @@ const (
 	DispelType_Enrage
 )
 
+func DispelTypeFromProto(dispelType proto.DispelType) DispelType {
+	switch dispelType {
+	case proto.DispelType_DispelTypeMagic:
+		return DispelType_Magic
+	case proto.DispelType_DispelTypeCurse:
+		return DispelType_Curse
+	case proto.DispelType_DispelTypePoison:
+		return DispelType_Poison
+	case proto.DispelType_DispelTypeDisease:
+		return DispelType_Disease
+	}
+	return DispelType_None
+}
+
 /*
 outcome roll hit/miss/crit/glance (assigns Outcome mask) -> If Hit, Crit Roll -> damage (applies metrics) -> trigger proc
 
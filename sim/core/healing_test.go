@@ -0,0 +1,223 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Sets up the fake caster in a raid with 2 target dummies, each with 10000 health.
+func setupHealingSim() (*Simulation, *FakeAgent, *Unit, *Unit) {
+	rsr := fakeSimRequest(&proto.Encounter{
+		Targets:  []*proto.Target{newFakeSimTarget()},
+		Duration: 180,
+	})
+	rsr.Raid.TargetDummies = 2
+
+	sim, agents := setupFakeSimWithRequest(rsr)
+	fa := agents[0]
+	dummies := sim.Raid.Parties[0].Players[1:]
+	return sim, fa, &dummies[0].GetCharacter().Unit, &dummies[1].GetCharacter().Unit
+}
+
+func TestLowestHealthAlly(t *testing.T) {
+	sim, fa, dummyA, dummyB := setupHealingSim()
+	metrics := dummyA.NewHealthMetrics(ActionID{OtherID: proto.OtherAction_OtherActionDamageTaken})
+
+	// Ties go to the lowest raid index.
+	if lowest := sim.Raid.GetLowestHealthAlly(); lowest != &fa.Unit {
+		t.Fatalf("Expected %s at full health, got %s", fa.Label, lowest.Label)
+	}
+
+	dummyB.RemoveHealth(sim, 3000, metrics)
+	dummyA.RemoveHealth(sim, 2000, metrics)
+	if lowest := sim.Raid.GetLowestHealthAlly(); lowest != dummyB {
+		t.Fatalf("Expected %s to be the lowest health ally, got %s", dummyB.Label, lowest.Label)
+	}
+
+	// The reference is resolved each time it's used, so follows health changes.
+	ref := NewUnitReference(&proto.UnitReference{Type: proto.UnitReference_LowestHealthAlly}, &fa.Unit)
+	dummyA.RemoveHealth(sim, 2000, metrics)
+	if ref.Get() != dummyA {
+		t.Fatalf("Expected the reference to follow %s, got %s", dummyA.Label, ref.Get().Label)
+	}
+	if unit := sim.Environment.GetUnit(&proto.UnitReference{Type: proto.UnitReference_LowestHealthAlly}, &fa.Unit); unit != dummyA {
+		t.Fatalf("Expected the environment to resolve %s, got %s", dummyA.Label, unit.Label)
+	}
+
+	// Dead players can't be healed.
+	dummyA.RemoveHealth(sim, dummyA.CurrentHealth(), metrics)
+	dummyA.Metrics.Died = true
+	if lowest := sim.Raid.GetLowestHealthAlly(); lowest != dummyB {
+		t.Fatalf("Expected %s to be skipped once dead, got %s", dummyA.Label, lowest.Label)
+	}
+}
+
+func TestMissingHealthAndInjuredAllies(t *testing.T) {
+	sim, fa, dummyA, dummyB := setupHealingSim()
+	metrics := dummyA.NewHealthMetrics(ActionID{OtherID: proto.OtherAction_OtherActionDamageTaken})
+	rot := &APLRotation{unit: &fa.Unit}
+
+	missingHealth := rot.newValueMissingHealth(&proto.APLValueMissingHealth{
+		SourceUnit: &proto.UnitReference{Type: proto.UnitReference_LowestHealthAlly},
+	})
+	injuredAllies := rot.newValueNumInjuredAllies(&proto.APLValueNumInjuredAllies{})
+	below80 := rot.newValueNumInjuredAllies(&proto.APLValueNumInjuredAllies{HealthPercent: 80})
+	if rot.newValueNumInjuredAllies(&proto.APLValueNumInjuredAllies{HealthPercent: 120}) != nil {
+		t.Fatalf("Expected an invalid health percent to be rejected")
+	}
+
+	if missingHealth.GetFloat(sim) != 0 || injuredAllies.GetInt(sim) != 0 {
+		t.Fatalf("Expected no injured allies at full health")
+	}
+
+	dummyA.RemoveHealth(sim, 1000, metrics)
+	dummyB.RemoveHealth(sim, 3000, metrics)
+	if missingHealth.GetFloat(sim) != 3000 {
+		t.Fatalf("Expected 3000 missing health, got %0.3f", missingHealth.GetFloat(sim))
+	}
+	// Without a health percent, any missing health counts as injured.
+	if injuredAllies.GetInt(sim) != 2 {
+		t.Fatalf("Expected 2 injured allies, got %d", injuredAllies.GetInt(sim))
+	}
+	if below80.GetInt(sim) != 1 {
+		t.Fatalf("Expected 1 ally below 80%% health, got %d", below80.GetInt(sim))
+	}
+
+	dummyB.Metrics.Died = true
+	if injuredAllies.GetInt(sim) != 1 || below80.GetInt(sim) != 0 {
+		t.Fatalf("Expected dead allies not to count as injured")
+	}
+}
+
+func TestOverhealing(t *testing.T) {
+	sim, fa, dummyA, _ := setupHealingSim()
+	metrics := dummyA.NewHealthMetrics(ActionID{OtherID: proto.OtherAction_OtherActionDamageTaken})
+
+	// 1000 healing into 600 missing health.
+	dummyA.RemoveHealth(sim, 600, metrics)
+	fa.Heal.Cast(sim, dummyA)
+	// Then 1000 healing into full health.
+	fa.Heal.Cast(sim, dummyA)
+
+	spellMetrics := fa.Heal.SpellMetrics[dummyA.UnitIndex]
+	if spellMetrics.TotalHealing != 2000 || spellMetrics.TotalOverhealing != 1400 {
+		t.Fatalf("Expected 1400 of 2000 healing to be overhealing, got %0.3f of %0.3f", spellMetrics.TotalOverhealing, spellMetrics.TotalHealing)
+	}
+	if dummyA.CurrentHealth() != dummyA.MaxHealth() {
+		t.Fatalf("Expected %s to be healed to full", dummyA.Label)
+	}
+
+	sim.Cleanup()
+	unitMetrics := fa.Metrics.ToProto()
+	if overhealPercent := unitMetrics.OverhealPercent.Avg; math.Abs(overhealPercent-70) > 1e-9 {
+		t.Fatalf("Expected 70%% overhealing, got %0.3f", overhealPercent)
+	}
+}
+
+func TestShieldAbsorbedAndWasted(t *testing.T) {
+	sim, fa, _, _ := setupHealingSim()
+	damage := func(amount float64) float64 {
+		return fa.Nuke.CalcAndDealDamage(sim, &fa.Unit, amount, fa.Nuke.OutcomeAlwaysHit).Damage
+	}
+
+	// Absorbs 600, then the other 400 is wasted when the shield is replaced.
+	fa.Shield.Cast(sim, &fa.Unit)
+	if taken := damage(600); taken != 0 {
+		t.Fatalf("Expected the shield to absorb all the damage, took %0.3f", taken)
+	}
+	fa.Shield.Cast(sim, &fa.Unit)
+
+	// Absorbs 1000, with 500 damage going through.
+	if taken := damage(1500); taken != 500 {
+		t.Fatalf("Expected 500 damage past the shield, took %0.3f", taken)
+	}
+	if fa.Shield.SelfShield().IsActive() {
+		t.Fatalf("Expected the shield to break once used up")
+	}
+
+	// Expires unused.
+	runFakeSimUntil(sim, time.Second)
+	fa.Shield.Cast(sim, &fa.Unit)
+	runFakeSimUntil(sim, time.Second*12)
+
+	// Still up at the end of the iteration, so not wasted.
+	runFakeSimUntil(sim, sim.Duration-time.Second)
+	fa.Shield.Cast(sim, &fa.Unit)
+	runFakeSimUntil(sim, sim.Duration)
+
+	spellMetrics := fa.Shield.SpellMetrics[fa.UnitIndex]
+	if spellMetrics.TotalShielding != 4000 || spellMetrics.TotalAbsorbedShielding != 1600 || spellMetrics.TotalWastedShielding != 1400 {
+		t.Fatalf("Expected 1600 of 4000 shielding absorbed and 1400 wasted, got %0.3f and %0.3f of %0.3f",
+			spellMetrics.TotalAbsorbedShielding, spellMetrics.TotalWastedShielding, spellMetrics.TotalShielding)
+	}
+
+	sim.Cleanup()
+	unitMetrics := fa.Metrics.ToProto()
+	if unitMetrics.AbsorbedShieldingAvg != 1600 || unitMetrics.WastedShieldingAvg != 1400 {
+		t.Fatalf("Expected 1600 absorbed and 1400 wasted shielding, got %0.3f and %0.3f", unitMetrics.AbsorbedShieldingAvg, unitMetrics.WastedShieldingAvg)
+	}
+	// Only absorbed shielding counts as effective healing.
+	if effectiveHps := unitMetrics.EffectiveHps.Avg * sim.Duration.Seconds(); math.Abs(effectiveHps-1600) > 1e-6 {
+		t.Fatalf("Expected 1600 effective healing, got %0.3f", effectiveHps)
+	}
+}
+
+func TestShieldWastedPastEstimatedDuration(t *testing.T) {
+	sim, fa, _, _ := setupHealingSim()
+	// Health fights can run past their estimated duration.
+	sim.Duration = time.Second * 5
+
+	fa.Shield.Cast(sim, &fa.Unit)
+	runFakeSimUntil(sim, time.Second*12)
+	if wasted := fa.Shield.SpellMetrics[fa.UnitIndex].TotalWastedShielding; wasted != 1000 {
+		t.Fatalf("Expected a shield expiring during the fight to be wasted, got %0.3f", wasted)
+	}
+
+	fa.Shield.Cast(sim, &fa.Unit)
+	sim.Cleanup()
+	if wasted := fa.Shield.SpellMetrics[fa.UnitIndex].TotalWastedShielding; wasted != 1000 {
+		t.Fatalf("Expected a shield still up when the fight ends to not be wasted, got %0.3f", wasted)
+	}
+}
+
+func TestOverhealPercentAggregation(t *testing.T) {
+	sim, fa, dummyA, _ := setupHealingSim()
+	metrics := dummyA.NewHealthMetrics(ActionID{OtherID: proto.OtherAction_OtherActionDamageTaken})
+
+	// 70% overhealing in the first iteration.
+	dummyA.RemoveHealth(sim, 600, metrics)
+	fa.Heal.Cast(sim, dummyA)
+	fa.Heal.Cast(sim, dummyA)
+	sim.Cleanup()
+	firstIteration := fa.Metrics.ToProto()
+
+	// 10% in the second.
+	sim.Reset()
+	dummyA.RemoveHealth(sim, 900, metrics)
+	fa.Heal.Cast(sim, dummyA)
+	sim.Cleanup()
+
+	// Each iteration counts equally, regardless of how much healing was done.
+	unitMetrics := fa.Metrics.ToProto()
+	if overhealPercent := unitMetrics.OverhealPercent.Avg; math.Abs(overhealPercent-40) > 1e-9 {
+		t.Fatalf("Expected 40%% average overhealing, got %0.3f", overhealPercent)
+	}
+	if unitMetrics.OverhealingAvg != 750 || unitMetrics.EffectiveHealingAvg != 750 {
+		t.Fatalf("Expected 750 average overhealing and effective healing, got %0.3f and %0.3f", unitMetrics.OverhealingAvg, unitMetrics.EffectiveHealingAvg)
+	}
+
+	// Results from concurrent sims are weighted by their share of the iterations.
+	rsrc := &raidSimResultCombiner{}
+	combined := rsrc.newUnitMetrics(unitMetrics)
+	rsrc.combineUnitMetrics(combined, firstIteration, false, 1.0/3)
+	rsrc.combineUnitMetrics(combined, unitMetrics, true, 2.0/3)
+	if overhealPercent := combined.OverhealPercent.Avg; math.Abs(overhealPercent-50) > 1e-9 {
+		t.Fatalf("Expected 50%% combined overhealing, got %0.3f", overhealPercent)
+	}
+	if math.Abs(combined.OverhealingAvg-966.666666667) > 1e-6 {
+		t.Fatalf("Expected 966.667 combined overhealing, got %0.3f", combined.OverhealingAvg)
+	}
+}
@@ -26,6 +26,11 @@ func (unit *Unit) HasHealthBar() bool {
 	return unit.healthBar.unit != nil
 }
 
+// Whether this player died in the current iteration. Enemy targets track their own deaths.
+func (unit *Unit) IsDead() bool {
+	return unit.Metrics.Died
+}
+
 func (hb *healthBar) reset(_ *Simulation) {
 	if hb.unit == nil {
 		return
@@ -54,6 +59,15 @@ func (hb *healthBar) GainHealth(sim *Simulation, amount float64, metrics *Resour
 	newHealth := min(oldHealth+amount, hb.unit.MaxHealth())
 	metrics.AddEvent(amount, newHealth-oldHealth)
 
+	// With virtual healers TMI is based on the net damage taken, so effective healing counts against it.
+	if hb.unit.Metrics.isTanking && hb.unit.Metrics.tmiIncludesHealing && newHealth > oldHealth {
+		entry := tmiListItem{
+			Timestamp:      sim.CurrentTime,
+			WeightedDamage: -(newHealth - oldHealth) / hb.MaxHealth(),
+		}
+		hb.unit.Metrics.tmiList = append(hb.unit.Metrics.tmiList, entry)
+	}
+
 	if sim.Log != nil {
 		hb.unit.Log(sim, "Gained %0.3f health from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, oldHealth, newHealth)
 	}
@@ -95,15 +109,21 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 			character.Unit.Metrics.isTanking = true
 		}
 	}
-	if !character.Unit.Metrics.isTanking {
+	takesEncounterDamage := character.Env.Encounter.HasDamageEvents()
+	if !character.Unit.Metrics.isTanking && !takesEncounterDamage {
 		return
 	}
 
 	if healingModel == nil {
-		return
+		if !takesEncounterDamage {
+			return
+		}
+		// Encounter damage can still kill players without any incoming healing.
+		healingModel = &proto.HealingModel{}
 	}
 
 	character.Unit.Metrics.tmiBin = healingModel.BurstWindow
+	character.Unit.trackSurvival(healingModel.BurstWindow)
 
 	character.RegisterAura(Aura{
 		Label:    ChanceOfDeathAuraLabel,
@@ -112,6 +132,7 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 			aura.Activate(sim)
 		},
 		OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			aura.Unit.Metrics.addDamageTaken(sim, spell, result, false)
 			if result.Damage > 0 {
 				aura.Unit.RemoveHealth(sim, result.Damage, aura.Unit.DamageTakenHealthMetrics)
 
@@ -124,6 +145,7 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 			}
 		},
 		OnPeriodicDamageTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			aura.Unit.Metrics.addDamageTaken(sim, spell, result, true)
 			if result.Damage > 0 {
 				aura.Unit.RemoveHealth(sim, result.Damage, aura.Unit.DamageTakenHealthMetrics)
 
@@ -140,6 +162,9 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 	if healingModel.Hps != 0 {
 		character.applyHealingModel(healingModel)
 	}
+	if len(healingModel.VirtualHealers) > 0 {
+		character.applyVirtualHealers(healingModel.VirtualHealers)
+	}
 }
 
 func (character *Character) applyHealingModel(healingModel *proto.HealingModel) {
@@ -173,6 +198,11 @@ func (character *Character) applyHealingModel(healingModel *proto.HealingModel)
 			// Use modeled HPS to scale heal per tick based on random cadence
 			healPerTick = healingModel.Hps * (float64(timeToNextHeal) / float64(time.Second))
 			totalHeal := healPerTick * character.PseudoStats.HealingTakenMultiplier
+			// Record the heal like any other, so that it shows up in the healing metrics.
+			spellMetrics := &healingModelSpell.SpellMetrics[character.UnitIndex]
+			spellMetrics.Hits++
+			spellMetrics.TotalHealing += totalHeal
+			spellMetrics.TotalOverhealing += max(0, totalHeal-(character.MaxHealth()-character.CurrentHealth()))
 			// Execute the heal
 			character.GainHealth(sim, totalHeal, healthMetrics)
 
@@ -205,8 +235,8 @@ func (character *Character) applyHealingModel(healingModel *proto.HealingModel)
 
 func (character *Character) GetPresimOptions(playerConfig *proto.Player) *PresimOptions {
 	healingModel := playerConfig.HealingModel
-	if healingModel == nil || healingModel.Hps != 0 || healingModel.CadenceSeconds == 0 {
-		// If Hps is not 0, then we don't need to run the presim.
+	if healingModel == nil || healingModel.Hps != 0 || healingModel.CadenceSeconds == 0 || len(healingModel.VirtualHealers) > 0 {
+		// If Hps is not 0 or there are virtual healers, then we don't need to run the presim.
 		// Tank sims should always have nonzero Cadence set, even if disabled
 		return nil
 	}
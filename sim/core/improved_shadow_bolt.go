@@ -0,0 +1,305 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+const (
+	ISBNumStacksBase        = 4
+	ISBNumStacksShadowflame = 30
+)
+
+// Virtual Improved Shadow Bolt casters for individual sims, from the Player.isb_* fields.
+type IsbConfig struct {
+	// Whether any of the isb_* fields are set, rather than all defaults.
+	isConfigured       bool
+	hasShadowflameRune bool
+	virtualCasters     []*proto.IsbVirtualCaster
+}
+
+func (character *Character) createIsbConfig(player *proto.Player) {
+	character.IsbConfig = IsbConfig{
+		isConfigured: player.IsbUsingShadowflame || len(player.IsbVirtualCasters) > 0 || player.IsbSbFrequency != 0 ||
+			player.IsbCrit != 0 || player.IsbWarlocks != 0 || player.IsbSpriests != 0,
+		hasShadowflameRune: player.IsbUsingShadowflame,
+		virtualCasters:     player.IsbVirtualCasters,
+	}
+	if len(character.IsbConfig.virtualCasters) == 0 {
+		character.IsbConfig.virtualCasters = legacyIsbVirtualCasters(player)
+	}
+}
+
+// Builds a schedule from the older flat settings: Warlocks casting Shadow Bolt together
+// every isb_sb_frequency seconds, and Shadow Priests dealing shadow damage on 5 of every 6 GCDs,
+// skipping the first GCD at 1.5s.
+func legacyIsbVirtualCasters(player *proto.Player) []*proto.IsbVirtualCaster {
+	//Defaults if not configured
+	sbFrequency := TernaryFloat64(player.IsbSbFrequency == 0, 3.0, player.IsbSbFrequency)
+	crit := TernaryFloat64(player.IsbCrit == 0, 25.0, player.IsbCrit)
+	numWarlocks := TernaryInt32(player.IsbWarlocks == 0, 1, player.IsbWarlocks)
+
+	var casters []*proto.IsbVirtualCaster
+	for i := int32(0); i < numWarlocks; i++ {
+		casters = append(casters, &proto.IsbVirtualCaster{
+			Type:         proto.IsbVirtualCaster_Warlock,
+			StartDelay:   sbFrequency,
+			CastInterval: sbFrequency,
+			Crit:         crit,
+		})
+	}
+	for i := int32(0); i < player.IsbSpriests; i++ {
+		casters = append(casters, &proto.IsbVirtualCaster{
+			Type:         proto.IsbVirtualCaster_ShadowPriest,
+			StartDelay:   GCDDefault.Seconds(),
+			CastInterval: GCDDefault.Seconds(),
+			SkipEvery:    6,
+		})
+	}
+	return casters
+}
+
+// Returns the ISB settings of the first raid member who configures them, or of the first
+// raid member if nobody does. In individual sims, this is the only player.
+func (raid *Raid) getIsbConfig() *IsbConfig {
+	var first *IsbConfig
+	for _, party := range raid.Parties {
+		for _, player := range party.Players {
+			if _, isDummy := player.(*TargetDummy); isDummy {
+				continue
+			}
+			config := &player.GetCharacter().IsbConfig
+			if config.isConfigured {
+				return config
+			}
+			if first == nil {
+				first = config
+			}
+		}
+	}
+	if first == nil {
+		return &IsbConfig{}
+	}
+	return first
+}
+
+// Adds virtual Warlocks applying Improved Shadow Bolt to the target, for the raid debuff.
+func ExternalIsbCaster(_ *proto.Debuffs, target *Unit) {
+	ImprovedShadowBoltAura(target, 5)
+	target.Env.GetTarget(target.Index).isb.hasVirtualWarlocks = true
+}
+
+type isbChargeConsumer struct {
+	name      string
+	unitIndex int32
+
+	// Metrics for the current iteration.
+	charges     int32
+	bonusDamage float64
+
+	// Aggregate values. These are updated after each iteration.
+	chargesSum     int32
+	bonusDamageSum float64
+}
+
+type isbVirtualCaster struct {
+	config   *proto.IsbVirtualCaster
+	consumer *isbChargeConsumer
+}
+
+// Improved Shadow Bolt on one target, shared by all Warlocks and virtual casters.
+type isbTracker struct {
+	aura        *Aura
+	rank        int32
+	damageMulti float64
+
+	hasVirtualWarlocks bool
+	config             *IsbConfig
+	virtualCasters     []isbVirtualCaster
+
+	consumers     []*isbChargeConsumer
+	unitConsumers map[*Unit]*isbChargeConsumer
+
+	chargesApplied    int32
+	chargesAppliedSum int32
+}
+
+func (isb *isbTracker) setRank(rank int32) {
+	isb.rank = max(isb.rank, rank)
+	isb.damageMulti = 1. + 0.04*float64(isb.rank)
+}
+
+func (isb *isbTracker) newConsumer(name string, unitIndex int32) *isbChargeConsumer {
+	consumer := &isbChargeConsumer{
+		name:      name,
+		unitIndex: unitIndex,
+	}
+	isb.consumers = append(isb.consumers, consumer)
+	return consumer
+}
+
+func (isb *isbTracker) consumeCharge(sim *Simulation, consumer *isbChargeConsumer, damage float64) {
+	consumer.charges++
+	consumer.bonusDamage += damage * (1 - 1/isb.damageMulti)
+	isb.aura.RemoveStack(sim)
+}
+
+// Virtual casters stand in for raid members outside the sim. Shadow Priests are left out of
+// raid sims, where the raid's own Shadow Priests consume the charges.
+func (isb *isbTracker) finalizeVirtualCasters(env *Environment) {
+	numPlayers := 0
+	for _, party := range env.Raid.Parties {
+		for _, player := range party.Players {
+			if _, isDummy := player.(*TargetDummy); !isDummy {
+				numPlayers++
+			}
+		}
+	}
+	isIndividualSim := numPlayers == 1
+
+	isb.config = env.Raid.getIsbConfig()
+	numWarlocks, numShadowPriests := 0, 0
+	for _, config := range isb.config.virtualCasters {
+		if config.CastInterval <= 0 {
+			continue
+		}
+
+		var name string
+		switch config.Type {
+		case proto.IsbVirtualCaster_Warlock:
+			if !isb.hasVirtualWarlocks {
+				continue
+			}
+			numWarlocks++
+			name = fmt.Sprintf("Virtual Warlock %d", numWarlocks)
+		case proto.IsbVirtualCaster_ShadowPriest:
+			if !isIndividualSim {
+				continue
+			}
+			numShadowPriests++
+			name = fmt.Sprintf("Virtual Shadow Priest %d", numShadowPriests)
+		}
+
+		isb.virtualCasters = append(isb.virtualCasters, isbVirtualCaster{
+			config:   config,
+			consumer: isb.newConsumer(name, -1),
+		})
+	}
+}
+
+func (isb *isbTracker) startVirtualCasters(sim *Simulation) {
+	baseStacks := TernaryInt32(isb.config.hasShadowflameRune, ISBNumStacksShadowflame, ISBNumStacksBase)
+
+	for _, caster := range isb.virtualCasters {
+		config, consumer := caster.config, caster.consumer
+		interval := DurationFromSeconds(config.CastInterval)
+		castIdx := int32(0)
+
+		var castAction *PendingAction
+		castAction = &PendingAction{
+			NextActionAt: DurationFromSeconds(config.StartDelay),
+			OnAction: func(sim *Simulation) {
+				castAction.NextActionAt = sim.CurrentTime + interval
+				sim.AddPendingAction(castAction)
+
+				castIdx++
+				if config.SkipEvery > 0 && (castIdx-1)%config.SkipEvery == 0 {
+					return
+				}
+
+				if config.Type == proto.IsbVirtualCaster_Warlock && sim.Proc(config.Crit/100.0, "External Isb Crit") {
+					isb.aura.Activate(sim)
+					isb.aura.SetStacks(sim, baseStacks)
+				} else if isb.aura.IsActive() {
+					isb.consumeCharge(sim, consumer, 0)
+				}
+			},
+		}
+		sim.AddPendingAction(castAction)
+	}
+}
+
+func (isb *isbTracker) doneIteration() {
+	isb.chargesAppliedSum += isb.chargesApplied
+	isb.chargesApplied = 0
+	for _, consumer := range isb.consumers {
+		consumer.chargesSum += consumer.charges
+		consumer.bonusDamageSum += consumer.bonusDamage
+		consumer.charges = 0
+		consumer.bonusDamage = 0
+	}
+}
+
+func (isb *isbTracker) ToProto() *proto.ImprovedShadowBoltMetrics {
+	n := float64(isb.aura.metrics.n)
+	uptime, _ := isb.aura.metrics.meanAndStdDev()
+	metrics := &proto.ImprovedShadowBoltMetrics{
+		UptimeSecondsAvg:  uptime,
+		ApplicationsAvg:   float64(isb.aura.metrics.procsSum) / n,
+		ChargesAppliedAvg: float64(isb.chargesAppliedSum) / n,
+	}
+	for _, consumer := range isb.consumers {
+		metrics.Consumers = append(metrics.Consumers, &proto.IsbChargeConsumerMetrics{
+			Name:           consumer.name,
+			UnitIndex:      consumer.unitIndex,
+			ChargesAvg:     float64(consumer.chargesSum) / n,
+			BonusDamageAvg: consumer.bonusDamageSum / n,
+		})
+	}
+	return metrics
+}
+
+func ImprovedShadowBoltAura(unit *Unit, rank int32) *Aura {
+	target := unit.Env.GetTarget(unit.Index)
+	if target.isb != nil {
+		target.isb.setRank(rank)
+		return target.isb.aura
+	}
+
+	isb := &isbTracker{
+		unitConsumers: make(map[*Unit]*isbChargeConsumer),
+	}
+	isb.setRank(rank)
+	target.isb = isb
+
+	unit.Env.RegisterPostFinalizeEffect(func() {
+		isb.finalizeVirtualCasters(unit.Env)
+	})
+
+	isb.aura = unit.GetOrRegisterAura(Aura{
+		Label:     "Improved Shadow Bolt",
+		ActionID:  ActionID{SpellID: 17800},
+		Duration:  12 * time.Second,
+		MaxStacks: 30,
+		OnReset: func(aura *Aura, sim *Simulation) {
+			isb.startVirtualCasters(sim)
+		},
+		OnDoneIteration: func(aura *Aura, sim *Simulation) {
+			isb.doneIteration()
+		},
+		OnGain: func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexShadow] *= isb.damageMulti
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexShadow] /= isb.damageMulti
+		},
+		OnStacksChange: func(aura *Aura, sim *Simulation, oldStacks int32, newStacks int32) {
+			isb.chargesApplied += max(newStacks-oldStacks, 0)
+		},
+		OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			if spell.SpellSchool.Matches(SpellSchoolShadow) && result.Landed() && result.Damage > 0 {
+				consumer := isb.unitConsumers[spell.Unit]
+				if consumer == nil {
+					consumer = isb.newConsumer(spell.Unit.Label, spell.Unit.UnitIndex)
+					isb.unitConsumers[spell.Unit] = consumer
+				}
+				isb.consumeCharge(sim, consumer, result.Damage)
+			}
+		},
+	})
+
+	return isb.aura
+}
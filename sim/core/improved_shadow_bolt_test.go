@@ -0,0 +1,162 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func setupIsbSim(player func(*proto.Player), numPlayers int) (*Simulation, *FakeAgent, *isbTracker) {
+	rsr := fakeRaidSimRequest(&proto.Encounter{
+		Targets:  []*proto.Target{newFakeSimTarget()},
+		Duration: 180,
+	}, numPlayers)
+	rsr.Raid.Debuffs = &proto.Debuffs{ImprovedShadowBolt: true}
+	if player != nil {
+		player(rsr.Raid.Parties[0].Players[0])
+	}
+
+	sim, agents := setupFakeSimWithRequest(rsr)
+	return sim, agents[0], sim.Encounter.Targets[0].isb
+}
+
+func isbConsumerNames(isb *isbTracker) []string {
+	var names []string
+	for _, consumer := range isb.consumers {
+		names = append(names, consumer.name)
+	}
+	return names
+}
+
+func TestIsbTrackerRankMerging(t *testing.T) {
+	isb := &isbTracker{}
+	isb.setRank(3)
+	isb.setRank(5)
+	isb.setRank(2)
+	if isb.rank != 5 || math.Abs(isb.damageMulti-1.2) > 1e-9 {
+		t.Fatalf("Expected the highest rank to be used, got rank %d", isb.rank)
+	}
+
+	// Lower ranks from other Warlocks share the same tracker and aura.
+	sim, _, isb := setupIsbSim(nil, 1)
+	target := sim.Encounter.TargetUnits[0]
+	if ImprovedShadowBoltAura(target, 3) != isb.aura || isb.rank != 5 {
+		t.Fatalf("Expected a lower rank to reuse the rank 5 debuff")
+	}
+}
+
+func TestIsbLegacyShadowPriestCadence(t *testing.T) {
+	sim, _, isb := setupIsbSim(func(player *proto.Player) {
+		// Keep the virtual Warlock out of the way.
+		player.IsbSbFrequency = 1000
+		player.IsbSpriests = 1
+	}, 1)
+	isb.aura.Activate(sim)
+	isb.aura.SetStacks(sim, 30)
+	priest := isb.consumers[1]
+
+	// The first GCD at 1.5s is skipped.
+	for _, check := range []struct {
+		at      time.Duration
+		charges int32
+	}{
+		{at: time.Millisecond * 2900, charges: 0},
+		{at: time.Millisecond * 3000, charges: 1},
+		{at: time.Millisecond * 9000, charges: 5},
+		{at: time.Millisecond * 10500, charges: 5},
+	} {
+		runFakeSimUntil(sim, check.at)
+		if priest.charges != check.charges {
+			t.Fatalf("Expected %d charges consumed at %s, got %d", check.charges, check.at, priest.charges)
+		}
+	}
+
+	isb.aura.Refresh(sim)
+	runFakeSimUntil(sim, time.Second*12)
+	if priest.charges != 6 {
+		t.Fatalf("Expected 6 charges consumed at 12s, got %d", priest.charges)
+	}
+}
+
+func TestIsbVirtualCasters(t *testing.T) {
+	withPriest := func(player *proto.Player) {
+		player.IsbSpriests = 1
+	}
+
+	_, _, isb := setupIsbSim(withPriest, 1)
+	if names := isbConsumerNames(isb); len(names) != 2 || names[0] != "Virtual Warlock 1" || names[1] != "Virtual Shadow Priest 1" {
+		t.Fatalf("Expected a virtual Warlock and Shadow Priest in individual sims, got %v", names)
+	}
+
+	// Raid sims use the raid's own Shadow Priests.
+	_, _, isb = setupIsbSim(withPriest, 2)
+	if names := isbConsumerNames(isb); len(names) != 1 || names[0] != "Virtual Warlock 1" {
+		t.Fatalf("Expected only a virtual Warlock in raid sims, got %v", names)
+	}
+
+	// An explicit schedule replaces the legacy settings.
+	_, _, isb = setupIsbSim(func(player *proto.Player) {
+		player.IsbSpriests = 1
+		player.IsbVirtualCasters = []*proto.IsbVirtualCaster{
+			{Type: proto.IsbVirtualCaster_Warlock, CastInterval: 2.5},
+			{Type: proto.IsbVirtualCaster_Warlock, CastInterval: 2.5},
+			{Type: proto.IsbVirtualCaster_ShadowPriest},
+		}
+	}, 1)
+	if names := isbConsumerNames(isb); len(names) != 2 || names[1] != "Virtual Warlock 2" {
+		t.Fatalf("Expected 2 virtual Warlocks and no Shadow Priest without a cast interval, got %v", names)
+	}
+}
+
+func TestIsbConsumerMetrics(t *testing.T) {
+	sim, fa, isb := setupIsbSim(func(player *proto.Player) {
+		player.IsbSbFrequency = 1000
+	}, 1)
+	target := sim.Encounter.TargetUnits[0]
+	isb.aura.Activate(sim)
+	isb.aura.SetStacks(sim, 4)
+
+	fa.Nuke.CalcAndDealDamage(sim, target, 100, fa.Nuke.OutcomeAlwaysHit)
+	fa.Nuke.CalcAndDealDamage(sim, target, 100, fa.Nuke.OutcomeAlwaysHit)
+	if isb.aura.GetStacks() != 2 {
+		t.Fatalf("Expected 2 charges left, got %d", isb.aura.GetStacks())
+	}
+
+	sim.Cleanup()
+	metrics := isb.ToProto()
+	if metrics.ChargesAppliedAvg != 4 {
+		t.Fatalf("Expected 4 charges applied, got %0.3f", metrics.ChargesAppliedAvg)
+	}
+	consumer := metrics.Consumers[1]
+	if consumer.UnitIndex != fa.UnitIndex || consumer.ChargesAvg != 2 {
+		t.Fatalf("Expected %s to consume 2 charges, got %0.3f", fa.Label, consumer.ChargesAvg)
+	}
+	// Each hit deals 120 damage, 20 of which is from the debuff.
+	if math.Abs(consumer.BonusDamageAvg-40) > 1e-9 {
+		t.Fatalf("Expected 40 bonus damage, got %0.3f", consumer.BonusDamageAvg)
+	}
+}
+
+func TestIsbConfigFromConfiguringPlayer(t *testing.T) {
+	rsr := fakeSimRequest(&proto.Encounter{
+		Targets:  []*proto.Target{newFakeSimTarget()},
+		Duration: 180,
+	})
+	rsr.Raid.Debuffs = &proto.Debuffs{ImprovedShadowBolt: true}
+	// The first party is empty, and only the second player of the second party sets up ISB.
+	players := []*proto.Player{fakeSimRequest(nil).Raid.Parties[0].Players[0], fakeSimRequest(nil).Raid.Parties[0].Players[0]}
+	players[1].IsbUsingShadowflame = true
+	players[1].IsbVirtualCasters = []*proto.IsbVirtualCaster{{Type: proto.IsbVirtualCaster_Warlock, CastInterval: 2}}
+	rsr.Raid.Parties = []*proto.Party{{Players: []*proto.Player{{}}}, {Players: players}}
+
+	sim, _ := setupFakeSimWithRequest(rsr)
+	isb := sim.Encounter.Targets[0].isb
+	if isb.config != &sim.Raid.Parties[1].Players[1].GetCharacter().IsbConfig {
+		t.Fatalf("Expected the ISB settings of the player who configures them")
+	}
+	if len(isb.virtualCasters) != 1 || isb.virtualCasters[0].config.CastInterval != 2 {
+		t.Fatalf("Expected the configured virtual Warlock, got %d virtual casters", len(isb.virtualCasters))
+	}
+}
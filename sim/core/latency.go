@@ -0,0 +1,178 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// A random delay drawn from a configured distribution.
+type latencyDistribution struct {
+	distribution proto.LatencyDistribution
+	label        string
+
+	mean   float64 // Milliseconds
+	stddev float64 // Milliseconds
+
+	// Log-normal parameters, derived from the mean and standard deviation.
+	mu    float64
+	sigma float64
+
+	samples []float64
+}
+
+func newLatencyDistribution(config *proto.LatencyProfile, label string) *latencyDistribution {
+	if config == nil {
+		return nil
+	}
+
+	ld := &latencyDistribution{
+		distribution: config.Distribution,
+		label:        label,
+		mean:         max(0, config.MeanMs),
+		stddev:       max(0, config.StddevMs),
+	}
+
+	switch ld.distribution {
+	case proto.LatencyDistribution_LatencyLogNormal:
+		if ld.mean > 0 {
+			variance := math.Log(1 + (ld.stddev*ld.stddev)/(ld.mean*ld.mean))
+			ld.sigma = math.Sqrt(variance)
+			ld.mu = math.Log(ld.mean) - variance/2
+		}
+	case proto.LatencyDistribution_LatencyEmpirical:
+		for _, sample := range config.SamplesMs {
+			ld.samples = append(ld.samples, max(0, sample))
+		}
+		if len(ld.samples) == 0 {
+			ld.distribution = proto.LatencyDistribution_LatencyFixed
+		}
+	}
+
+	return ld
+}
+
+// Draws a delay. Fixed distributions never use the RNG, so they do not change results
+// for sims which don't need randomness.
+func (ld *latencyDistribution) sample(sim *Simulation) time.Duration {
+	var ms float64
+	switch ld.distribution {
+	case proto.LatencyDistribution_LatencyNormal:
+		ms = ld.mean + ld.stddev*sim.RandomNormFloat(ld.label)
+	case proto.LatencyDistribution_LatencyLogNormal:
+		if ld.mean > 0 {
+			ms = math.Exp(ld.mu + ld.sigma*sim.RandomNormFloat(ld.label))
+		}
+	case proto.LatencyDistribution_LatencyEmpirical:
+		idx := int(sim.RandomFloat(ld.label) * float64(len(ld.samples)))
+		ms = ld.samples[min(idx, len(ld.samples)-1)]
+	default:
+		ms = ld.mean
+	}
+	return max(0, DurationFromSeconds(ms/1000))
+}
+
+// Models the delays between a player deciding to do something and it happening in game.
+type LatencyModel struct {
+	network  *latencyDistribution
+	reaction *latencyDistribution
+
+	// Casts pressed within this long of the GCD ending are queued by the client.
+	SpellQueueWindow time.Duration
+}
+
+func newLatencyModel(config *proto.LatencyModel) *LatencyModel {
+	if config == nil {
+		return nil
+	}
+	return &LatencyModel{
+		network:          newLatencyDistribution(config.Network, "Network Latency"),
+		reaction:         newLatencyDistribution(config.Reaction, "Reaction Time"),
+		SpellQueueWindow: max(0, time.Duration(config.SpellQueueWindowMs)*time.Millisecond),
+	}
+}
+
+// Returns a sampled network latency, or 0 if this unit has no latency model.
+func (unit *Unit) NetworkLatency(sim *Simulation) time.Duration {
+	if unit.LatencyModel == nil || unit.LatencyModel.network == nil {
+		return 0
+	}
+	return unit.LatencyModel.network.sample(sim)
+}
+
+// Returns a sampled reaction time, falling back to the unit's fixed ReactionTime.
+func (unit *Unit) SampleReactionTime(sim *Simulation) time.Duration {
+	if unit.LatencyModel == nil || unit.LatencyModel.reaction == nil {
+		return unit.ReactionTime
+	}
+	return unit.LatencyModel.reaction.sample(sim)
+}
+
+// Delay before the next queued cast reaches the server. The spell queue window lets
+// the client send the cast early, so only latency beyond the window is lost.
+func (unit *Unit) queuedCastDelay(sim *Simulation) time.Duration {
+	if unit.LatencyModel == nil || unit.LatencyModel.network == nil {
+		return 0
+	}
+	return max(0, unit.NetworkLatency(sim)-unit.LatencyModel.SpellQueueWindow)
+}
+
+// Channels can't be queued, so the full latency applies on top of the clip delay.
+func (unit *Unit) channelClipDelay(sim *Simulation) time.Duration {
+	return unit.ChannelClipDelay + unit.NetworkLatency(sim)
+}
+
+// Delay before a cast reaches the server. Only casts which occupy the unit or come from
+// the rotation are sent by the player, the rest are procs which happen server side.
+// Channels and off-GCD casts aren't queued, so the full latency applies to them.
+func (spell *Spell) castLatency(sim *Simulation) time.Duration {
+	effectiveTime := spell.CurCast.EffectiveTime()
+	if effectiveTime == 0 && !spell.Flags.Matches(SpellFlagAPL) {
+		return 0
+	}
+	if effectiveTime == 0 || spell.Flags.Matches(SpellFlagChanneled) {
+		return spell.Unit.NetworkLatency(sim)
+	}
+	return spell.Unit.queuedCastDelay(sim)
+}
+
+// Off-GCD casts don't trigger the GCD, but still hold up the unit's next action until
+// they've reached the server.
+func (spell *Spell) delayNextAction(sim *Simulation, castDelay time.Duration) {
+	if castDelay > 0 && spell.Unit.GCD.ReadyAt() < sim.CurrentTime+castDelay {
+		spell.Unit.SetGCDTimer(sim, sim.CurrentTime+castDelay)
+	}
+}
+
+// Reaction time to an aura being gained, resampled each time the aura is (re)applied
+// so that repeated evaluations of the same proc see a consistent delay.
+type auraReactionTime struct {
+	unit         *Unit
+	startedAt    time.Duration
+	reactionTime time.Duration
+}
+
+func newAuraReactionTime(unit *Unit) *auraReactionTime {
+	art := &auraReactionTime{
+		unit:         unit,
+		reactionTime: -1,
+	}
+	// Auras often start at the same time in every iteration, which would otherwise
+	// reuse the previous iteration's sample.
+	unit.RegisterResetEffect(func(sim *Simulation) {
+		art.reactionTime = -1
+	})
+	return art
+}
+
+func (art *auraReactionTime) get(sim *Simulation, aura *Aura) time.Duration {
+	if art.unit.LatencyModel == nil || art.unit.LatencyModel.reaction == nil {
+		return art.unit.ReactionTime
+	}
+	if startedAt := aura.StartedAt(); startedAt != art.startedAt || art.reactionTime < 0 {
+		art.startedAt = startedAt
+		art.reactionTime = art.unit.SampleReactionTime(sim)
+	}
+	return art.reactionTime
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestLatencyDistribution(t *testing.T) {
+	sim := &Simulation{rand: NewSplitMix(1)}
+
+	fixed := newLatencyDistribution(&proto.LatencyProfile{MeanMs: 100}, "Test")
+	if latency := fixed.sample(sim); latency != time.Millisecond*100 {
+		t.Fatalf("Expected fixed latency of 100ms, got %s", latency)
+	}
+
+	empirical := newLatencyDistribution(&proto.LatencyProfile{
+		Distribution: proto.LatencyDistribution_LatencyEmpirical,
+		SamplesMs:    []float64{20, 40},
+	}, "Test")
+	for i := 0; i < 100; i++ {
+		if latency := empirical.sample(sim); latency != time.Millisecond*20 && latency != time.Millisecond*40 {
+			t.Fatalf("Expected an empirical sample, got %s", latency)
+		}
+	}
+
+	logNormal := newLatencyDistribution(&proto.LatencyProfile{
+		Distribution: proto.LatencyDistribution_LatencyLogNormal,
+		MeanMs:       80,
+		StddevMs:     30,
+	}, "Test")
+	var total time.Duration
+	numSamples := 10000
+	for i := 0; i < numSamples; i++ {
+		total += logNormal.sample(sim)
+	}
+	if mean := total.Seconds() * 1000 / float64(numSamples); math.Abs(mean-80) > 2 {
+		t.Fatalf("Expected log-normal mean near 80ms, got %0.3fms", mean)
+	}
+}
+
+func setupLatencySim() (*Simulation, *FakeAgent, *Unit) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	fa.Rotation = &APLRotation{unit: &fa.Unit}
+	fa.LatencyModel = newLatencyModel(&proto.LatencyModel{
+		Network:            &proto.LatencyProfile{MeanMs: 100},
+		SpellQueueWindowMs: 40,
+	})
+	return sim, fa, sim.Encounter.TargetUnits[0]
+}
+
+func TestHardcastLatency(t *testing.T) {
+	sim, fa, target := setupLatencySim()
+
+	// Queued casts only lose the latency beyond the spell queue window.
+	fa.Nuke.Cast(sim, target)
+	if fa.Hardcast.Expires != time.Millisecond*2060 || fa.GCD.ReadyAt() != time.Millisecond*2060 {
+		t.Fatalf("Expected the cast to complete along with the GCD at 2.06s, got %s and %s", fa.Hardcast.Expires, fa.GCD.ReadyAt())
+	}
+
+	runFakeSimUntil(sim, time.Millisecond*2050)
+	if fa.Nuke.SpellMetrics[target.UnitIndex].Hits != 0 {
+		t.Fatalf("Expected no damage before the delayed cast completes")
+	}
+	runFakeSimUntil(sim, time.Millisecond*2060)
+	if fa.Nuke.SpellMetrics[target.UnitIndex].Hits != 1 {
+		t.Fatalf("Expected the cast to complete at 2.06s")
+	}
+}
+
+func TestOffGCDLatency(t *testing.T) {
+	sim, fa, target := setupLatencySim()
+
+	// Procs aren't sent by the player, so don't delay anything.
+	fa.Spell.Cast(sim, target)
+	if !fa.GCD.IsReady(sim) {
+		t.Fatalf("Expected no delay from procs")
+	}
+
+	// Off-GCD casts and channels can't be queued, so take the full latency.
+	for _, spell := range []*Spell{fa.Spell, fa.Channel} {
+		spell.Flags |= SpellFlagAPL
+		fa.GCD.Reset()
+		spell.Cast(sim, target)
+		if fa.GCD.ReadyAt() != time.Millisecond*100 {
+			t.Fatalf("Expected %s to delay the next action until 100ms, got %s", spell.ActionID, fa.GCD.ReadyAt())
+		}
+	}
+}
+
+func TestAuraReactionTimeResets(t *testing.T) {
+	sim, fa, _ := setupLatencySim()
+	fa.LatencyModel.reaction = newLatencyDistribution(&proto.LatencyProfile{
+		Distribution: proto.LatencyDistribution_LatencyNormal,
+		MeanMs:       200,
+		StddevMs:     50,
+	}, "Test")
+	art := newAuraReactionTime(&fa.Unit)
+	aura := fa.Dot.Aura
+
+	first := art.get(sim, aura)
+	if art.get(sim, aura) != first {
+		t.Fatalf("Expected the same reaction time for the same aura application")
+	}
+
+	// The aura starts at the same time in the next iteration, but gets a new sample.
+	sim.Cleanup()
+	sim.Reset()
+	if art.get(sim, aura) == first {
+		t.Fatalf("Expected a new reaction time in the next iteration")
+	}
+}
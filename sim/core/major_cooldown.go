@@ -56,6 +56,12 @@ type MajorCooldown struct {
 	// automatic timing.
 	ShouldActivate CooldownActivationCondition
 
+	// If set, this cooldown is held when the current target is expected to die before
+	// the fight ends and sooner than this. Defaults to the duration of the spell's related
+	// self buff for DPS cooldowns. Only used with time to die estimates, and ignored when
+	// timings are specified.
+	MinTargetTimeToDie time.Duration
+
 	// Fixed timings at which to use this cooldown. If these are specified, they
 	// are used instead of ShouldActivate.
 	timings []time.Duration
@@ -131,6 +137,14 @@ func (mcd *MajorCooldown) shouldActivateHelper(sim *Simulation, character *Chara
 		return sim.CurrentTime >= mcd.timings[mcd.numUsages]
 	}
 
+	if mcd.MinTargetTimeToDie > 0 && sim.Encounter.UseTimeToDieEstimate && sim.Encounter.EndFightAtHealth > 0 && character.CurrentTarget != nil && character.CurrentTarget.Type == EnemyUnit {
+		// Using a cooldown at the end of the fight still helps, so only hold it for targets which die before then.
+		timeToDie := character.Env.GetTarget(character.CurrentTarget.Index).TimeToDie(sim)
+		if timeToDie < mcd.MinTargetTimeToDie && timeToDie < sim.GetRemainingDuration() {
+			return false
+		}
+	}
+
 	if mcd.Type.Matches(CooldownTypeSurvival) { // survival cooldowns are now skipped unless HpPercentForDefensives is > 0
 		if character.CurrentHealthPercent() > character.cooldownConfigs.HpPercentForDefensives {
 			return false
@@ -253,6 +267,12 @@ func (mcdm *majorCooldownManager) AddMajorCooldown(mcd MajorCooldown) {
 
 	mcd.Spell.Flags |= SpellFlagAPL | SpellFlagMCD
 
+	if mcd.MinTargetTimeToDie == 0 && mcd.Type.Matches(CooldownTypeDPS) {
+		if buff := mcd.Spell.RelatedSelfBuff; buff != nil && buff.Duration > 0 && buff.Duration != NeverExpires {
+			mcd.MinTargetTimeToDie = buff.Duration
+		}
+	}
+
 	if mcd.ShouldActivate == nil {
 		mcd.ShouldActivate = func(sim *Simulation, character *Character) bool {
 			return true
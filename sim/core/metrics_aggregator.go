@@ -91,17 +91,48 @@ type UnitMetrics struct {
 	hps    DistributionMetrics
 	tto    DistributionMetrics
 
+	effectiveHps    DistributionMetrics
+	overhealPercent DistributionMetrics
+
+	movementDpsLost DistributionMetrics
+
 	tmiList   []tmiListItem
 	isTanking bool
 	tmiBin    int32
+	// Whether healing counts against TMI, which is only the case with virtual healers.
+	tmiIncludesHealing bool
+
+	ehp         DistributionMetrics
+	maxSpike    DistributionMetrics
+	timeToDeath DistributionMetrics
+
+	tracksSurvival    bool
+	spikeWindow       int32
+	damageTakenList   []damageTakenItem
+	iterationEhp      []float64
+	schoolEhpSums     []float64
+	damageTaken       map[*Spell]*DamageTakenMetrics
+	damageTakenSpells []*Spell
 
 	CharacterIterationMetrics
 
 	// Aggregate values. These are updated after each iteration.
-	numItersDead int32
-	oomTimeSum   float64
-	actions      map[ActionID]*ActionMetrics
-	resources    []*ResourceMetrics
+	numItersDead          int32
+	oomTimeSum            float64
+	forcedMovementTimeSum float64
+	missedInterruptsSum   int32
+	missedInterruptDamage float64
+	dispellableDebuffSum  float64
+	aggroSwapsSum         int32
+	tankingTimeSum        float64
+	aggroPullsSum         int32
+	numItersAggroWipe     int32
+	effectiveHealingSum   float64
+	overhealingSum        float64
+	absorbedShieldingSum  float64
+	wastedShieldingSum    float64
+	actions               map[ActionID]*ActionMetrics
+	resources             []*ResourceMetrics
 }
 
 // Metrics for the current iteration, for 1 agent. Keep this as a separate
@@ -116,6 +147,23 @@ type CharacterIterationMetrics struct {
 	OOMTime time.Duration // time spent not casting and waiting for regen.
 
 	FirstOOMTimestamp time.Duration // Timestamp at which unit first went OOM.
+
+	ForcedMovementTime   time.Duration // Time spent moving or unable to act because of encounter movement events.
+	ForcedMovementDamage float64       // Damage done during that time.
+
+	MissedInterrupts      int32         // Interruptible casts by this target which weren't interrupted.
+	MissedInterruptDamage float64       // Damage dealt by those casts.
+	DispellableDebuffTime time.Duration // Time spent with dispellable debuffs from target abilities.
+
+	AggroSwaps  int32         // Times this target switched to attacking a different unit.
+	TankingTime time.Duration // Time spent being attacked by targets, summed over targets.
+	AggroPulls  int32         // Times this player pulled aggro from the tank.
+	AggroWipe   bool          // Whether an aggro pull by this player wiped the raid.
+
+	Healing           float64 // Healing done, including overhealing.
+	Overhealing       float64 // Healing done in excess of the targets' missing health.
+	AbsorbedShielding float64 // Shielding done which absorbed damage.
+	WastedShielding   float64 // Shielding done which expired or was replaced unused.
 }
 
 type ActionMetrics struct {
@@ -182,7 +230,10 @@ type SpellMetrics struct {
 	TotalThreat                 float64 // Threat generated by all casts of this spell.
 	TotalHealing                float64 // Healing done by all casts of this spell.
 	TotalCritHealing            float64 // Healing done by all critical casts of this spell.
+	TotalOverhealing            float64 // Healing done by all casts of this spell in excess of the target's missing health.
 	TotalShielding              float64 // Shielding done by all casts of this spell.
+	TotalAbsorbedShielding      float64 // Shielding from this spell which absorbed damage.
+	TotalWastedShielding        float64 // Shielding from this spell which expired or was replaced unused.
 	TotalCastTime               time.Duration
 }
 
@@ -219,7 +270,11 @@ type TargetedActionMetrics struct {
 	Threat                 float64
 	Healing                float64
 	CritHealing            float64
+	Overhealing            float64
+	EffectiveHealing       float64
 	Shielding              float64
+	AbsorbedShielding      float64
+	WastedShielding        float64
 	CastTime               time.Duration
 }
 
@@ -258,7 +313,11 @@ func (tam *TargetedActionMetrics) ToProto(unitIndex int32) *proto.TargetedAction
 		Threat:                 tam.Threat,
 		Healing:                tam.Healing,
 		CritHealing:            tam.CritHealing,
+		Overhealing:            tam.Overhealing,
+		EffectiveHealing:       tam.EffectiveHealing,
 		Shielding:              tam.Shielding,
+		AbsorbedShielding:      tam.AbsorbedShielding,
+		WastedShielding:        tam.WastedShielding,
 		CastTimeMs:             float64(tam.CastTime.Milliseconds()),
 	}
 }
@@ -273,6 +332,15 @@ func NewUnitMetrics() UnitMetrics {
 		hps:     NewDistributionMetrics(),
 		tto:     NewDistributionMetrics(),
 		actions: make(map[ActionID]*ActionMetrics),
+
+		effectiveHps:    NewDistributionMetrics(),
+		overhealPercent: NewDistributionMetrics(),
+
+		movementDpsLost: NewDistributionMetrics(),
+
+		ehp:         NewDistributionMetrics(),
+		maxSpike:    NewDistributionMetrics(),
+		timeToDeath: NewDistributionMetrics(),
 	}
 }
 
@@ -284,8 +352,10 @@ type ResourceMetrics struct {
 	Gain       float64
 	ActualGain float64
 
-	EventsFromPreviousIterations     int32
-	ActualGainFromPreviousIterations float64
+	EventsFromPreviousIterations int32
+	// Tracked separately rather than subtracted from ActualGain, so the result
+	// doesn't depend on how many iterations came before.
+	ActualGainInCurrentIteration float64
 }
 
 func (resourceMetrics *ResourceMetrics) ToProto() *proto.ResourceMetrics {
@@ -301,19 +371,20 @@ func (resourceMetrics *ResourceMetrics) ToProto() *proto.ResourceMetrics {
 
 func (resourceMetrics *ResourceMetrics) reset() {
 	resourceMetrics.EventsFromPreviousIterations = resourceMetrics.Events
-	resourceMetrics.ActualGainFromPreviousIterations = resourceMetrics.ActualGain
+	resourceMetrics.ActualGainInCurrentIteration = 0
 }
 func (resourceMetrics *ResourceMetrics) EventsForCurrentIteration() int32 {
 	return resourceMetrics.Events - resourceMetrics.EventsFromPreviousIterations
 }
 func (resourceMetrics *ResourceMetrics) ActualGainForCurrentIteration() float64 {
-	return resourceMetrics.ActualGain - resourceMetrics.ActualGainFromPreviousIterations
+	return resourceMetrics.ActualGainInCurrentIteration
 }
 
 func (resourceMetrics *ResourceMetrics) AddEvent(gain float64, actualGain float64) {
 	resourceMetrics.Events++
 	resourceMetrics.Gain += gain
 	resourceMetrics.ActualGain += actualGain
+	resourceMetrics.ActualGainInCurrentIteration += actualGain
 }
 
 func (unitMetrics *UnitMetrics) NewResourceMetrics(actionID ActionID, resourceType proto.ResourceType) *ResourceMetrics {
@@ -418,7 +489,11 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 		tam.Threat += spellTargetMetrics.TotalThreat
 		tam.Healing += spellTargetMetrics.TotalHealing
 		tam.CritHealing += spellTargetMetrics.TotalCritHealing
+		tam.Overhealing += spellTargetMetrics.TotalOverhealing
+		tam.EffectiveHealing += spellTargetMetrics.TotalHealing - spellTargetMetrics.TotalOverhealing
 		tam.Shielding += spellTargetMetrics.TotalShielding
+		tam.AbsorbedShielding += spellTargetMetrics.TotalAbsorbedShielding
+		tam.WastedShielding += spellTargetMetrics.TotalWastedShielding
 		if !spell.Flags.Matches(SpellFlagPassiveSpell) {
 			tam.CastTime += spellTargetMetrics.TotalCastTime
 		}
@@ -431,6 +506,11 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 			unitMetrics.threat.Total += spellTargetMetrics.TotalThreat
 		} else {
 			unitMetrics.hps.Total += spellTargetMetrics.TotalHealing + spellTargetMetrics.TotalShielding
+			unitMetrics.effectiveHps.Total += spellTargetMetrics.TotalHealing - spellTargetMetrics.TotalOverhealing + spellTargetMetrics.TotalAbsorbedShielding
+			unitMetrics.Healing += spellTargetMetrics.TotalHealing
+			unitMetrics.Overhealing += spellTargetMetrics.TotalOverhealing
+			unitMetrics.AbsorbedShielding += spellTargetMetrics.TotalAbsorbedShielding
+			unitMetrics.WastedShielding += spellTargetMetrics.TotalWastedShielding
 		}
 	}
 }
@@ -469,6 +549,14 @@ func (unitMetrics *UnitMetrics) reset() {
 	unitMetrics.tmiList = nil
 	unitMetrics.hps.reset()
 	unitMetrics.tto.reset()
+	unitMetrics.movementDpsLost.reset()
+	unitMetrics.effectiveHps.reset()
+	unitMetrics.overhealPercent.reset()
+	unitMetrics.ehp.reset()
+	unitMetrics.maxSpike.reset()
+	unitMetrics.timeToDeath.reset()
+	unitMetrics.damageTakenList = unitMetrics.damageTakenList[:0]
+	clear(unitMetrics.iterationEhp)
 	unitMetrics.CharacterIterationMetrics = CharacterIterationMetrics{}
 
 	for _, resourceMetrics := range unitMetrics.resources {
@@ -506,6 +594,23 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 		unitMetrics.tmi.Total *= sim.Duration.Seconds()
 	}
 
+	if forcedTime := unitMetrics.ForcedMovementTime.Seconds(); forcedTime > 0 && forcedTime < sim.Duration.Seconds() {
+		// Assume the damage done outside of forced movement would have continued through it.
+		normalDps := (unitMetrics.dps.Total - unitMetrics.ForcedMovementDamage) / (sim.Duration.Seconds() - forcedTime)
+		unitMetrics.movementDpsLost.Total = max(0, normalDps*forcedTime-unitMetrics.ForcedMovementDamage)
+	}
+
+	if unitMetrics.Healing > 0 {
+		unitMetrics.overhealPercent.Total = unitMetrics.Overhealing / unitMetrics.Healing * 100
+
+		// Hack because of the way DistributionMetrics does its calculations.
+		unitMetrics.overhealPercent.Total *= sim.Duration.Seconds()
+	}
+
+	if unitMetrics.tracksSurvival {
+		unitMetrics.doneSurvivalIteration(sim)
+	}
+
 	unitMetrics.dps.doneIteration(sim)
 	unitMetrics.dpasp.doneIteration(sim)
 	unitMetrics.threat.doneIteration(sim)
@@ -513,8 +618,28 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 	unitMetrics.tmi.doneIteration(sim)
 	unitMetrics.hps.doneIteration(sim)
 	unitMetrics.tto.doneIteration(sim)
+	unitMetrics.movementDpsLost.doneIteration(sim)
+	unitMetrics.effectiveHps.doneIteration(sim)
+	unitMetrics.overhealPercent.doneIteration(sim)
+	unitMetrics.ehp.doneIteration(sim)
+	unitMetrics.maxSpike.doneIteration(sim)
+	unitMetrics.timeToDeath.doneIteration(sim)
 
 	unitMetrics.oomTimeSum += unitMetrics.OOMTime.Seconds()
+	unitMetrics.forcedMovementTimeSum += unitMetrics.ForcedMovementTime.Seconds()
+	unitMetrics.missedInterruptsSum += unitMetrics.MissedInterrupts
+	unitMetrics.missedInterruptDamage += unitMetrics.MissedInterruptDamage
+	unitMetrics.dispellableDebuffSum += unitMetrics.DispellableDebuffTime.Seconds()
+	unitMetrics.aggroSwapsSum += unitMetrics.AggroSwaps
+	unitMetrics.tankingTimeSum += unitMetrics.TankingTime.Seconds()
+	unitMetrics.aggroPullsSum += unitMetrics.AggroPulls
+	if unitMetrics.AggroWipe {
+		unitMetrics.numItersAggroWipe++
+	}
+	unitMetrics.effectiveHealingSum += unitMetrics.Healing - unitMetrics.Overhealing
+	unitMetrics.overhealingSum += unitMetrics.Overhealing
+	unitMetrics.absorbedShieldingSum += unitMetrics.AbsorbedShielding
+	unitMetrics.wastedShieldingSum += unitMetrics.WastedShielding
 	if unitMetrics.Died {
 		unitMetrics.numItersDead++
 	}
@@ -604,7 +729,30 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		Tto:           unitMetrics.tto.ToProto(),
 		SecondsOomAvg: unitMetrics.oomTimeSum / n,
 		ChanceOfDeath: float64(unitMetrics.numItersDead) / n,
+
+		SecondsForcedMovementAvg: unitMetrics.forcedMovementTimeSum / n,
+		MovementDpsLost:          unitMetrics.movementDpsLost.ToProto(),
+
+		MissedInterruptsAvg:         float64(unitMetrics.missedInterruptsSum) / n,
+		MissedInterruptDamageAvg:    unitMetrics.missedInterruptDamage / n,
+		DispellableDebuffSecondsAvg: unitMetrics.dispellableDebuffSum / n,
+		AggroSwapsAvg:               float64(unitMetrics.aggroSwapsSum) / n,
+		TankingSecondsAvg:           unitMetrics.tankingTimeSum / n,
+		AggroPullsAvg:               float64(unitMetrics.aggroPullsSum) / n,
+		ChanceOfAggroWipe:           float64(unitMetrics.numItersAggroWipe) / n,
+
+		EffectiveHps:         unitMetrics.effectiveHps.ToProto(),
+		OverhealPercent:      unitMetrics.overhealPercent.ToProto(),
+		EffectiveHealingAvg:  unitMetrics.effectiveHealingSum / n,
+		OverhealingAvg:       unitMetrics.overhealingSum / n,
+		AbsorbedShieldingAvg: unitMetrics.absorbedShieldingSum / n,
+		WastedShieldingAvg:   unitMetrics.wastedShieldingSum / n,
+
+		Ehp:         unitMetrics.ehp.ToProto(),
+		MaxSpike:    unitMetrics.maxSpike.ToProto(),
+		TimeToDeath: unitMetrics.timeToDeath.ToProto(),
 	}
+	unitMetrics.survivalToProto(protoMetrics)
 
 	protoMetrics.Actions = make([]*proto.ActionMetrics, 0, len(unitMetrics.actions))
 	for actionID, action := range unitMetrics.actions {
@@ -0,0 +1,30 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestResourceMetricsCurrentIteration(t *testing.T) {
+	metrics := &ResourceMetrics{ActionID: ActionID{OtherID: proto.OtherAction_OtherActionManaGain}, Type: proto.ResourceType_ResourceTypeMana}
+
+	// The gain in the current iteration must not depend on the totals of the iterations
+	// before, otherwise threat from resource gains differs between single and multi
+	// threaded sims.
+	for i := 0; i < 1000; i++ {
+		metrics.reset()
+		metrics.AddEvent(0.2, 0.1)
+		metrics.AddEvent(0.2, 0.1)
+		if gain := metrics.ActualGainForCurrentIteration(); gain != 0.1+0.1 {
+			t.Fatalf("Expected an actual gain of exactly 0.2 in iteration %d, got %v", i, gain)
+		}
+		if events := metrics.EventsForCurrentIteration(); events != 2 {
+			t.Fatalf("Expected 2 events in iteration %d, got %d", i, events)
+		}
+	}
+
+	if !WithinToleranceFloat64(200, metrics.ActualGain, 0.000001) || !WithinToleranceFloat64(400, metrics.Gain, 0.000001) || metrics.Events != 2000 {
+		t.Fatalf("Expected the totals to cover every iteration")
+	}
+}
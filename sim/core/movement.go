@@ -80,6 +80,13 @@ type MovementHandler struct {
 	moveSpell          *Spell
 	moveSpeedBonuses   *MoveHeap
 	moveSpeedPenalties *MoveHeap
+
+	// Movement and downtime forced by the encounter, see encounter_movement.go.
+	forcedAura    *Aura
+	forcedMoving  bool
+	incapacitated bool
+	downtimeUntil time.Duration
+	damageAtStart float64
 }
 
 func (unit *Unit) initMovement() {
@@ -116,7 +123,7 @@ func (unit *Unit) initMovement() {
 			unit.AutoAttacks.EnableAutoSwing(sim)
 
 			// Simulate the delay from starting attack
-			unit.AutoAttacks.DelayMeleeBy(sim, time.Millisecond*50)
+			unit.AutoAttacks.DelayMeleeBy(sim, time.Millisecond*50+unit.NetworkLatency(sim))
 		},
 	})
 
@@ -132,7 +139,17 @@ func (unit *Unit) initMovement() {
 }
 
 func (unit *Unit) IsMoving() bool {
-	return unit.MovementHandler.Moving
+	return unit.MovementHandler.Moving || unit.MovementHandler.forcedMoving
+}
+
+// Whether the unit is being moved by the encounter, see encounter_movement.go.
+func (unit *Unit) IsForcedMoving() bool {
+	return unit.MovementHandler.forcedMoving
+}
+
+// Whether the unit is unable to act, e.g. during downtime forced by the encounter.
+func (unit *Unit) IsIncapacitated() bool {
+	return unit.MovementHandler.incapacitated
 }
 
 func (unit *Unit) MoveTo(moveRange float64, sim *Simulation) {
@@ -0,0 +1,460 @@
+package core
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	goproto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+)
+
+const (
+	defaultPartyOptimizerResults = 3
+	// Swap rounds are capped so huge rosters still finish in a reasonable time.
+	maxPartyOptimizerRounds     = 10
+	minPartyScreeningIterations = 20
+)
+
+// partyOptimizer searches party layouts for a roster of players. Layouts are screened with
+// short simulations while hill climbing over player swaps, and the best ones are then
+// refined with the full iteration count.
+type partyOptimizer struct {
+	// SingleRaidSimRunner used to evaluate one layout.
+	SingleRaidSimRunner raidSimRunner
+	// Request used for this optimization.
+	Request *proto.PartyOptimizerRequest
+
+	numParties int
+	weights    []float64
+	// Signature of each roster player's settings, ignoring their name. Swapping players
+	// with the same signature can't change the results, so those swaps are skipped.
+	signatures []string
+	// Party buffs each roster player provides on their own, from Party.GetPartyBuffs.
+	partyBuffs []*proto.PartyBuffs
+
+	mu    sync.Mutex
+	cache map[string]*partyLayoutResult
+}
+
+// partyLayout holds the roster index of the player in each raid slot, or -1 if empty.
+type partyLayout []int
+
+type partyLayoutResult struct {
+	layout partyLayout
+	raid   *proto.Raid
+	result *proto.RaidSimResult
+	score  float64
+}
+
+func OptimizeParties(signals simsignals.Signals, request *proto.PartyOptimizerRequest) *proto.PartyOptimizerResult {
+	optimizer := &partyOptimizer{
+		SingleRaidSimRunner: runSim,
+		Request:             request,
+	}
+	return optimizer.Run(signals)
+}
+
+func (po *partyOptimizer) Run(signals simsignals.Signals) (result *proto.PartyOptimizerResult) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.PartyOptimizerResult{
+				Error: &proto.ErrorOutcome{Message: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack()))},
+			}
+		}
+	}()
+
+	roster := po.Request.Roster
+	if len(roster) == 0 {
+		return &proto.PartyOptimizerResult{Error: &proto.ErrorOutcome{Message: "Roster is empty"}}
+	}
+	if po.Request.BaseSettings == nil {
+		return &proto.PartyOptimizerResult{Error: &proto.ErrorOutcome{Message: "Missing base settings"}}
+	}
+
+	raidSize := int(po.Request.RaidSize)
+	if raidSize <= 0 {
+		raidSize = len(roster)
+	}
+	if len(roster) > raidSize {
+		return &proto.PartyOptimizerResult{
+			Error: &proto.ErrorOutcome{Message: fmt.Sprintf("Roster of %d players doesn't fit in a raid of %d", len(roster), raidSize)},
+		}
+	}
+	po.numParties = (raidSize + 4) / 5
+	if po.numParties > 8 {
+		return &proto.PartyOptimizerResult{Error: &proto.ErrorOutcome{Message: "Raids can't have more than 8 parties"}}
+	}
+
+	po.weights = make([]float64, len(roster))
+	po.signatures = make([]string, len(roster))
+	po.partyBuffs = make([]*proto.PartyBuffs, len(roster))
+	for i, player := range roster {
+		po.weights[i] = 1
+		if i < len(po.Request.DpsWeights) {
+			po.weights[i] = po.Request.DpsWeights[i]
+		}
+		po.signatures[i] = playerSignature(player)
+		po.partyBuffs[i] = providedPartyBuffs(player)
+	}
+	po.cache = make(map[string]*partyLayoutResult)
+
+	// Use the same seed for every layout, so differences between layouts aren't just noise.
+	baseSettings := goproto.Clone(po.Request.BaseSettings).(*proto.RaidSimRequest)
+	if baseSettings.SimOptions == nil {
+		baseSettings.SimOptions = &proto.SimOptions{}
+	}
+	if baseSettings.SimOptions.RandomSeed == 0 {
+		baseSettings.SimOptions.RandomSeed = time.Now().UnixNano()
+	}
+	fullIterations := baseSettings.SimOptions.Iterations
+	if fullIterations <= 0 {
+		fullIterations = defaultIterationsPerCombo
+	}
+	screeningIterations := max(minPartyScreeningIterations, fullIterations/10)
+	screeningIterations = min(screeningIterations, fullIterations)
+
+	// Hill climb from a layout with the party buffs spread out, always taking the best improving swap.
+	current := po.initialLayout()
+	best, err := po.evaluate(signals, baseSettings, []partyLayout{current}, screeningIterations)
+	if err != nil {
+		return &proto.PartyOptimizerResult{Error: err}
+	}
+	currentResult := best[0]
+	for round := 0; round < maxPartyOptimizerRounds; round++ {
+		results, err := po.evaluate(signals, baseSettings, po.neighbours(currentResult.layout), screeningIterations)
+		if err != nil {
+			return &proto.PartyOptimizerResult{Error: err}
+		}
+		improved := false
+		for _, res := range results {
+			if res.score > currentResult.score {
+				currentResult = res
+				improved = true
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+
+	// Refine the best screened layouts with the full iteration count.
+	numResults := int(po.Request.NumResults)
+	if numResults <= 0 {
+		numResults = defaultPartyOptimizerResults
+	}
+	candidates := po.rankedLayouts(numResults)
+	po.cache = make(map[string]*partyLayoutResult)
+	refined, err := po.evaluate(signals, baseSettings, candidates, fullIterations)
+	if err != nil {
+		return &proto.PartyOptimizerResult{Error: err}
+	}
+	sort.SliceStable(refined, func(i, j int) bool {
+		return refined[i].score > refined[j].score
+	})
+
+	result = &proto.PartyOptimizerResult{}
+	for _, res := range refined {
+		result.Layouts = append(result.Layouts, res.toProto())
+	}
+	return result
+}
+
+// Returns the player's settings, ignoring their name.
+func playerSignature(player *proto.Player) string {
+	player = goproto.Clone(player).(*proto.Player)
+	player.Name = ""
+	data, err := goproto.MarshalOptions{Deterministic: true}.Marshal(player)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+// Returns the party buffs the player provides to their party.
+func providedPartyBuffs(player *proto.Player) *proto.PartyBuffs {
+	party := NewParty(&Raid{}, 0, &proto.Party{Players: []*proto.Player{goproto.Clone(player).(*proto.Player)}})
+	return party.GetPartyBuffs(nil)
+}
+
+// initialLayout deals the providers of each kind of party buff round-robin over the parties,
+// so the buffs reach as many players as possible, then fills the free slots in roster order.
+func (po *partyOptimizer) initialLayout() partyLayout {
+	layout := make(partyLayout, po.numParties*5)
+	for i := range layout {
+		layout[i] = -1
+	}
+
+	var buffKinds []*proto.PartyBuffs
+	var providers [][]int
+	for rosterIdx, buffs := range po.partyBuffs {
+		if goproto.Equal(buffs, &proto.PartyBuffs{}) {
+			continue
+		}
+		kind := slices.IndexFunc(buffKinds, func(kind *proto.PartyBuffs) bool {
+			return goproto.Equal(kind, buffs)
+		})
+		if kind == -1 {
+			kind = len(buffKinds)
+			buffKinds = append(buffKinds, buffs)
+			providers = append(providers, nil)
+		}
+		providers[kind] = append(providers[kind], rosterIdx)
+	}
+
+	placed := make([]bool, len(po.Request.Roster))
+	partyIdx := 0
+	for _, rosterIndices := range providers {
+		for _, rosterIdx := range rosterIndices {
+			// The roster fits in the raid, so some party always has a free slot.
+			slot := po.freeSlot(layout, partyIdx%po.numParties)
+			for slot == -1 {
+				partyIdx++
+				slot = po.freeSlot(layout, partyIdx%po.numParties)
+			}
+			layout[slot] = rosterIdx
+			placed[rosterIdx] = true
+			partyIdx++
+		}
+	}
+
+	slot := 0
+	for rosterIdx := range po.Request.Roster {
+		if placed[rosterIdx] {
+			continue
+		}
+		for layout[slot] != -1 {
+			slot++
+		}
+		layout[slot] = rosterIdx
+	}
+	return layout
+}
+
+// Returns the party's first free slot, or -1 if the party is full.
+func (po *partyOptimizer) freeSlot(layout partyLayout, partyIdx int) int {
+	for slot := partyIdx * 5; slot < partyIdx*5+5; slot++ {
+		if layout[slot] == -1 {
+			return slot
+		}
+	}
+	return -1
+}
+
+// neighbours returns all layouts reachable by swapping two players in different parties,
+// or by moving a player into another party's free slot.
+func (po *partyOptimizer) neighbours(layout partyLayout) []partyLayout {
+	var neighbours []partyLayout
+	for i := range layout {
+		for j := i + 1; j < len(layout); j++ {
+			if i/5 == j/5 || (layout[i] == -1 && layout[j] == -1) {
+				continue
+			}
+			if layout[i] != -1 && layout[j] != -1 && po.signatures[layout[i]] == po.signatures[layout[j]] {
+				continue
+			}
+			// Only the first free slot of a party is considered, the rest are equivalent.
+			if (layout[i] == -1 && !po.isFirstFreeSlot(layout, i)) || (layout[j] == -1 && !po.isFirstFreeSlot(layout, j)) {
+				continue
+			}
+			neighbour := slices.Clone(layout)
+			neighbour[i], neighbour[j] = neighbour[j], neighbour[i]
+			neighbours = append(neighbours, neighbour)
+		}
+	}
+	return neighbours
+}
+
+func (po *partyOptimizer) isFirstFreeSlot(layout partyLayout, slot int) bool {
+	for i := slot - slot%5; i < slot; i++ {
+		if layout[i] == -1 {
+			return false
+		}
+	}
+	return true
+}
+
+// key identifies a layout regardless of slot order within parties. Party order still
+// matters, because the base party buffs are kept by party index.
+func (po *partyOptimizer) key(layout partyLayout) string {
+	parties := make([]string, po.numParties)
+	for p := range parties {
+		members := slices.Clone(layout[p*5 : p*5+5])
+		slices.Sort(members)
+		parties[p] = fmt.Sprint(members)
+	}
+	return strings.Join(parties, "|")
+}
+
+func (po *partyOptimizer) rankedLayouts(numResults int) []partyLayout {
+	results := make([]*partyLayoutResult, 0, len(po.cache))
+	for _, res := range po.cache {
+		results = append(results, res)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].score > results[j].score
+	})
+
+	layouts := make([]partyLayout, 0, numResults)
+	for i := 0; i < len(results) && i < numResults; i++ {
+		layouts = append(layouts, results[i].layout)
+	}
+	return layouts
+}
+
+// evaluate sims the given layouts in parallel, reusing cached results for layouts already seen.
+func (po *partyOptimizer) evaluate(signals simsignals.Signals, baseSettings *proto.RaidSimRequest, layouts []partyLayout, iterations int32) ([]*partyLayoutResult, *proto.ErrorOutcome) {
+	results := make([]*partyLayoutResult, len(layouts))
+	errs := make([]*proto.ErrorOutcome, len(layouts))
+
+	concurrency := runtime.NumCPU() + 1
+	if IsRunningInWasm() {
+		concurrency = 1
+	}
+	tickets := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, layout := range layouts {
+		key := po.key(layout)
+		po.mu.Lock()
+		cached, ok := po.cache[key]
+		po.mu.Unlock()
+		if ok {
+			results[i] = cached
+			continue
+		}
+
+		wg.Add(1)
+		tickets <- struct{}{}
+		go func(i int, layout partyLayout) {
+			defer func() {
+				<-tickets
+				wg.Done()
+			}()
+			if signals.Abort.IsTriggered() {
+				errs[i] = &proto.ErrorOutcome{Message: "aborted"}
+				return
+			}
+
+			request := goproto.Clone(baseSettings).(*proto.RaidSimRequest)
+			request.Raid = po.buildRaid(layout)
+			request.SimOptions.Iterations = iterations
+			result := po.SingleRaidSimRunner(request, nil, false, signals)
+			if result == nil || result.Error != nil {
+				errs[i] = result.GetError()
+				if errs[i] == nil {
+					errs[i] = &proto.ErrorOutcome{Message: "missing sim result"}
+				}
+				return
+			}
+
+			res := &partyLayoutResult{
+				layout: layout,
+				raid:   request.Raid,
+				result: result,
+				score:  po.score(layout, result),
+			}
+			po.mu.Lock()
+			po.cache[key] = res
+			po.mu.Unlock()
+			results[i] = res
+		}(i, layout)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func (po *partyOptimizer) buildRaid(layout partyLayout) *proto.Raid {
+	base := po.Request.BaseSettings.Raid
+	raid := &proto.Raid{
+		NumActiveParties: int32(po.numParties),
+	}
+	if base != nil {
+		raid = goproto.Clone(base).(*proto.Raid)
+		raid.NumActiveParties = int32(po.numParties)
+		raid.Tanks = nil
+	}
+
+	parties := make([]*proto.Party, po.numParties)
+	for p := range parties {
+		parties[p] = &proto.Party{}
+		if p < len(raid.Parties) {
+			parties[p].Buffs = raid.Parties[p].Buffs
+		}
+		for slot := p * 5; slot < p*5+5; slot++ {
+			player := &proto.Player{}
+			if layout[slot] != -1 {
+				player = goproto.Clone(po.Request.Roster[layout[slot]]).(*proto.Player)
+			}
+			parties[p].Players = append(parties[p].Players, player)
+		}
+	}
+	raid.Parties = parties
+
+	if base != nil {
+		for _, tank := range base.Tanks {
+			tank = goproto.Clone(tank).(*proto.UnitReference)
+			if tank.Type == proto.UnitReference_Player {
+				// Unknown roster indices still keep their place, so encounter tank indices stay valid.
+				raidIdx := slices.Index(layout, int(tank.Index))
+				if raidIdx == -1 {
+					raidIdx = len(layout)
+				}
+				tank.Index = int32(raidIdx)
+			}
+			raid.Tanks = append(raid.Tanks, tank)
+		}
+	}
+	return raid
+}
+
+func (po *partyOptimizer) score(layout partyLayout, result *proto.RaidSimResult) float64 {
+	score := 0.0
+	for slot, rosterIdx := range layout {
+		if rosterIdx == -1 {
+			continue
+		}
+		score += po.weights[rosterIdx] * slotDps(result, slot)
+	}
+	return score
+}
+
+func slotDps(result *proto.RaidSimResult, slot int) float64 {
+	parties := result.GetRaidMetrics().GetParties()
+	if slot/5 >= len(parties) {
+		return 0
+	}
+	players := parties[slot/5].GetPlayers()
+	if slot%5 >= len(players) {
+		return 0
+	}
+	return players[slot%5].GetDps().GetAvg()
+}
+
+func (res *partyLayoutResult) toProto() *proto.PartyLayout {
+	layout := &proto.PartyLayout{
+		Raid:    res.raid,
+		Score:   res.score,
+		RaidDps: res.result.GetRaidMetrics().GetDps().GetAvg(),
+	}
+	for _, rosterIdx := range res.layout {
+		layout.RosterIndices = append(layout.RosterIndices, int32(rosterIdx))
+	}
+	for _, party := range res.result.GetRaidMetrics().GetParties() {
+		layout.PartyDps = append(layout.PartyDps, party.GetDps().GetAvg())
+	}
+	return layout
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+)
+
+// fakePartyBuffRunner gives every warrior +50 DPS if their party has a shaman.
+func fakePartyBuffRunner(rsr *proto.RaidSimRequest, _ chan *proto.ProgressMetrics, _ bool, _ simsignals.Signals) *proto.RaidSimResult {
+	raidMetrics := &proto.RaidMetrics{Dps: &proto.DistributionMetrics{}}
+	for _, party := range rsr.Raid.Parties {
+		hasShaman := false
+		for _, player := range party.Players {
+			hasShaman = hasShaman || player.Class == proto.Class_ClassShaman
+		}
+
+		partyMetrics := &proto.PartyMetrics{Dps: &proto.DistributionMetrics{}}
+		for _, player := range party.Players {
+			dps := 0.0
+			if player.Class != proto.Class_ClassUnknown {
+				dps = 100
+			}
+			if player.Class == proto.Class_ClassWarrior && hasShaman {
+				dps += 50
+			}
+			partyMetrics.Players = append(partyMetrics.Players, &proto.UnitMetrics{Dps: &proto.DistributionMetrics{Avg: dps}})
+			partyMetrics.Dps.Avg += dps
+		}
+		raidMetrics.Parties = append(raidMetrics.Parties, partyMetrics)
+		raidMetrics.Dps.Avg += partyMetrics.Dps.Avg
+	}
+	return &proto.RaidSimResult{RaidMetrics: raidMetrics}
+}
+
+func init() {
+	RegisterAgentFactory(
+		proto.Player_EnhancementShaman{},
+		proto.Spec_SpecEnhancementShaman,
+		func(char *Character, _ *proto.Player) Agent {
+			return &fakePartyBuffProvider{FakeAgent: FakeAgent{Character: *char}}
+		},
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_EnhancementShaman)
+			if !ok {
+				panic("Invalid spec value for Enhancement Shaman!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+// Provides a Mana Tide Totem to its party.
+type fakePartyBuffProvider struct {
+	FakeAgent
+}
+
+func (fa *fakePartyBuffProvider) AddPartyBuffs(partyBuffs *proto.PartyBuffs) {
+	partyBuffs.ManaTideTotems++
+}
+
+// A roster of two party buff providing shamans and warriors, in that order.
+func partyOptimizerRoster(numWarriors int) []*proto.Player {
+	var roster []*proto.Player
+	for i := 0; i < 2+numWarriors; i++ {
+		player := fakeSimRequest(nil).Raid.Parties[0].Players[0]
+		if i < 2 {
+			player.Name = fmt.Sprintf("Shaman %d", i+1)
+			player.Spec = &proto.Player_EnhancementShaman{}
+		} else {
+			player.Name = "Warrior"
+			player.Class = proto.Class_ClassWarrior
+			player.Spec = &proto.Player_Warrior{}
+		}
+		roster = append(roster, player)
+	}
+	return roster
+}
+
+func TestPartyOptimizerInitialLayout(t *testing.T) {
+	optimizer := &partyOptimizer{
+		Request:    &proto.PartyOptimizerRequest{Roster: partyOptimizerRoster(4)},
+		numParties: 2,
+	}
+	for _, player := range optimizer.Request.Roster {
+		optimizer.partyBuffs = append(optimizer.partyBuffs, providedPartyBuffs(player))
+	}
+
+	// The shamans are spread over the parties, and the warriors fill the free slots in order.
+	want := partyLayout{0, 2, 3, 4, 5, 1, -1, -1, -1, -1}
+	if layout := optimizer.initialLayout(); !slices.Equal(layout, want) {
+		t.Fatalf("initialLayout() = %v, want %v", layout, want)
+	}
+}
+
+func TestPartyOptimizerSignatures(t *testing.T) {
+	roster := partyOptimizerRoster(2)
+	roster[3].Name = "Other Warrior"
+	if playerSignature(roster[2]) != playerSignature(roster[3]) {
+		t.Fatalf("Expected players with different names to have the same signature")
+	}
+	roster[3].TalentsString = "05"
+	if playerSignature(roster[2]) == playerSignature(roster[3]) {
+		t.Fatalf("Expected players with different talents to have different signatures")
+	}
+	roster[3].TalentsString = ""
+	roster[3].Equipment = &proto.EquipmentSpec{Items: []*proto.ItemSpec{{Id: 1}}}
+	if playerSignature(roster[2]) == playerSignature(roster[3]) {
+		t.Fatalf("Expected players with different gear to have different signatures")
+	}
+}
+
+func TestPartyOptimizerSpreadsPartyBuffs(t *testing.T) {
+	roster := partyOptimizerRoster(7)
+
+	optimizer := &partyOptimizer{
+		SingleRaidSimRunner: fakePartyBuffRunner,
+		Request: &proto.PartyOptimizerRequest{
+			BaseSettings: &proto.RaidSimRequest{
+				Raid:       &proto.Raid{Tanks: []*proto.UnitReference{{Type: proto.UnitReference_Player, Index: 8}}},
+				SimOptions: &proto.SimOptions{Iterations: 100},
+			},
+			Roster:   roster,
+			RaidSize: 10,
+		},
+	}
+
+	result := optimizer.Run(simsignals.CreateSignals())
+	if result.Error != nil {
+		t.Fatalf("Run() returned error: %s", result.Error.Message)
+	}
+	if len(result.Layouts) != defaultPartyOptimizerResults {
+		t.Fatalf("Run() returned %d layouts, want %d", len(result.Layouts), defaultPartyOptimizerResults)
+	}
+
+	best := result.Layouts[0]
+	if want := 9*100.0 + 7*50.0; best.RaidDps != want {
+		t.Errorf("best layout has %.0f raid DPS, want %.0f", best.RaidDps, want)
+	}
+	if len(best.PartyDps) != 2 {
+		t.Errorf("best layout has %d parties, want 2", len(best.PartyDps))
+	}
+
+	tankIdx := best.Raid.Tanks[0].Index
+	if best.RosterIndices[tankIdx] != 8 {
+		t.Errorf("tank is in raid slot %d holding roster player %d, want roster player 8", tankIdx, best.RosterIndices[tankIdx])
+	}
+}
@@ -121,12 +121,19 @@ type Raid struct {
 
 	AllPlayerUnits []*Unit // Cached list of all Players in the raid.
 	AllUnits       []*Unit // Cached list of all Units (players and pets) in the raid.
+	Tanks          []*Unit // Units in Raid.tanks, in order.
 
 	nextPetIndex int32
 
 	replenishmentUnits         []*Unit   // All units who can receive replenishment.
 	curReplenishmentUnits      [][]*Unit // Units that currently have replenishment active, separated by source.
 	leftoverReplenishmentUnits []*Unit   // Units without replenishment currently active.
+
+	cooldownAssignmentConfigs []*proto.RaidCooldownAssignment
+	cooldownAssignments       []*CooldownAssignment // Raid.cooldown_assignments, nil for unresolved entries.
+
+	// Whether buffs come from the simulated raid members' spells rather than static toggles.
+	SimulateRaidBuffs bool
 }
 
 func (raid *Raid) GetActiveUnits() []*Unit {
@@ -150,6 +157,9 @@ func NewRaid(raidConfig *proto.Raid) *Raid {
 		dpsMetrics:   NewDistributionMetrics(),
 		hpsMetrics:   NewDistributionMetrics(),
 		nextPetIndex: int32(numParties) * 5,
+
+		cooldownAssignmentConfigs: raidConfig.CooldownAssignments,
+		SimulateRaidBuffs:         raidConfig.SimulateRaidBuffs,
 	}
 
 	for partyIndex, partyConfig := range raidConfig.Parties {
@@ -232,6 +242,29 @@ func (raid *Raid) GetRaidBuffs(baseRaidBuffs *proto.RaidBuffs) *proto.RaidBuffs
 	return raidBuffs
 }
 
+// Clears the static buff and debuff toggles which simulated players provide through their
+// own spells. Modifies the config in place, so it must not be shared with other sims.
+func (raid *Raid) removeSimulatedBuffs(raidConfig *proto.Raid) {
+	if raidConfig.Buffs == nil {
+		raidConfig.Buffs = &proto.RaidBuffs{}
+	}
+	if raidConfig.Debuffs == nil {
+		raidConfig.Debuffs = &proto.Debuffs{}
+	}
+
+	for _, party := range raid.Parties {
+		partyConfig := raidConfig.Parties[party.Index]
+		if partyConfig.Buffs == nil {
+			partyConfig.Buffs = &proto.PartyBuffs{}
+		}
+		for _, player := range party.Players {
+			if provider, ok := player.(BuffProviderAgent); ok {
+				provider.RemoveSimulatedBuffs(raidConfig.Buffs, partyConfig.Buffs, raidConfig.Debuffs)
+			}
+		}
+	}
+}
+
 // Precompute the playersAndPets array for each party.
 func (raid *Raid) updatePlayersAndPets() {
 	var raidPlayers []*Unit
@@ -280,7 +313,10 @@ func (raid *Raid) applyCharacterEffects(raidConfig *proto.Raid) *proto.RaidStats
 		// Apply all buffs to the players in this party.
 		for playerIdx, player := range party.Players {
 			if playerIdx >= len(partyConfig.Players) {
-				// This happens for target dummies.
+				// This happens for target dummies. They only need health, so they can take
+				// encounter damage and be healed.
+				player.GetCharacter().EnableHealthBar()
+				player.GetCharacter().trackChanceOfDeath(nil)
 				continue
 			}
 			playerConfig := partyConfig.Players[playerIdx]
@@ -349,6 +385,29 @@ func (raid *Raid) GetFirstNPlayersOrPets(n int32) []*Unit {
 	return raid.AllUnits[:min(n, int32(len(raid.AllUnits)))]
 }
 
+// Returns the player with the lowest health percent, or nil if no players track health.
+// Ties go to the player with the lowest raid index.
+func (raid *Raid) GetLowestHealthAlly() *Unit {
+	var lowest *Unit
+	for _, unit := range raid.AllPlayerUnits {
+		if unit.HasHealthBar() && !unit.IsDead() && (lowest == nil || unit.CurrentHealthPercent() < lowest.CurrentHealthPercent()) {
+			lowest = unit
+		}
+	}
+	return lowest
+}
+
+// Returns the number of players below the given health percent (0-1).
+func (raid *Raid) NumInjuredAllies(healthPercent float64) int32 {
+	count := int32(0)
+	for _, unit := range raid.AllPlayerUnits {
+		if unit.HasHealthBar() && !unit.IsDead() && unit.CurrentHealthPercent() < healthPercent {
+			count++
+		}
+	}
+	return count
+}
+
 func (raid *Raid) GetPlayerFromUnitIndex(unitIndex int32) Agent {
 	for _, party := range raid.Parties {
 		for _, agent := range party.PlayersAndPets {
@@ -364,6 +423,11 @@ func (raid *Raid) reset(sim *Simulation) {
 	for _, party := range raid.Parties {
 		party.reset(sim)
 	}
+	for _, assignment := range raid.cooldownAssignments {
+		if assignment != nil {
+			assignment.reset(sim)
+		}
+	}
 	raid.dpsMetrics.reset()
 	raid.hpsMetrics.reset()
 }
@@ -381,8 +445,9 @@ func (raid *Raid) doneIteration(sim *Simulation) {
 
 func (raid *Raid) GetMetrics() *proto.RaidMetrics {
 	metrics := &proto.RaidMetrics{
-		Dps: raid.dpsMetrics.ToProto(),
-		Hps: raid.hpsMetrics.ToProto(),
+		Dps:                 raid.dpsMetrics.ToProto(),
+		Hps:                 raid.hpsMetrics.ToProto(),
+		CooldownAssignments: raid.getCooldownAssignmentMetrics(),
 	}
 	for _, party := range raid.Parties {
 		metrics.Parties = append(metrics.Parties, party.GetMetrics())
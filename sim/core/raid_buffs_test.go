@@ -0,0 +1,121 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func init() {
+	RegisterAgentFactory(
+		proto.Player_Warrior{},
+		proto.Spec_SpecWarrior,
+		func(char *Character, _ *proto.Player) Agent {
+			return &fakeBuffProvider{FakeAgent: FakeAgent{Character: *char}}
+		},
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_Warrior)
+			if !ok {
+				panic("Invalid spec value for Warrior!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+// Provides Battle Shout and Sunder Armor from its own spells.
+type fakeBuffProvider struct {
+	FakeAgent
+}
+
+func (fa *fakeBuffProvider) RemoveSimulatedBuffs(raidBuffs *proto.RaidBuffs, _ *proto.PartyBuffs, debuffs *proto.Debuffs) {
+	raidBuffs.BattleShout = proto.TristateEffect_TristateEffectMissing
+	debuffs.SunderArmor = false
+}
+
+func raidBuffsSimRequest(simulateRaidBuffs bool, withProvider bool) *proto.RaidSimRequest {
+	rsr := fakeSimRequest(&proto.Encounter{
+		Targets:  []*proto.Target{newFakeSimTarget()},
+		Duration: 100,
+	})
+	rsr.Raid.SimulateRaidBuffs = simulateRaidBuffs
+	rsr.Raid.Buffs = &proto.RaidBuffs{BattleShout: proto.TristateEffect_TristateEffectImproved, ArcaneBrilliance: true}
+	rsr.Raid.Debuffs = &proto.Debuffs{SunderArmor: true, FaerieFire: true}
+	// Missing party buffs are filled in before the providers see them.
+	rsr.Raid.Parties[0].Buffs = nil
+
+	party := rsr.Raid.Parties[0]
+	party.Players[0].Level = 60
+	if withProvider {
+		warrior := fakeSimRequest(nil).Raid.Parties[0].Players[0]
+		warrior.Name = "Warrior"
+		warrior.Class = proto.Class_ClassWarrior
+		warrior.Spec = &proto.Player_Warrior{}
+		warrior.Level = 60
+		party.Players = append(party.Players, warrior)
+	}
+	return rsr
+}
+
+func TestRemoveSimulatedBuffs(t *testing.T) {
+	tests := []struct {
+		name              string
+		simulateRaidBuffs bool
+		withProvider      bool
+		expectToggles     bool
+	}{
+		{name: "static toggles", simulateRaidBuffs: false, withProvider: true, expectToggles: true},
+		{name: "no provider", simulateRaidBuffs: true, withProvider: false, expectToggles: true},
+		{name: "simulated provider", simulateRaidBuffs: true, withProvider: true, expectToggles: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rsr := raidBuffsSimRequest(test.simulateRaidBuffs, test.withProvider)
+			sim, _ := setupFakeSimWithRequest(rsr)
+			caster := sim.Raid.Parties[0].Players[0].GetCharacter()
+			target := sim.Encounter.TargetUnits[0]
+
+			if hasBuff := caster.GetAura("Battle Shout") != nil; hasBuff != test.expectToggles {
+				t.Fatalf("Expected the Battle Shout toggle to apply: %t", test.expectToggles)
+			}
+			if hasDebuff := target.GetAura("Sunder Armor") != nil; hasDebuff != test.expectToggles {
+				t.Fatalf("Expected the Sunder Armor toggle to apply: %t", test.expectToggles)
+			}
+			// Toggles which the provider doesn't cover still apply.
+			if target.GetAura("Faerie Fire") == nil {
+				t.Fatalf("Expected the Faerie Fire toggle to apply")
+			}
+
+			// The request is left untouched, so it can be reused for other sims.
+			if rsr.Raid.Buffs.BattleShout != proto.TristateEffect_TristateEffectImproved || !rsr.Raid.Debuffs.SunderArmor || rsr.Raid.Parties[0].Buffs != nil {
+				t.Fatalf("Expected the request's buffs to be unchanged")
+			}
+		})
+	}
+}
+
+func TestRaidRemoveSimulatedBuffs(t *testing.T) {
+	rsr := raidBuffsSimRequest(false, true)
+	raidConfig := rsr.Raid
+	raid := NewRaid(raidConfig)
+	raid.removeSimulatedBuffs(raidConfig)
+
+	if raidConfig.Parties[0].Buffs == nil {
+		t.Fatalf("Expected missing party buffs to be filled in")
+	}
+	if raidConfig.Buffs.BattleShout != proto.TristateEffect_TristateEffectMissing || raidConfig.Debuffs.SunderArmor {
+		t.Fatalf("Expected the provider's toggles to be cleared")
+	}
+	if !raidConfig.Buffs.ArcaneBrilliance || !raidConfig.Debuffs.FaerieFire {
+		t.Fatalf("Expected other toggles to be kept")
+	}
+
+	// Missing buffs and debuffs are filled in too.
+	raidConfig.Buffs = nil
+	raidConfig.Debuffs = nil
+	raid.removeSimulatedBuffs(raidConfig)
+	if raidConfig.Buffs == nil || raidConfig.Debuffs == nil {
+		t.Fatalf("Expected missing raid buffs and debuffs to be filled in")
+	}
+}
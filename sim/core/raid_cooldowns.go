@@ -0,0 +1,256 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	googleProto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// How often to retry a cast that is held back by its condition, or by anything other than
+// the spell's cooldown, the GCD or another cast.
+const cooldownAssignmentRetryInterval = time.Millisecond * 250
+
+// A Raid.cooldown_assignments entry, cast on the target using the caster's own spell.
+type CooldownAssignment struct {
+	Caster *Character
+	Target *Unit
+	Spell  *Spell
+
+	condition        APLValue
+	timings          []time.Duration
+	repeatOnCooldown bool
+
+	// Number of casts so far in the current iteration, and over all iterations.
+	numCasts   int
+	totalCasts int
+
+	pendingAction *PendingAction
+}
+
+// Resolves the assignments, and adds a warning to the raid stats for each one that is ignored.
+func (raid *Raid) registerCooldownAssignments(env *Environment, raidConfig *proto.Raid, raidStats *proto.RaidStats) {
+	raid.cooldownAssignments = make([]*CooldownAssignment, len(raidConfig.CooldownAssignments))
+	for i, config := range raidConfig.CooldownAssignments {
+		assignment, warnings, err := newCooldownAssignment(env, config)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("ignored: %s", err))
+		}
+		for _, warning := range warnings {
+			raidStats.CooldownAssignmentWarnings = append(raidStats.CooldownAssignmentWarnings, fmt.Sprintf("Cooldown assignment %d %s", i+1, warning))
+		}
+		raid.cooldownAssignments[i] = assignment
+	}
+}
+
+// Returns whether a Raid.cooldown_assignments entry has the character cast the spell. Classes use
+// this to only register spells which are never cast without an assignment.
+func (character *Character) HasCooldownAssignment(actionID ActionID) bool {
+	for _, config := range character.Env.Raid.cooldownAssignmentConfigs {
+		if config.Caster != nil && config.Caster.Type == proto.UnitReference_Player && config.Caster.Index == character.Index &&
+			ProtoToActionID(config.SpellId).SameActionIgnoreTag(actionID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns an error for assignments that can't be resolved, e.g. a caster who doesn't know the
+// spell, and the validation warnings from the condition.
+func newCooldownAssignment(env *Environment, config *proto.RaidCooldownAssignment) (*CooldownAssignment, []string, error) {
+	if config.Caster == nil || config.Caster.Type != proto.UnitReference_Player {
+		return nil, nil, errors.New("caster must be a player")
+	}
+	casterUnit := env.GetUnit(config.Caster, nil)
+	if casterUnit == nil {
+		return nil, nil, fmt.Errorf("no player at raid index %d", config.Caster.Index)
+	}
+	target := env.GetUnit(config.Target, casterUnit)
+	if target == nil || target.Type == EnemyUnit {
+		return nil, nil, errors.New("target must be a raid member")
+	}
+	caster := env.Raid.GetPlayerFromUnit(casterUnit).GetCharacter()
+
+	actionID := ProtoToActionID(config.SpellId)
+	var spell *Spell
+	for _, s := range caster.Spellbook {
+		if s.ActionID.SameActionIgnoreTag(actionID) {
+			spell = s
+			break
+		}
+	}
+	if spell == nil {
+		return nil, nil, fmt.Errorf("%s doesn't know %s", caster.Label, actionID)
+	}
+
+	assignment := &CooldownAssignment{
+		Caster:           caster,
+		Target:           target,
+		Spell:            spell,
+		timings:          MapSlice(config.Timings, DurationFromSeconds),
+		repeatOnCooldown: config.RepeatOnCooldown || len(config.Timings) == 0,
+	}
+
+	var warnings []string
+	if config.Condition != nil {
+		// Conditions are written from the target's point of view.
+		rot := &APLRotation{unit: target}
+		assignment.condition = rot.NewAPLValue(config.Condition)
+		if assignment.condition != nil {
+			assignment.condition.Finalize(rot)
+		}
+		warnings = MapSlice(rot.curWarnings, func(warning string) string {
+			return "condition: " + warning
+		})
+	}
+
+	// The assignment decides when the spell is used, so the caster shouldn't use it on their own.
+	caster.removeInitialMajorCooldown(spell.ActionID)
+
+	return assignment, warnings, nil
+}
+
+// Returns when the next cast should happen, ignoring the condition, or NeverExpires if there
+// are no more casts.
+func (ca *CooldownAssignment) NextCastAt(sim *Simulation) time.Duration {
+	if ca.numCasts < len(ca.timings) {
+		return max(ca.timings[ca.numCasts], ca.Spell.ReadyAt(), sim.CurrentTime)
+	}
+	if ca.repeatOnCooldown {
+		return max(ca.Spell.ReadyAt(), sim.CurrentTime)
+	}
+	return NeverExpires
+}
+
+func (ca *CooldownAssignment) reset(sim *Simulation) {
+	ca.numCasts = 0
+	ca.pendingAction = nil
+	ca.schedule(sim, ca.NextCastAt(sim))
+}
+
+func (ca *CooldownAssignment) schedule(sim *Simulation, doAt time.Duration) {
+	if ca.pendingAction != nil {
+		ca.pendingAction.Cancel(sim)
+		ca.pendingAction = nil
+	}
+	if doAt == NeverExpires {
+		return
+	}
+
+	ca.pendingAction = StartDelayedAction(sim, DelayedActionOptions{
+		DoAt: doAt,
+		// Run before the caster's GCD action, or an idle caster would keep pushing its GCD back.
+		Priority: ActionPriorityGCD + 1,
+		OnAction: ca.tryCast,
+	})
+}
+
+func (ca *CooldownAssignment) tryCast(sim *Simulation) {
+	ca.pendingAction = nil
+
+	if nextCastAt := ca.NextCastAt(sim); nextCastAt > sim.CurrentTime {
+		ca.schedule(sim, nextCastAt)
+		return
+	}
+
+	if ca.condition != nil && !ca.condition.GetBool(sim) {
+		ca.schedule(sim, sim.CurrentTime+cooldownAssignmentRetryInterval)
+		return
+	}
+
+	switch ca.Spell.CastFailureReason(sim, ca.Target) {
+	case proto.CastFailureReason_CastFailureNone:
+		if ca.Spell.Cast(sim, ca.Target) {
+			ca.numCasts++
+			ca.totalCasts++
+			ca.schedule(sim, ca.NextCastAt(sim))
+			return
+		}
+	case proto.CastFailureReason_CastFailureCooldown:
+		ca.schedule(sim, ca.Spell.ReadyAt())
+		return
+	case proto.CastFailureReason_CastFailureGCD:
+		ca.schedule(sim, ca.Caster.GCD.ReadyAt())
+		return
+	case proto.CastFailureReason_CastFailureCasting:
+		ca.schedule(sim, ca.Caster.Hardcast.Expires)
+		return
+	}
+
+	ca.schedule(sim, sim.CurrentTime+cooldownAssignmentRetryInterval)
+}
+
+// Returns the assignments casting a spell with the given ID on the unit.
+func (raid *Raid) GetCooldownAssignmentsOn(target *Unit, actionID ActionID) []*CooldownAssignment {
+	var assignments []*CooldownAssignment
+	for _, assignment := range raid.cooldownAssignments {
+		if assignment != nil && assignment.Target == target && assignment.Spell.ActionID.SameActionIgnoreTag(actionID) {
+			assignments = append(assignments, assignment)
+		}
+	}
+	return assignments
+}
+
+// DPS gains are filled in by the sim, which runs the counterfactual sims if
+// Raid.sim_cooldown_assignment_gains is set.
+func (raid *Raid) getCooldownAssignmentMetrics() []*proto.RaidCooldownAssignmentMetrics {
+	numIterations := raid.dpsMetrics.n
+
+	metrics := make([]*proto.RaidCooldownAssignmentMetrics, len(raid.cooldownAssignments))
+	for i, assignment := range raid.cooldownAssignments {
+		metrics[i] = &proto.RaidCooldownAssignmentMetrics{}
+		if assignment != nil && numIterations > 0 {
+			metrics[i].AvgCasts = float64(assignment.totalCasts) / float64(numIterations)
+		}
+	}
+	return metrics
+}
+
+// Runs a sim without each cooldown assignment, using the same seed and duration as the given
+// sim, so the results can be compared to find what each assignment adds.
+func (sim *Simulation) runCooldownAssignmentSims(rsr *proto.RaidSimRequest) []*proto.RaidSimResult {
+	numAssignments := len(rsr.Raid.GetCooldownAssignments())
+	results := make([]*proto.RaidSimResult, numAssignments)
+	for i := 0; i < numAssignments; i++ {
+		request := googleProto.Clone(rsr).(*proto.RaidSimRequest)
+		request.Raid.CooldownAssignments = append(request.Raid.CooldownAssignments[:i], request.Raid.CooldownAssignments[i+1:]...)
+		request.SimOptions.RandomSeed = sim.rseed
+		request.SimOptions.Debug = false
+		request.SimOptions.DebugFirstIteration = false
+
+		withoutSim := NewSim(request, sim.Signals)
+		withoutSim.BaseDuration = sim.BaseDuration
+		withoutSim.Duration = sim.Duration
+		withoutSim.Encounter.DurationIsEstimate = sim.Encounter.DurationIsEstimate
+		results[i] = withoutSim.run()
+		if results[i].Error != nil {
+			return nil
+		}
+	}
+	return results
+}
+
+func (sim *Simulation) addCooldownAssignmentGains(result *proto.RaidSimResult) {
+	withoutResults := sim.withoutCooldownAssignmentResults
+	if withoutResults == nil {
+		return
+	}
+	for i, metrics := range result.RaidMetrics.CooldownAssignments {
+		without := withoutResults[i].RaidMetrics
+		metrics.RaidDpsGain = result.RaidMetrics.Dps.Avg - without.Dps.Avg
+
+		assignment := sim.Raid.cooldownAssignments[i]
+		if assignment == nil {
+			continue
+		}
+		if assignment.Target.Type == PlayerUnit {
+			character := sim.Raid.GetPlayerFromUnit(assignment.Target).GetCharacter()
+			partyIdx, playerIdx := character.Party.Index, character.PartyIndex
+			metrics.TargetDpsGain = result.RaidMetrics.Parties[partyIdx].Players[playerIdx].Dps.Avg -
+				without.Parties[partyIdx].Players[playerIdx].Dps.Avg
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func init() {
+	RegisterAgentFactory(
+		proto.Player_HealingPriest{},
+		proto.Spec_SpecHealingPriest,
+		func(char *Character, _ *proto.Player) Agent {
+			return &fakeCooldownCaster{FakeAgent: FakeAgent{Character: *char}}
+		},
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_HealingPriest)
+			if !ok {
+				panic("Invalid spec value for Healing Priest!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+const (
+	fakeStrikeSpellID   = 47
+	fakeInfusionSpellID = 48
+)
+
+// Strikes on every GCD, and has an external cooldown which increases the target's damage
+// dealt by 50% for 15s, every 20s.
+type fakeCooldownCaster struct {
+	FakeAgent
+	Infusion *Spell
+}
+
+func (fa *fakeCooldownCaster) Initialize() {
+	fa.RegisterSpell(SpellConfig{
+		ActionID:    ActionID{SpellID: fakeStrikeSpellID},
+		SpellSchool: SpellSchoolPhysical,
+		ProcMask:    ProcMaskSpellDamage,
+		Flags:       SpellFlagAPL,
+		Cast: CastConfig{
+			DefaultCast: Cast{
+				GCD: GCDDefault,
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			spell.CalcAndDealDamage(sim, target, 100, spell.OutcomeAlwaysHit)
+		},
+	})
+
+	infusionAuras := fa.NewRaidAuraArray(func(unit *Unit) *Aura {
+		return unit.GetOrRegisterAura(Aura{
+			Label:    "Fake Infusion",
+			ActionID: ActionID{SpellID: fakeInfusionSpellID},
+			Duration: time.Second * 15,
+			OnGain: func(aura *Aura, sim *Simulation) {
+				aura.Unit.PseudoStats.DamageDealtMultiplier *= 1.5
+			},
+			OnExpire: func(aura *Aura, sim *Simulation) {
+				aura.Unit.PseudoStats.DamageDealtMultiplier /= 1.5
+			},
+		})
+	})
+
+	fa.Infusion = fa.RegisterSpell(SpellConfig{
+		ActionID: ActionID{SpellID: fakeInfusionSpellID},
+		ProcMask: ProcMaskEmpty,
+		Flags:    SpellFlagHelpful,
+		Cast: CastConfig{
+			DefaultCast: Cast{
+				GCD: GCDDefault,
+			},
+			CD: Cooldown{
+				Timer:    fa.NewTimer(),
+				Duration: time.Second * 20,
+			},
+		},
+
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			infusionAuras.Get(target).Activate(sim)
+		},
+	})
+}
+
+func fakeStrikeRotation() *proto.APLRotation {
+	return &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+		PriorityList: []*proto.APLListItem{{
+			Action: &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+				SpellId: ActionID{SpellID: fakeStrikeSpellID}.ToProto(),
+			}}},
+		}},
+	}
+}
+
+// Two striking priests, with the given assignments of the first priest's infusion.
+func cooldownAssignmentSimRequest(assignments ...*proto.RaidCooldownAssignment) *proto.RaidSimRequest {
+	rsr := fakeSimRequest(&proto.Encounter{
+		Targets:  []*proto.Target{newFakeSimTarget()},
+		Duration: 100,
+	})
+	rsr.SimOptions.Iterations = 5
+	rsr.SimOptions.IsTest = true
+	party := rsr.Raid.Parties[0]
+	party.Players = nil
+	for _, name := range []string{"Caster", "Target"} {
+		priest := fakeSimRequest(nil).Raid.Parties[0].Players[0]
+		priest.Name = name
+		priest.Class = proto.Class_ClassPriest
+		priest.Spec = &proto.Player_HealingPriest{}
+		priest.Rotation = fakeStrikeRotation()
+		party.Players = append(party.Players, priest)
+	}
+	rsr.Raid.CooldownAssignments = assignments
+	return rsr
+}
+
+func newInfusionAssignment(timings []float64, repeatOnCooldown bool) *proto.RaidCooldownAssignment {
+	return &proto.RaidCooldownAssignment{
+		Caster:           &proto.UnitReference{Type: proto.UnitReference_Player, Index: 0},
+		SpellId:          ActionID{SpellID: fakeInfusionSpellID}.ToProto(),
+		Target:           &proto.UnitReference{Type: proto.UnitReference_Player, Index: 1},
+		Timings:          timings,
+		RepeatOnCooldown: repeatOnCooldown,
+	}
+}
+
+func setupCooldownAssignmentSim(assignments ...*proto.RaidCooldownAssignment) *Simulation {
+	sim, _ := setupFakeSimWithRequest(cooldownAssignmentSimRequest(assignments...))
+	return sim
+}
+
+func TestCooldownAssignmentTimings(t *testing.T) {
+	sim := setupCooldownAssignmentSim(newInfusionAssignment([]float64{10, 15, 40}, false))
+	assignment := sim.Raid.cooldownAssignments[0]
+	target := sim.Raid.Parties[0].Players[1].GetCharacter()
+
+	// Timings wait for the cooldown and the caster's GCD, so the casts are at 10s, 31s and 52s.
+	for _, check := range []struct {
+		at     time.Duration
+		casts  int
+		buffed bool
+	}{
+		{at: time.Millisecond * 9900, casts: 0, buffed: false},
+		{at: time.Second * 10, casts: 1, buffed: true},
+		{at: time.Millisecond * 30900, casts: 1, buffed: false},
+		{at: time.Second * 31, casts: 2, buffed: true},
+		{at: time.Millisecond * 51900, casts: 2, buffed: false},
+		{at: time.Second * 52, casts: 3, buffed: true},
+		{at: time.Second * 99, casts: 3, buffed: false},
+	} {
+		runFakeSimUntil(sim, check.at)
+		if assignment.numCasts != check.casts {
+			t.Fatalf("Expected %d casts at %s, got %d", check.casts, check.at, assignment.numCasts)
+		}
+		if buffed := target.GetAura("Fake Infusion").IsActive(); buffed != check.buffed {
+			t.Fatalf("Expected the target to be buffed: %t at %s", check.buffed, check.at)
+		}
+	}
+}
+
+func TestCooldownAssignmentRepeatOnCooldown(t *testing.T) {
+	// Without timings, the first cast is at the pull.
+	sim := setupCooldownAssignmentSim(newInfusionAssignment(nil, false))
+	assignment := sim.Raid.cooldownAssignments[0]
+	runFakeSimUntil(sim, time.Second*99)
+	if assignment.numCasts != 5 {
+		t.Fatalf("Expected casts every 20s from the pull, got %d", assignment.numCasts)
+	}
+
+	// Repeats start after the last timing.
+	sim = setupCooldownAssignmentSim(newInfusionAssignment([]float64{30}, true))
+	assignment = sim.Raid.cooldownAssignments[0]
+	runFakeSimUntil(sim, time.Second*99)
+	if assignment.numCasts != 4 {
+		t.Fatalf("Expected casts at 30s and every 20s after, got %d", assignment.numCasts)
+	}
+
+	// Each iteration starts over.
+	sim.Cleanup()
+	sim.Reset()
+	if assignment.numCasts != 0 || assignment.totalCasts != 4 || assignment.NextCastAt(sim) != time.Second*30 {
+		t.Fatalf("Expected the casts to be reset for the next iteration")
+	}
+}
+
+func TestCooldownAssignmentCondition(t *testing.T) {
+	config := newInfusionAssignment(nil, true)
+	config.Condition = &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+		Op:  proto.APLValueCompare_OpGt,
+		Lhs: &proto.APLValue{Value: &proto.APLValue_CurrentTime{CurrentTime: &proto.APLValueCurrentTime{}}},
+		Rhs: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: "25s"}}},
+	}}}
+	sim := setupCooldownAssignmentSim(config)
+	assignment := sim.Raid.cooldownAssignments[0]
+
+	runFakeSimUntil(sim, time.Second*25)
+	if assignment.numCasts != 0 {
+		t.Fatalf("Expected no casts before the condition is met")
+	}
+	// Held back casts are retried every 250ms.
+	runFakeSimUntil(sim, time.Second*25+cooldownAssignmentRetryInterval)
+	if assignment.numCasts != 1 {
+		t.Fatalf("Expected a cast once the condition is met")
+	}
+}
+
+func TestCooldownAssignmentResolution(t *testing.T) {
+	unknownSpell := newInfusionAssignment(nil, true)
+	unknownSpell.SpellId = ActionID{SpellID: 12345}.ToProto()
+	enemyTarget := newInfusionAssignment(nil, true)
+	enemyTarget.Target = &proto.UnitReference{Type: proto.UnitReference_Target, Index: 0}
+	missingCaster := newInfusionAssignment(nil, true)
+	missingCaster.Caster = nil
+
+	sim := setupCooldownAssignmentSim(unknownSpell, newInfusionAssignment(nil, true), enemyTarget, missingCaster)
+	assignments := sim.Raid.cooldownAssignments
+	if assignments[0] != nil || assignments[1] == nil || assignments[2] != nil || assignments[3] != nil {
+		t.Fatalf("Expected only the valid assignment to be resolved")
+	}
+
+	target := &sim.Raid.Parties[0].Players[1].GetCharacter().Unit
+	if found := sim.Raid.GetCooldownAssignmentsOn(target, ActionID{SpellID: fakeInfusionSpellID, Tag: 1}); len(found) != 1 || found[0] != assignments[1] {
+		t.Fatalf("Expected to find the assignment on its target, ignoring tags")
+	}
+	caster := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+	if found := sim.Raid.GetCooldownAssignmentsOn(caster, ActionID{SpellID: fakeInfusionSpellID}); len(found) != 0 {
+		t.Fatalf("Expected no assignments on the caster")
+	}
+
+	casterChar := sim.Raid.Parties[0].Players[0].GetCharacter()
+	targetChar := sim.Raid.Parties[0].Players[1].GetCharacter()
+	if !casterChar.HasCooldownAssignment(ActionID{SpellID: fakeInfusionSpellID, Tag: 1}) || targetChar.HasCooldownAssignment(ActionID{SpellID: fakeInfusionSpellID}) {
+		t.Fatalf("Expected only the caster to have an infusion assignment")
+	}
+
+	// Unresolved assignments are reported with the raid stats.
+	badCondition := newInfusionAssignment(nil, true)
+	badCondition.Condition = &proto.APLValue{Value: &proto.APLValue_SpellIsReady{SpellIsReady: &proto.APLValueSpellIsReady{
+		SpellId: ActionID{SpellID: 12345}.ToProto(),
+	}}}
+	result := ComputeStats(&proto.ComputeStatsRequest{
+		Raid: cooldownAssignmentSimRequest(unknownSpell, newInfusionAssignment(nil, true), enemyTarget, missingCaster, badCondition).Raid,
+	})
+	warnings := result.RaidStats.CooldownAssignmentWarnings
+	if len(warnings) != 4 {
+		t.Fatalf("Expected warnings for the 3 ignored assignments and the invalid condition, got %v", warnings)
+	}
+	for i, prefix := range []string{"Cooldown assignment 1 ignored", "Cooldown assignment 3 ignored", "Cooldown assignment 4 ignored", "Cooldown assignment 5 condition"} {
+		if !strings.HasPrefix(warnings[i], prefix) {
+			t.Fatalf("Expected warning %q to start with %q", warnings[i], prefix)
+		}
+	}
+}
+
+func TestCooldownAssignmentGains(t *testing.T) {
+	// Gains are only simmed when asked for.
+	rsr := cooldownAssignmentSimRequest(newInfusionAssignment(nil, true))
+	result := RunRaidSim(rsr)
+	if result.Error != nil {
+		t.Fatal(result.Error.Message)
+	}
+	metrics := result.RaidMetrics.CooldownAssignments
+	if len(metrics) != 1 || metrics[0].AvgCasts != 5 || metrics[0].RaidDpsGain != 0 || metrics[0].TargetDpsGain != 0 {
+		t.Fatalf("Expected casts without gains, got %v", metrics)
+	}
+
+	rsr.Raid.SimCooldownAssignmentGains = true
+	result = RunRaidSim(rsr)
+	if result.Error != nil {
+		t.Fatal(result.Error.Message)
+	}
+	// The target gains 50% damage for 15s of every 20s, while the caster loses a strike every 20s.
+	gain := result.RaidMetrics.CooldownAssignments[0]
+	if !WithinToleranceFloat64(25, gain.TargetDpsGain, 0.001) || !WithinToleranceFloat64(20, gain.RaidDpsGain, 0.001) {
+		t.Fatalf("Expected gains of 25 target DPS and 20 raid DPS, got %v", gain)
+	}
+	if len(rsr.Raid.CooldownAssignments) != 1 {
+		t.Fatalf("Expected the request to be left untouched")
+	}
+
+	// Gains are compared against the sim without each assignment, so a duplicate assignment
+	// which takes over the casts adds nothing on its own.
+	rsr.Raid.CooldownAssignments = append(rsr.Raid.CooldownAssignments, newInfusionAssignment(nil, true))
+	result = RunRaidSim(rsr)
+	for i, metrics := range result.RaidMetrics.CooldownAssignments {
+		if metrics.RaidDpsGain != 0 || metrics.TargetDpsGain != 0 {
+			t.Fatalf("Expected no gain from duplicate assignment %d, got %v", i, metrics)
+		}
+	}
+}
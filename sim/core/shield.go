@@ -1,10 +1,17 @@
 package core
 
-import "strconv"
+import (
+	"slices"
+	"strconv"
+)
 
 type ShieldConfig struct {
 	SelfOnly bool // Set to true to only create the self-shield.
 
+	// Set to true for damage taken to be absorbed up to the amount passed to Apply. Leave
+	// unset if the shield aura absorbs damage itself, or only reports shielding.
+	AbsorbDamage bool
+
 	Spell *Spell
 
 	Aura
@@ -16,6 +23,9 @@ type Shield struct {
 
 	// Embed Aura so we can use IsActive/Refresh/etc directly.
 	*Aura
+
+	// Amount left to absorb, for shields with ShieldConfig.AbsorbDamage.
+	remaining float64
 }
 
 func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
@@ -29,6 +39,7 @@ func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
 
 	shield.Aura.Deactivate(sim)
 	shield.Aura.Activate(sim)
+	shield.remaining = shieldAmount
 
 	threat := 0.0 // TODO
 	shield.Spell.SpellMetrics[target.UnitIndex].TotalThreat += threat
@@ -47,6 +58,45 @@ func newShield(config Shield) *Shield {
 	return shield
 }
 
+// Tracks the shield in the target's active shields while its aura is active, so that
+// damage taken is absorbed by it.
+func (shield *Shield) enableAbsorb() {
+	unit := shield.Aura.Unit
+	shield.Aura.ApplyOnGain(func(_ *Aura, _ *Simulation) {
+		unit.activeShields = append(unit.activeShields, shield)
+	})
+	shield.Aura.ApplyOnExpire(func(_ *Aura, sim *Simulation) {
+		// Shields still up when the iteration ends aren't counted as wasted.
+		if !sim.IsDone() {
+			shield.Spell.SpellMetrics[unit.UnitIndex].TotalWastedShielding += shield.remaining
+		}
+		shield.remaining = 0
+		if idx := slices.Index(unit.activeShields, shield); idx != -1 {
+			unit.activeShields = slices.Delete(unit.activeShields, idx, idx+1)
+		}
+	})
+}
+
+// Absorbs as much of the damage as possible with the unit's active shields, oldest
+// first, and returns the damage left over.
+func (unit *Unit) absorbDamage(sim *Simulation, damage float64) float64 {
+	for len(unit.activeShields) > 0 && damage > 0 {
+		shield := unit.activeShields[0]
+		absorbed := min(damage, shield.remaining)
+		shield.remaining -= absorbed
+		damage -= absorbed
+		shield.Spell.SpellMetrics[unit.UnitIndex].TotalAbsorbedShielding += absorbed
+
+		if sim.Log != nil && absorbed > 0 {
+			unit.Log(sim, "%s absorbed %0.3f damage.", shield.Spell.ActionID, absorbed)
+		}
+		if shield.remaining <= 0 {
+			shield.Aura.Deactivate(sim)
+		}
+	}
+	return damage
+}
+
 type ShieldArray []*Shield
 
 func (shields ShieldArray) Get(target *Unit) *Shield {
@@ -74,6 +124,9 @@ func (spell *Spell) createShields(config ShieldConfig) {
 	if config.SelfOnly {
 		shield.Aura = caster.GetOrRegisterAura(auraConfig)
 		spell.selfShield = newShield(shield)
+		if config.AbsorbDamage {
+			spell.selfShield.enableAbsorb()
+		}
 	} else {
 		auraConfig.Label += "-" + strconv.Itoa(int(caster.UnitIndex))
 		if spell.shields == nil {
@@ -83,6 +136,9 @@ func (spell *Spell) createShields(config ShieldConfig) {
 			if !caster.IsOpponent(target) {
 				shield.Aura = target.GetOrRegisterAura(auraConfig)
 				spell.shields[target.UnitIndex] = newShield(shield)
+				if config.AbsorbDamage {
+					spell.shields[target.UnitIndex].enableAbsorb()
+				}
 			}
 		}
 	}
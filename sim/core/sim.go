@@ -41,6 +41,9 @@ type Simulation struct {
 	ProgressReport func(*proto.ProgressMetrics)
 	Signals        simsignals.Signals
 
+	// Results of sims without each raid cooldown assignment, used to measure their DPS gains.
+	withoutCooldownAssignmentResults []*proto.RaidSimResult
+
 	Log func(string, ...interface{})
 
 	executePhase int32 // 20, 25, or 35 for the respective execute range, 100 otherwise
@@ -52,6 +55,8 @@ type Simulation struct {
 
 	endOfCombatDuration time.Duration
 	endOfCombatDamage   float64
+	// Set once the iteration's fight has ended, while it's being cleaned up.
+	isDone bool
 
 	minTrackerTime time.Duration
 	trackers       []*auraTracker
@@ -177,6 +182,10 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 		}
 	}
 
+	if rsr.Raid.GetSimCooldownAssignmentGains() && len(rsr.Raid.GetCooldownAssignments()) > 0 {
+		sim.withoutCooldownAssignmentResults = sim.runCooldownAssignmentSims(rsr)
+	}
+
 	// using a variable here allows us to mutate it in the deferred recover, sending out error info
 	result = sim.run()
 
@@ -251,6 +260,11 @@ func (sim *Simulation) RandomExpFloat(label string) float64 {
 	return rand.New(sim.labelRand(label)).ExpFloat64()
 }
 
+// Returns a normally distributed float64 with mean 0 and standard deviation 1.
+func (sim *Simulation) RandomNormFloat(label string) float64 {
+	return rand.New(sim.labelRand(label)).NormFloat64()
+}
+
 // Shorthand for commonly-used RNG behavior.
 // Returns a random number between min and max.
 func (sim *Simulation) Roll(min float64, max float64) float64 {
@@ -349,6 +363,7 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 		AvgIterationDuration:   totalDuration.Seconds() / float64(sim.Options.Iterations),
 		IterationsDone:         sim.Options.Iterations,
 	}
+	sim.addCooldownAssignmentGains(result)
 
 	// Final progress report
 	if sim.ProgressReport != nil {
@@ -408,6 +423,7 @@ func (sim *Simulation) reset() {
 	}
 
 	sim.CurrentTime = 0
+	sim.isDone = false
 
 	sim.trackers = sim.trackers[:0]
 	sim.minTrackerTime = NeverExpires
@@ -455,6 +471,7 @@ func (sim *Simulation) Cleanup() {
 	// during the doneIteration phase will return the Duration value, which is
 	// intuitive.
 	sim.CurrentTime = sim.Duration
+	sim.isDone = true
 
 	for _, pa := range sim.pendingActions {
 		if pa.CleanUp != nil {
@@ -468,7 +485,7 @@ func (sim *Simulation) Cleanup() {
 	for _, unit := range sim.Raid.AllUnits {
 		unit.Metrics.doneIteration(unit, sim)
 	}
-	for _, target := range sim.Encounter.TargetUnits {
+	for _, target := range sim.Encounter.AllTargetUnits {
 		target.Metrics.doneIteration(target, sim)
 	}
 }
@@ -557,6 +574,10 @@ func (sim *Simulation) advance(nextTime time.Duration) {
 		}
 	}
 
+	if sim.Encounter.DamageTaken >= sim.Encounter.nextPhaseDamage {
+		sim.Encounter.checkPhaseDamageTriggers(sim)
+	}
+
 	if sim.CurrentTime >= sim.minTrackerTime {
 		sim.minTrackerTime = NeverExpires
 		for _, t := range sim.trackers {
@@ -631,8 +652,21 @@ func (sim *Simulation) IsExecutePhase35() bool {
 	return sim.executePhase <= 35
 }
 
+// Returns whether the current iteration's fight has ended. This is the case while auras
+// are deactivated at the end of the iteration, including in health fights where the fight
+// can end before or after the estimated duration.
+func (sim *Simulation) IsDone() bool {
+	return sim.isDone
+}
+
 func (sim *Simulation) GetRemainingDuration() time.Duration {
 	if sim.Encounter.EndFightAtHealth > 0 {
+		if sim.Encounter.UseTimeToDieEstimate {
+			if rate := sim.Encounter.damageRate.DamageRate(sim); rate > 0 {
+				return DurationFromSeconds((sim.Encounter.EndFightAtHealth - sim.Encounter.DamageTaken) / rate)
+			}
+		}
+
 		if !sim.Encounter.DurationIsEstimate || sim.CurrentTime < time.Second*5 {
 			return sim.Duration - sim.CurrentTime
 		}
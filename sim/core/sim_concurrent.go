@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"runtime"
 	"runtime/debug"
+	"slices"
 
 	"github.com/wowsims/sod/sim/core/proto"
 	"github.com/wowsims/sod/sim/core/simsignals"
@@ -77,6 +78,14 @@ func (rsrc *raidSimResultCombiner) newUnitMetrics(baseUnit *proto.UnitMetrics) *
 		Tmi:       rsrc.newDistMetrics(),
 		Hps:       rsrc.newDistMetrics(),
 		Tto:       rsrc.newDistMetrics(),
+
+		MovementDpsLost: rsrc.newDistMetrics(),
+		EffectiveHps:    rsrc.newDistMetrics(),
+		OverhealPercent: rsrc.newDistMetrics(),
+		Ehp:             rsrc.newDistMetrics(),
+		MaxSpike:        rsrc.newDistMetrics(),
+		TimeToDeath:     rsrc.newDistMetrics(),
+
 		Actions:   make([]*proto.ActionMetrics, 0, len(baseUnit.Actions)),
 		Auras:     make([]*proto.AuraMetrics, len(baseUnit.Auras)),
 		Resources: make([]*proto.ResourceMetrics, 0, len(baseUnit.Resources)),
@@ -94,6 +103,18 @@ func (rsrc *raidSimResultCombiner) newUnitMetrics(baseUnit *proto.UnitMetrics) *
 		newUm.Pets[i] = rsrc.newUnitMetrics(pet)
 	}
 
+	if baseUnit.AplProfile != nil {
+		newUm.AplProfile = &proto.APLProfile{
+			PriorityList: make([]*proto.APLItemProfile, len(baseUnit.AplProfile.PriorityList)),
+		}
+		for i, item := range baseUnit.AplProfile.PriorityList {
+			newUm.AplProfile.PriorityList[i] = &proto.APLItemProfile{
+				ListIndex: item.ListIndex,
+				Action:    item.Action,
+			}
+		}
+	}
+
 	return newUm
 }
 
@@ -202,7 +223,11 @@ func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add
 		baseTgt.Threat += addTgt.Threat
 		baseTgt.Healing += addTgt.Healing
 		baseTgt.CritHealing += addTgt.CritHealing
+		baseTgt.Overhealing += addTgt.Overhealing
+		baseTgt.EffectiveHealing += addTgt.EffectiveHealing
 		baseTgt.Shielding += addTgt.Shielding
+		baseTgt.AbsorbedShielding += addTgt.AbsorbedShielding
+		baseTgt.WastedShielding += addTgt.WastedShielding
 		baseTgt.CastTimeMs += addTgt.CastTimeMs
 	}
 }
@@ -245,6 +270,87 @@ func (rsrc *raidSimResultCombiner) addResourceMetrics(unit *proto.UnitMetrics, a
 	rm.ActualGain += add.ActualGain
 }
 
+func (rsrc *raidSimResultCombiner) addDamageTakenMetrics(unit *proto.UnitMetrics, add *proto.DamageTakenMetrics) {
+	idx := slices.IndexFunc(unit.DamageTaken, func(dtm *proto.DamageTakenMetrics) bool {
+		return dtm.SourceUnitIndex == add.SourceUnitIndex && dtm.Id.String() == add.Id.String()
+	})
+	if idx == -1 {
+		unit.DamageTaken = append(unit.DamageTaken, &proto.DamageTakenMetrics{
+			Id:              add.Id,
+			SourceUnitIndex: add.SourceUnitIndex,
+		})
+		idx = len(unit.DamageTaken) - 1
+	}
+
+	dtm := unit.DamageTaken[idx]
+	dtm.Hits += add.Hits
+	dtm.Crits += add.Crits
+	dtm.Crushes += add.Crushes
+	dtm.Blocks += add.Blocks
+	dtm.Glances += add.Glances
+	dtm.Misses += add.Misses
+	dtm.Dodges += add.Dodges
+	dtm.Parries += add.Parries
+	dtm.Ticks += add.Ticks
+	dtm.Damage += add.Damage
+	dtm.HitDamage += add.HitDamage
+	dtm.CritDamage += add.CritDamage
+	dtm.CrushDamage += add.CrushDamage
+	dtm.BlockDamage += add.BlockDamage
+	dtm.GlanceDamage += add.GlanceDamage
+	dtm.TickDamage += add.TickDamage
+}
+
+func (rsrc *raidSimResultCombiner) combineIsbMetrics(unit *proto.UnitMetrics, add *proto.ImprovedShadowBoltMetrics, weight float64) {
+	if unit.ImprovedShadowBolt == nil {
+		unit.ImprovedShadowBolt = &proto.ImprovedShadowBoltMetrics{}
+	}
+
+	isb := unit.ImprovedShadowBolt
+	isb.UptimeSecondsAvg += add.UptimeSecondsAvg * weight
+	isb.ApplicationsAvg += add.ApplicationsAvg * weight
+	isb.ChargesAppliedAvg += add.ChargesAppliedAvg * weight
+
+	for _, addConsumer := range add.Consumers {
+		idx := slices.IndexFunc(isb.Consumers, func(c *proto.IsbChargeConsumerMetrics) bool {
+			return c.Name == addConsumer.Name && c.UnitIndex == addConsumer.UnitIndex
+		})
+		if idx == -1 {
+			isb.Consumers = append(isb.Consumers, &proto.IsbChargeConsumerMetrics{
+				Name:      addConsumer.Name,
+				UnitIndex: addConsumer.UnitIndex,
+			})
+			idx = len(isb.Consumers) - 1
+		}
+		isb.Consumers[idx].ChargesAvg += addConsumer.ChargesAvg * weight
+		isb.Consumers[idx].BonusDamageAvg += addConsumer.BonusDamageAvg * weight
+	}
+}
+
+func (rsrc *raidSimResultCombiner) combineAPLProfile(base *proto.APLProfile, add *proto.APLProfile) {
+	base.Evaluations += add.Evaluations
+	base.NoAction += add.NoAction
+
+	for i, addItem := range add.PriorityList {
+		item := base.PriorityList[i]
+		item.Evaluations += addItem.Evaluations
+		item.ConditionTrue += addItem.ConditionTrue
+		item.Executions += addItem.Executions
+		if item.Evaluations > 0 {
+			item.ConditionTrueRate = float64(item.ConditionTrue) / float64(item.Evaluations)
+		}
+
+		for _, addBlocked := range addItem.Blocked {
+			idx := slices.IndexFunc(item.Blocked, func(b *proto.APLBlockedCount) bool { return b.Reason == addBlocked.Reason })
+			if idx == -1 {
+				item.Blocked = append(item.Blocked, &proto.APLBlockedCount{Reason: addBlocked.Reason})
+				idx = len(item.Blocked) - 1
+			}
+			item.Blocked[idx].Count += addBlocked.Count
+		}
+	}
+}
+
 func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, add *proto.UnitMetrics, isLast bool, weight float64) {
 	rsrc.combineDistMetrics(base.Dps, add.Dps, isLast, weight)
 	rsrc.combineDistMetrics(base.Dpasp, add.Dpasp, isLast, weight)
@@ -253,9 +359,27 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 	rsrc.combineDistMetrics(base.Tmi, add.Tmi, isLast, weight)
 	rsrc.combineDistMetrics(base.Hps, add.Hps, isLast, weight)
 	rsrc.combineDistMetrics(base.Tto, add.Tto, isLast, weight)
+	rsrc.combineDistMetrics(base.MovementDpsLost, add.MovementDpsLost, isLast, weight)
+	rsrc.combineDistMetrics(base.EffectiveHps, add.EffectiveHps, isLast, weight)
+	rsrc.combineDistMetrics(base.OverhealPercent, add.OverhealPercent, isLast, weight)
+	rsrc.combineDistMetrics(base.Ehp, add.Ehp, isLast, weight)
+	rsrc.combineDistMetrics(base.MaxSpike, add.MaxSpike, isLast, weight)
+	rsrc.combineDistMetrics(base.TimeToDeath, add.TimeToDeath, isLast, weight)
 
 	base.SecondsOomAvg += add.SecondsOomAvg * weight
 	base.ChanceOfDeath += add.ChanceOfDeath * weight
+	base.SecondsForcedMovementAvg += add.SecondsForcedMovementAvg * weight
+	base.MissedInterruptsAvg += add.MissedInterruptsAvg * weight
+	base.MissedInterruptDamageAvg += add.MissedInterruptDamageAvg * weight
+	base.DispellableDebuffSecondsAvg += add.DispellableDebuffSecondsAvg * weight
+	base.AggroSwapsAvg += add.AggroSwapsAvg * weight
+	base.TankingSecondsAvg += add.TankingSecondsAvg * weight
+	base.AggroPullsAvg += add.AggroPullsAvg * weight
+	base.ChanceOfAggroWipe += add.ChanceOfAggroWipe * weight
+	base.EffectiveHealingAvg += add.EffectiveHealingAvg * weight
+	base.OverhealingAvg += add.OverhealingAvg * weight
+	base.AbsorbedShieldingAvg += add.AbsorbedShieldingAvg * weight
+	base.WastedShieldingAvg += add.WastedShieldingAvg * weight
 
 	for _, addAction := range add.Actions {
 		rsrc.addActionMetrics(base, addAction)
@@ -269,9 +393,28 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 		rsrc.addResourceMetrics(base, addResource)
 	}
 
+	for i, addEhp := range add.SchoolEhp {
+		if i == len(base.SchoolEhp) {
+			base.SchoolEhp = append(base.SchoolEhp, &proto.SchoolEffectiveHealth{School: addEhp.School})
+		}
+		base.SchoolEhp[i].Ehp += addEhp.Ehp * weight
+	}
+
+	for _, addDamageTaken := range add.DamageTaken {
+		rsrc.addDamageTakenMetrics(base, addDamageTaken)
+	}
+
+	if add.ImprovedShadowBolt != nil {
+		rsrc.combineIsbMetrics(base, add.ImprovedShadowBolt, weight)
+	}
+
 	for i, addPet := range add.Pets {
 		rsrc.combineUnitMetrics(base.Pets[i], addPet, isLast, weight)
 	}
+
+	if base.AplProfile != nil && add.AplProfile != nil {
+		rsrc.combineAPLProfile(base.AplProfile, add.AplProfile)
+	}
 }
 
 func (rsrc *raidSimResultCombiner) AddResult(result *proto.RaidSimResult, isLast bool, weight float64) {
@@ -287,6 +430,13 @@ func (rsrc *raidSimResultCombiner) AddResult(result *proto.RaidSimResult, isLast
 		}
 	}
 
+	for i, assignment := range result.RaidMetrics.CooldownAssignments {
+		baseAssignment := rsrc.Combined.RaidMetrics.CooldownAssignments[i]
+		baseAssignment.AvgCasts += assignment.AvgCasts * weight
+		baseAssignment.RaidDpsGain += assignment.RaidDpsGain * weight
+		baseAssignment.TargetDpsGain += assignment.TargetDpsGain * weight
+	}
+
 	for i, tar := range result.EncounterMetrics.Targets {
 		rsrc.combineUnitMetrics(rsrc.Combined.EncounterMetrics.Targets[i], tar, isLast, weight)
 	}
@@ -320,6 +470,10 @@ func (rsrc *raidSimResultCombiner) SetBaseResult(baseRsr *proto.RaidSimResult) {
 		newRsr.RaidMetrics.Parties[i] = rsrc.newPartyMetrics(party)
 	}
 
+	for range baseRsr.RaidMetrics.CooldownAssignments {
+		newRsr.RaidMetrics.CooldownAssignments = append(newRsr.RaidMetrics.CooldownAssignments, &proto.RaidCooldownAssignmentMetrics{})
+	}
+
 	for i, tar := range baseRsr.EncounterMetrics.Targets {
 		newRsr.EncounterMetrics.Targets[i] = rsrc.newUnitMetrics(tar)
 	}
@@ -557,68 +557,68 @@ func (spell *Spell) CanCast(sim *Simulation, target *Unit) bool {
 		return false
 	}
 
+	return spell.CastFailureReason(sim, target) == proto.CastFailureReason_CastFailureNone
+}
+
+// Returns the first check that prevents this spell from being cast, or CastFailureNone
+// if the spell can be cast. Uses the same order of checks as CanCast.
+func (spell *Spell) CastFailureReason(sim *Simulation, target *Unit) proto.CastFailureReason {
 	if spell.Flags.Matches(SpellFlagSwapped) {
-		//if sim.Log != nil {
-		//	sim.Log("Cant cast because of item swap")
-		//}
-		return false
+		return proto.CastFailureReason_CastFailureSwapped
+	}
+
+	// Enemies which have despawned or are untargetable can't be cast on
+	if target != nil && spell.Unit.IsOpponent(target) && (!target.IsEnabled() || (target.Type == EnemyUnit && !target.Env.GetTarget(target.Index).IsSpawned())) {
+		return proto.CastFailureReason_CastFailureTarget
 	}
 
 	if spell.ExtraCastCondition != nil && !spell.ExtraCastCondition(sim, target) {
-		//if sim.Log != nil {
-		//	sim.Log("Cant cast because of extra condition")
-		//}
-		return false
+		// Range checks are folded into ExtraCastCondition, so split them out here.
+		if ((spell.MinRange != 0) && (spell.Unit.DistanceFromTarget < spell.MinRange)) || ((spell.MaxRange != 0) && (spell.Unit.DistanceFromTarget > spell.MaxRange)) {
+			return proto.CastFailureReason_CastFailureRange
+		}
+		return proto.CastFailureReason_CastFailureCondition
+	}
+
+	if spell.Unit.IsIncapacitated() {
+		return proto.CastFailureReason_CastFailureIncapacitated
 	}
 
 	// While moving only instant casts are possible
 	if spell.DefaultCast.CastTime > 0 && spell.Unit.IsMoving() {
-		//if sim.Log != nil {
-		//	sim.Log("Cant cast because moving")
-		//}
-		return false
+		return proto.CastFailureReason_CastFailureMoving
+	}
+
+	// Channels are cancelled by encounter movement, so don't start them while it lasts
+	if spell.Flags.Matches(SpellFlagChanneled) && spell.Unit.IsForcedMoving() {
+		return proto.CastFailureReason_CastFailureMoving
 	}
 
 	// While casting no other action is possible except rare cast-while-casting spells
 	if spell.Unit.IsCasting(sim) && !spell.Flags.Matches(SpellFlagCastWhileCasting) {
-		//if sim.Log != nil {
-		//	sim.Log("Cant cast because already casting")
-		//}
-		return false
+		return proto.CastFailureReason_CastFailureCasting
 	}
 
 	// While channeling no other action is possible except rare cast-while-channeling spells
 	if spell.Unit.IsChanneling(sim) && !spell.Flags.Matches(SpellFlagCastWhileChanneling) && (spell.Unit.Rotation.interruptChannelIf == nil || !spell.Unit.Rotation.interruptChannelIf.GetBool(sim)) {
-		//if sim.Log != nil {
-		//	sim.Log("Cant cast because already channeling")
-		//}
-		return false
+		return proto.CastFailureReason_CastFailureChanneling
 	}
 
 	if spell.DefaultCast.GCD > 0 && !spell.Unit.GCD.IsReady(sim) {
-		//if sim.Log != nil {
-		//	sim.Log("Cant cast because of GCD")
-		//}
-		return false
+		return proto.CastFailureReason_CastFailureGCD
 	}
 
 	if !BothTimersReady(spell.CD.Timer, spell.SharedCD.Timer, sim) {
-		//if sim.Log != nil {
-		//	sim.Log("Cant cast because of CDs")
-		//}
-		return false
+		return proto.CastFailureReason_CastFailureCooldown
 	}
 
 	if spell.Cost != nil {
 		if !spell.Cost.MeetsRequirement(sim, spell) {
-			//if sim.Log != nil {
-			//	sim.Log("Cant cast because of resource cost")
-			//}
-			return false
+			return proto.CastFailureReason_CastFailureResource
 		}
 	}
 
-	return true
+	return proto.CastFailureReason_CastFailureNone
 }
 
 func (spell *Spell) Cast(sim *Simulation, target *Unit) bool {
@@ -642,10 +642,11 @@ func (spell *Spell) applyEffects(sim *Simulation, target *Unit) {
 	spell.ApplyEffects(sim, target, spell)
 }
 
+// Only adds to the threat metrics, not to the targets' threat tables, as this is used for
+// resource gains which are summed up at the end of each iteration.
 func (spell *Spell) ApplyAOEThreatIgnoreMultipliers(threatAmount float64) {
-	numTargets := spell.Unit.Env.GetNumTargets()
-	for i := int32(0); i < numTargets; i++ {
-		spell.SpellMetrics[i].TotalThreat += threatAmount
+	for _, target := range spell.Unit.Env.Encounter.TargetUnits {
+		spell.SpellMetrics[target.UnitIndex].TotalThreat += threatAmount
 	}
 }
 func (spell *Spell) ApplyAOEThreat(threatAmount float64) {
@@ -142,6 +142,10 @@ func (unit *Unit) resistCoeff(spell *Spell, attacker *Unit, binary bool, pureDot
 		resistance = unit.GetResistanceForSchool(spell.SchoolIndex)
 	}
 
+	return unit.resistCoeffForResistance(resistance, attacker, binary, pureDot)
+}
+
+func (unit *Unit) resistCoeffForResistance(resistance float64, attacker *Unit, binary bool, pureDot bool) float64 {
 	resistance = max(0, resistance-attacker.stats[stats.SpellPenetration])
 
 	resistanceCap := float64(attacker.Level * 5)
@@ -169,8 +173,20 @@ func (unit *Unit) binaryHitChance(spell *Spell, attacker *Unit) float64 {
 
 // Roll threshold for each type of partial resist.
 func (unit *Unit) partialResistRollThresholds(spell *Spell, attacker *Unit, pureDot bool) (float64, float64, float64) {
-	resistCoeff := unit.resistCoeff(spell, attacker, false, pureDot)
+	return partialResistRollThresholds(unit.resistCoeff(spell, attacker, false, pureDot))
+}
+
+// Average fraction of the damage from a non-binary spell of the attacker which is partially resisted.
+// Only for base schools!
+func (unit *Unit) averagePartialResist(schoolIndex stats.SchoolIndex, attacker *Unit) float64 {
+	if schoolIndex <= stats.SchoolIndexPhysical {
+		return 0
+	}
+	threshold00, threshold25, threshold50 := partialResistRollThresholds(unit.resistCoeffForResistance(unit.GetResistanceForSchool(schoolIndex), attacker, false, false))
+	return 0.25 * (threshold00 + threshold25 + threshold50)
+}
 
+func partialResistRollThresholds(resistCoeff float64) (float64, float64, float64) {
 	// Based on the piecewise linear regression estimates at https://royalgiraffe.github.io/partial-resist-table.
 	if val := resistCoeff * 3; val <= 1 {
 		return 0.76 * val, 0.21 * val, 0.03 * val
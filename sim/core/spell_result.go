@@ -333,6 +333,10 @@ func (spell *Spell) calcDamageInternal(sim *Simulation, target *Unit, baseDamage
 		)
 	}
 
+	if target.PseudoStats.Immune {
+		result.Damage = 0
+	}
+
 	result.Threat = spell.ThreatFromDamage(result.Outcome, result.Damage)
 
 	return result
@@ -405,6 +409,17 @@ func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result
 	isPeriodic = isPeriodic || spell.Flags.Matches(SpellFlagTreatAsPeriodic)
 	isPartialResist := result.DidResist()
 
+	// Despawned and untargetable targets take no damage, e.g. from a dot ticking or a
+	// delayed hit landing after they are gone.
+	if result.Target.Type == EnemyUnit && !sim.Encounter.AllTargets[result.Target.Index].IsSpawned() {
+		result.Damage = 0
+		result.Threat = 0
+	}
+
+	if len(result.Target.activeShields) > 0 {
+		result.Damage = result.Target.absorbDamage(sim, result.Damage)
+	}
+
 	if sim.CurrentTime >= 0 {
 		spell.SpellMetrics[result.Target.UnitIndex].TotalDamage += result.Damage
 		if isPartialResist {
@@ -438,12 +453,24 @@ func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result
 			spell.SpellMetrics[result.Target.UnitIndex].TotalCrushDamage += result.Damage
 		}
 		spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+		spell.Unit.addThreat(sim, result.Target, result.Threat)
 	}
 
 	// Mark total damage done in raid so far for health based fights.
 	// Don't include damage done by EnemyUnits to Players
+	// Targets with health take at most their remaining health, and only required targets
+	// count towards health fights.
 	if result.Target.Type == EnemyUnit {
-		sim.Encounter.DamageTaken += result.Damage
+		target := sim.Encounter.AllTargets[result.Target.Index]
+		damage := result.Damage
+		if target.HasHealthBar() {
+			damage = target.takeDamage(sim, damage)
+		}
+		if target.required {
+			sim.Encounter.DamageTaken += damage
+			sim.Encounter.damageRate.addDamage(sim, damage)
+		}
+		target.damageRate.addDamage(sim, result.Damage)
 	}
 
 	if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
@@ -551,7 +578,10 @@ func (spell *Spell) dealHealingInternal(sim *Simulation, isPeriodic bool, result
 	}
 	spell.SpellMetrics[result.Target.UnitIndex].TotalHealing += result.Damage
 	spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+	spell.Unit.addHealingThreat(sim, result.Threat)
 	if result.Target.HasHealthBar() {
+		missingHealth := result.Target.MaxHealth() - result.Target.CurrentHealth()
+		spell.SpellMetrics[result.Target.UnitIndex].TotalOverhealing += max(0, result.Damage-missingHealth)
 		result.Target.GainHealth(sim, result.Damage, spell.HealthMetrics(result.Target))
 	}
 
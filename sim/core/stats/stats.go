@@ -506,6 +506,8 @@ type PseudoStats struct {
 
 	BonusHealingTaken float64 // Talisman of Troll Divinity
 
+	Immune bool // Takes no damage, e.g. during scripted boss phases
+
 	BonusDamageTakenBeforeModifiers [DefenseTypeLen]float64 // Flat damage reduction values BEFORE Modifiers like Blessing of Sanctuary
 	BonusDamageTakenAfterModifiers  [DefenseTypeLen]float64 // Flat damage reduction values AFTER Modifiers like Stoneskin Totem, Windwall Totem, etc.
 
@@ -76,6 +76,7 @@ type StatWeightsResult struct {
 	Dtps   StatWeightValues
 	Tmi    StatWeightValues
 	PDeath StatWeightValues
+	Ehp    StatWeightValues
 }
 
 func NewStatWeightsResult() *StatWeightsResult {
@@ -86,6 +87,7 @@ func NewStatWeightsResult() *StatWeightsResult {
 		Dtps:   NewStatWeightValues(),
 		Tmi:    NewStatWeightValues(),
 		PDeath: NewStatWeightValues(),
+		Ehp:    NewStatWeightValues(),
 	}
 }
 
@@ -97,6 +99,7 @@ func (swr *StatWeightsResult) ToProto() *proto.StatWeightsResult {
 		Dtps:   swr.Dtps.ToProto(),
 		Tmi:    swr.Tmi.ToProto(),
 		PDeath: swr.PDeath.ToProto(),
+		Ehp:    swr.Ehp.ToProto(),
 	}
 }
 
@@ -214,7 +217,8 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 
 		// Check for hard caps. Hard caps will have results identical to the baseline because RNG is fixed.
 		// When we find a hard-capped stat, just skip it (will return 0).
-		if modPlayerHigh.Dps.Avg == baselinePlayer.Dps.Avg && modPlayerHigh.Hps.Avg == baselinePlayer.Hps.Avg && modPlayerHigh.Tmi.Avg == baselinePlayer.Tmi.Avg {
+		if modPlayerHigh.Dps.Avg == baselinePlayer.Dps.Avg && modPlayerHigh.Hps.Avg == baselinePlayer.Hps.Avg && modPlayerHigh.Tmi.Avg == baselinePlayer.Tmi.Avg &&
+			modPlayerHigh.Ehp.Avg == baselinePlayer.Ehp.Avg {
 			continue
 		}
 
@@ -239,6 +243,7 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 		calcWeightResults(baselinePlayer.Threat, modPlayerLow.Threat, modPlayerHigh.Threat, &result.Tps)
 		calcWeightResults(baselinePlayer.Dtps, modPlayerLow.Dtps, modPlayerHigh.Dtps, &result.Dtps)
 		calcWeightResults(baselinePlayer.Tmi, modPlayerLow.Tmi, modPlayerHigh.Tmi, &result.Tmi)
+		calcWeightResults(baselinePlayer.Ehp, modPlayerLow.Ehp, modPlayerHigh.Ehp, &result.Ehp)
 		meanLow := (modPlayerLow.ChanceOfDeath - baselinePlayer.ChanceOfDeath) / statResult.StatData.ModLow
 		meanHigh := (modPlayerHigh.ChanceOfDeath - baselinePlayer.ChanceOfDeath) / statResult.StatData.ModHigh
 		result.PDeath.Weights.AddStat(stat, (meanLow+meanHigh)/2)
@@ -267,6 +272,7 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 		calcEpResults(&result.Dtps, DTPSReferenceStat)
 		calcEpResults(&result.Tmi, DTPSReferenceStat)
 		calcEpResults(&result.PDeath, DTPSReferenceStat)
+		calcEpResults(&result.Ehp, DTPSReferenceStat)
 	}
 
 	return result.ToProto()
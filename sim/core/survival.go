@@ -0,0 +1,286 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+// Burst window used for spike metrics when HealingModel.BurstWindow isn't set.
+const defaultSpikeWindow = 6
+
+var numBaseSchools = len(proto.SpellSchool_name)
+
+type damageTakenItem struct {
+	Timestamp time.Duration
+	Damage    float64
+	MaxHealth float64
+}
+
+// Damage taken from a single spell, summed over all iterations.
+type DamageTakenMetrics struct {
+	Hits    int32
+	Crits   int32
+	Crushes int32
+	Blocks  int32
+	Glances int32
+	Misses  int32
+	Dodges  int32
+	Parries int32
+	Ticks   int32
+
+	Damage       float64
+	HitDamage    float64
+	CritDamage   float64
+	CrushDamage  float64
+	BlockDamage  float64
+	GlanceDamage float64
+	TickDamage   float64
+}
+
+func (dtm *DamageTakenMetrics) ToProto(spell *Spell) *proto.DamageTakenMetrics {
+	return &proto.DamageTakenMetrics{
+		Id:              spell.ActionID.ToProto(),
+		SourceUnitIndex: spell.Unit.UnitIndex,
+
+		Hits:    dtm.Hits,
+		Crits:   dtm.Crits,
+		Crushes: dtm.Crushes,
+		Blocks:  dtm.Blocks,
+		Glances: dtm.Glances,
+		Misses:  dtm.Misses,
+		Dodges:  dtm.Dodges,
+		Parries: dtm.Parries,
+		Ticks:   dtm.Ticks,
+
+		Damage:       dtm.Damage,
+		HitDamage:    dtm.HitDamage,
+		CritDamage:   dtm.CritDamage,
+		CrushDamage:  dtm.CrushDamage,
+		BlockDamage:  dtm.BlockDamage,
+		GlanceDamage: dtm.GlanceDamage,
+		TickDamage:   dtm.TickDamage,
+	}
+}
+
+// Starts collecting survival metrics for the unit, i.e. effective health, damage spikes,
+// time to death and the damage taken from each source.
+func (unit *Unit) trackSurvival(burstWindow int32) {
+	unitMetrics := &unit.Metrics
+	unitMetrics.tracksSurvival = true
+	unitMetrics.spikeWindow = burstWindow
+	if unitMetrics.spikeWindow <= 0 {
+		unitMetrics.spikeWindow = defaultSpikeWindow
+	}
+	unitMetrics.schoolEhpSums = make([]float64, numBaseSchools)
+	unitMetrics.iterationEhp = make([]float64, numBaseSchools)
+	unitMetrics.damageTaken = make(map[*Spell]*DamageTakenMetrics)
+
+	unit.RegisterResetEffect(func(sim *Simulation) {
+		// Wait for all permanent auras to be active.
+		StartDelayedAction(sim, DelayedActionOptions{
+			DoAt:     0,
+			Priority: ActionPriorityLow,
+			OnAction: func(sim *Simulation) {
+				attacker := unit.primaryAttacker()
+				if attacker == nil {
+					return
+				}
+				for i := range unitMetrics.iterationEhp {
+					unitMetrics.iterationEhp[i] = unit.EffectiveHealth(attacker, SpellSchoolFromProto(proto.SpellSchool(i)))
+				}
+			},
+		})
+	})
+}
+
+// The target attacking this unit, or the primary target if none are.
+func (unit *Unit) primaryAttacker() *Unit {
+	targets := unit.Env.Encounter.TargetUnits
+	if len(targets) == 0 {
+		return nil
+	}
+	for _, target := range targets {
+		if target.CurrentTarget == unit {
+			return target
+		}
+	}
+	return targets[0]
+}
+
+// The raw damage of the given school from the attacker it takes to kill this unit from full
+// health, given its current armor, resistances and avoidance.
+func (unit *Unit) EffectiveHealth(attacker *Unit, school SpellSchool) float64 {
+	damageTaken := unit.expectedDamageTakenMultiplier(attacker, school)
+	if damageTaken <= 0 {
+		return 0
+	}
+	return unit.MaxHealth() / damageTaken
+}
+
+// Expected fraction of the raw damage of a melee attack or non-binary spell which is taken.
+func (unit *Unit) expectedDamageTakenMultiplier(attacker *Unit, school SpellSchool) float64 {
+	schoolIndex := school.GetSchoolIndex()
+	multiplier := unit.PseudoStats.DamageTakenMultiplier * unit.PseudoStats.SchoolDamageTakenMultiplier[schoolIndex]
+
+	attackTable := attacker.AttackTables[unit.UnitIndex][proto.CastType_CastTypeMainHand]
+	if schoolIndex != stats.SchoolIndexPhysical {
+		return multiplier * (1 - attackTable.BaseSpellMissChance) * (1 - unit.averagePartialResist(schoolIndex, attacker))
+	}
+
+	armorMultiplier := attackTable.GetArmorDamageModifier()
+
+	// Same single roll table as outcomeEnemyMeleeWhite.
+	total := 0.0
+	addChance := func(chance float64) float64 {
+		chance = min(max(0, chance), 1-total)
+		total += chance
+		return chance
+	}
+
+	missChance := attackTable.BaseMissChance + attacker.PseudoStats.IncreasedMissChance + unit.stats[stats.Defense]*DefenseRatingToChanceReduction
+	if attacker.AutoAttacks.IsDualWielding && !attacker.PseudoStats.DisableDWMissPenalty {
+		missChance += 0.19
+	}
+	addChance(missChance)
+	if !unit.PseudoStats.Stunned {
+		addChance(attackTable.BaseDodgeChance - max(0, unit.PseudoStats.DodgeReduction) + unit.GetStat(stats.Dodge)/100)
+	}
+	if unit.PseudoStats.CanParry && !unit.PseudoStats.Stunned {
+		addChance(attackTable.BaseParryChance + unit.GetStat(stats.Parry)/100)
+	}
+
+	blockChance := 0.0
+	if unit.PseudoStats.CanBlock && !unit.PseudoStats.Stunned {
+		blockChance = addChance(attackTable.BaseBlockChance + unit.stats[stats.Block]/BlockRatingPerBlockChance/100)
+	}
+	critChance := addChance(attackTable.BaseCritChance - unit.stats[stats.Defense]*DefenseRatingToChanceReduction - unit.PseudoStats.ReducedCritTakenChance)
+	crushChance := 0.0
+	if attacker.PseudoStats.CanCrush {
+		crushChance = addChance(attackTable.BaseCrushChance)
+	}
+	hitChance := 1 - total
+
+	// Blocks reduce the damage by a flat amount, so compare it against an average swing.
+	blockMultiplier := 1.0
+	if mh := attacker.AutoAttacks.MH(); mh != nil && mh.BaseDamageMin > 0 {
+		averageHit := mh.BaseDamageMin * (1 + attacker.PseudoStats.DamageSpread/2 + max(0, attacker.stats[stats.AttackPower])*EnemyAutoAttackAPCoefficient)
+		averageHit *= armorMultiplier
+		blockMultiplier = max(0, 1-unit.BlockValue()/averageHit)
+	}
+
+	return multiplier * armorMultiplier * (hitChance + critChance*2 + crushChance*1.5 + blockChance*blockMultiplier)
+}
+
+func (unitMetrics *UnitMetrics) addDamageTaken(sim *Simulation, spell *Spell, result *SpellResult, isPeriodic bool) {
+	if !unitMetrics.tracksSurvival {
+		return
+	}
+
+	dtm := unitMetrics.damageTaken[spell]
+	if dtm == nil {
+		dtm = &DamageTakenMetrics{}
+		unitMetrics.damageTaken[spell] = dtm
+		unitMetrics.damageTakenSpells = append(unitMetrics.damageTakenSpells, spell)
+	}
+
+	damage := result.Damage
+	dtm.Damage += damage
+	switch {
+	case isPeriodic:
+		dtm.Ticks++
+		dtm.TickDamage += damage
+	case result.Outcome.Matches(OutcomeMiss):
+		dtm.Misses++
+	case result.Outcome.Matches(OutcomeDodge):
+		dtm.Dodges++
+	case result.Outcome.Matches(OutcomeParry):
+		dtm.Parries++
+	case result.Outcome.Matches(OutcomeBlock):
+		dtm.Blocks++
+		dtm.BlockDamage += damage
+	case result.Outcome.Matches(OutcomeCrit):
+		dtm.Crits++
+		dtm.CritDamage += damage
+	case result.Outcome.Matches(OutcomeCrush):
+		dtm.Crushes++
+		dtm.CrushDamage += damage
+	case result.Outcome.Matches(OutcomeGlance):
+		dtm.Glances++
+		dtm.GlanceDamage += damage
+	default:
+		dtm.Hits++
+		dtm.HitDamage += damage
+	}
+
+	if damage > 0 {
+		unitMetrics.damageTakenList = append(unitMetrics.damageTakenList, damageTakenItem{
+			Timestamp: sim.CurrentTime,
+			Damage:    damage,
+			MaxHealth: result.Target.MaxHealth(),
+		})
+	}
+}
+
+// Largest damage taken within any window of the given length.
+func (unitMetrics *UnitMetrics) calculateMaxSpike(window time.Duration) float64 {
+	maxSpike := 0.0
+	windowDamage := 0.0
+	first := 0
+	for _, item := range unitMetrics.damageTakenList {
+		windowDamage += item.Damage
+		for item.Timestamp-unitMetrics.damageTakenList[first].Timestamp >= window {
+			windowDamage -= unitMetrics.damageTakenList[first].Damage
+			first++
+		}
+		maxSpike = max(maxSpike, windowDamage)
+	}
+	return maxSpike
+}
+
+func (unitMetrics *UnitMetrics) calculateTimeToDeath(sim *Simulation) float64 {
+	totalDamage := 0.0
+	for _, item := range unitMetrics.damageTakenList {
+		totalDamage += item.Damage
+		if totalDamage >= item.MaxHealth {
+			return item.Timestamp.Seconds()
+		}
+	}
+	if totalDamage == 0 {
+		return sim.Duration.Seconds()
+	}
+
+	maxHealth := unitMetrics.damageTakenList[len(unitMetrics.damageTakenList)-1].MaxHealth
+	return maxHealth / (totalDamage / sim.Duration.Seconds())
+}
+
+func (unitMetrics *UnitMetrics) doneSurvivalIteration(sim *Simulation) {
+	encounterDurationSeconds := sim.Duration.Seconds()
+
+	// Hack because of the way DistributionMetrics does its calculations.
+	unitMetrics.ehp.Total = unitMetrics.iterationEhp[proto.SpellSchool_SpellSchoolPhysical] * encounterDurationSeconds
+	unitMetrics.maxSpike.Total = unitMetrics.calculateMaxSpike(time.Duration(unitMetrics.spikeWindow)*time.Second) * encounterDurationSeconds
+	unitMetrics.timeToDeath.Total = unitMetrics.calculateTimeToDeath(sim) * encounterDurationSeconds
+
+	for i, ehp := range unitMetrics.iterationEhp {
+		unitMetrics.schoolEhpSums[i] += ehp
+	}
+}
+
+func (unitMetrics *UnitMetrics) survivalToProto(protoMetrics *proto.UnitMetrics) {
+	if !unitMetrics.tracksSurvival {
+		return
+	}
+
+	n := float64(unitMetrics.dps.n)
+	for i, ehpSum := range unitMetrics.schoolEhpSums {
+		protoMetrics.SchoolEhp = append(protoMetrics.SchoolEhp, &proto.SchoolEffectiveHealth{
+			School: proto.SpellSchool(i),
+			Ehp:    ehpSum / n,
+		})
+	}
+	for _, spell := range unitMetrics.damageTakenSpells {
+		protoMetrics.DamageTaken = append(protoMetrics.DamageTaken, unitMetrics.damageTaken[spell].ToProto(spell))
+	}
+}
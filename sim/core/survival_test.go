@@ -0,0 +1,255 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func TestExpectedDamageTakenMultiplier(t *testing.T) {
+	// Against a level 63 attacker, 3000 armor reduces physical damage by 3000 / (3000 + 400 + 85*63).
+	armorMultiplier := 1 - 3000.0/8755
+
+	tests := []struct {
+		name     string
+		school   SpellSchool
+		setup    func(sim *Simulation, fa *FakeAgent)
+		expected float64
+	}{
+		{
+			name:     "spell hits",
+			school:   SpellSchoolShadow,
+			expected: 0.95,
+		},
+		{
+			name:   "capped resistance",
+			school: SpellSchoolFire,
+			setup: func(sim *Simulation, fa *FakeAgent) {
+				fa.AddStatDynamic(sim, stats.FireResistance, 315)
+			},
+			// Resists 69% on average.
+			expected: 0.95 * 0.31,
+		},
+		{
+			name:   "damage taken multiplier",
+			school: SpellSchoolShadow,
+			setup: func(_ *Simulation, fa *FakeAgent) {
+				fa.PseudoStats.DamageTakenMultiplier *= 0.5
+			},
+			expected: 0.475,
+		},
+		{
+			name:   "armor without avoidance",
+			school: SpellSchoolPhysical,
+			setup: func(sim *Simulation, fa *FakeAgent) {
+				fa.AddStatDynamic(sim, stats.Armor, 3000-fa.Armor())
+				fa.PseudoStats.Stunned = true
+			},
+			// 17.6% crits and 15% crushes, the rest are normal hits.
+			expected: armorMultiplier * (0.674 + 0.176*2 + 0.15*1.5),
+		},
+		{
+			name:   "armor with dodge",
+			school: SpellSchoolPhysical,
+			setup: func(sim *Simulation, fa *FakeAgent) {
+				fa.AddStatDynamic(sim, stats.Armor, 3000-fa.Armor())
+				fa.AddStatDynamic(sim, stats.Dodge, 20)
+			},
+			// 9.1% dodges take their share from normal hits.
+			expected: armorMultiplier * (0.583 + 0.176*2 + 0.15*1.5),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sim, agents := setupEncounterDamageSim(1, &proto.EncounterDamageEvent{TimeSeconds: 1000})
+			fa := agents[0]
+			attacker := sim.Encounter.TargetUnits[0]
+			if test.setup != nil {
+				test.setup(sim, fa)
+			}
+
+			if multiplier := fa.expectedDamageTakenMultiplier(attacker, test.school); !WithinToleranceFloat64(test.expected, multiplier, 0.000001) {
+				t.Fatalf("Expected a damage taken multiplier of %0.5f, got %0.5f", test.expected, multiplier)
+			}
+			if ehp := fa.EffectiveHealth(attacker, test.school); !WithinToleranceFloat64(fa.MaxHealth()/test.expected, ehp, 0.001) {
+				t.Fatalf("Expected %0.3f effective health, got %0.3f", fa.MaxHealth()/test.expected, ehp)
+			}
+		})
+	}
+}
+
+func TestCalculateMaxSpike(t *testing.T) {
+	tests := []struct {
+		name     string
+		hits     map[time.Duration]float64
+		window   time.Duration
+		expected float64
+	}{
+		{name: "no damage", window: time.Second * 6, expected: 0},
+		{
+			name:     "single hit",
+			hits:     map[time.Duration]float64{time.Second: 500},
+			window:   time.Second * 6,
+			expected: 500,
+		},
+		{
+			name:     "hits within the window",
+			hits:     map[time.Duration]float64{time.Second: 500, time.Second * 3: 300, time.Second * 6: 200},
+			window:   time.Second * 6,
+			expected: 1000,
+		},
+		{
+			// Hits exactly a window apart are in different windows.
+			name:     "window boundary",
+			hits:     map[time.Duration]float64{time.Second: 500, time.Second * 7: 300, time.Second * 8: 400},
+			window:   time.Second * 6,
+			expected: 700,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			unitMetrics := &UnitMetrics{}
+			for at := time.Duration(0); at <= time.Second*10; at += time.Second {
+				if damage, ok := test.hits[at]; ok {
+					unitMetrics.damageTakenList = append(unitMetrics.damageTakenList, damageTakenItem{Timestamp: at, Damage: damage, MaxHealth: 1000})
+				}
+			}
+			if spike := unitMetrics.calculateMaxSpike(test.window); spike != test.expected {
+				t.Fatalf("Expected a max spike of %0.3f, got %0.3f", test.expected, spike)
+			}
+		})
+	}
+}
+
+func TestCalculateTimeToDeath(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []damageTakenItem
+		expected float64
+	}{
+		{name: "no damage", expected: 100},
+		{
+			name: "dies",
+			items: []damageTakenItem{
+				{Timestamp: time.Second * 10, Damage: 600, MaxHealth: 1000},
+				{Timestamp: time.Second * 20, Damage: 600, MaxHealth: 1000},
+			},
+			expected: 20,
+		},
+		{
+			// 500 damage over 100s is 5 DTPS, so 1000 health lasts 200s.
+			name: "survives",
+			items: []damageTakenItem{
+				{Timestamp: time.Second * 10, Damage: 200, MaxHealth: 1000},
+				{Timestamp: time.Second * 50, Damage: 300, MaxHealth: 1000},
+			},
+			expected: 200,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sim := &Simulation{Duration: time.Second * 100}
+			unitMetrics := &UnitMetrics{damageTakenList: test.items}
+			if ttd := unitMetrics.calculateTimeToDeath(sim); !WithinToleranceFloat64(test.expected, ttd, 0.000001) {
+				t.Fatalf("Expected a time to death of %0.3fs, got %0.3fs", test.expected, ttd)
+			}
+		})
+	}
+}
+
+func TestSchoolEhpMerge(t *testing.T) {
+	rsr := fakeSimRequest(&proto.Encounter{
+		Targets:      []*proto.Target{newFakeSimTarget()},
+		Duration:     60,
+		DamageEvents: []*proto.EncounterDamageEvent{{TimeSeconds: 1000}},
+	})
+	rsr.SimOptions.Iterations = 30
+	rsr.SimOptions.IsTest = true
+
+	result := RunRaidSim(rsr)
+	mtResult := RunRaidSimConcurrent(rsr)
+	if result.Error != nil || mtResult.Error != nil {
+		t.Fatalf("Unexpected error: %v %v", result.Error, mtResult.Error)
+	}
+
+	schoolEhp := result.RaidMetrics.Parties[0].Players[0].SchoolEhp
+	mtSchoolEhp := mtResult.RaidMetrics.Parties[0].Players[0].SchoolEhp
+	if len(schoolEhp) != numBaseSchools || len(mtSchoolEhp) != numBaseSchools {
+		t.Fatalf("Expected effective health for all %d schools, got %d and %d", numBaseSchools, len(schoolEhp), len(mtSchoolEhp))
+	}
+	for i := range schoolEhp {
+		if schoolEhp[i].School != mtSchoolEhp[i].School || !WithinToleranceFloat64(schoolEhp[i].Ehp, mtSchoolEhp[i].Ehp, 0.001) {
+			t.Fatalf("Expected %s effective health of %0.3f from the split sims, got %0.3f", schoolEhp[i].School, schoolEhp[i].Ehp, mtSchoolEhp[i].Ehp)
+		}
+	}
+
+	// The fake agent has 2050 health, and 5% of shadow spells miss.
+	if ehp := mtSchoolEhp[proto.SpellSchool_SpellSchoolShadow].Ehp; !WithinToleranceFloat64(2050/0.95, ehp, 0.001) {
+		t.Fatalf("Expected %0.3f shadow effective health, got %0.3f", 2050/0.95, ehp)
+	}
+}
+
+// A raid sim result for a single player, with the given effective health in each iteration.
+func ehpStatWeightResult(ehp ...float64) *proto.RaidSimResult {
+	dist := func(values []float64) *proto.DistributionMetrics {
+		avg := 0.0
+		for _, value := range values {
+			avg += value / float64(len(values))
+		}
+		return &proto.DistributionMetrics{Avg: avg, AllValues: values}
+	}
+	zeros := make([]float64, len(ehp))
+	return &proto.RaidSimResult{
+		RaidMetrics: &proto.RaidMetrics{
+			Parties: []*proto.PartyMetrics{{
+				Players: []*proto.UnitMetrics{{
+					Dps:    dist(zeros),
+					Hps:    dist(zeros),
+					Threat: dist(zeros),
+					Dtps:   dist(zeros),
+					Tmi:    dist(zeros),
+					Ehp:    dist(ehp),
+				}},
+			}},
+		},
+	}
+}
+
+func TestEhpStatWeights(t *testing.T) {
+	result := computeStatWeights(&proto.StatWeightsCalcRequest{
+		BaseResult:      ehpStatWeightResult(1000, 1000),
+		EpReferenceStat: proto.Stat_StatAttackPower,
+		StatSimResults: []*proto.StatWeightsStatResultData{
+			{
+				StatData:   &proto.StatWeightsStatData{UnitStat: int32(stats.Armor), ModLow: 100, ModHigh: 200},
+				ResultLow:  ehpStatWeightResult(1050, 1050),
+				ResultHigh: ehpStatWeightResult(1100, 1100),
+			},
+			{
+				StatData:   &proto.StatWeightsStatData{UnitStat: int32(stats.Stamina), ModLow: 10, ModHigh: 20},
+				ResultLow:  ehpStatWeightResult(1100, 1100),
+				ResultHigh: ehpStatWeightResult(1200, 1200),
+			},
+			{
+				// Stats without any effect are skipped.
+				StatData:   &proto.StatWeightsStatData{UnitStat: int32(stats.AttackPower), ModLow: 10, ModHigh: 20},
+				ResultLow:  ehpStatWeightResult(1000, 1000),
+				ResultHigh: ehpStatWeightResult(1000, 1000),
+			},
+		},
+	})
+
+	weights, epValues := result.Ehp.Weights.Stats, result.Ehp.EpValues.Stats
+	if weights[stats.Armor] != 0.5 || weights[stats.Stamina] != 10 || weights[stats.AttackPower] != 0 {
+		t.Fatalf("Expected EHP weights of 0.5 per armor and 10 per stamina, got %0.3f and %0.3f", weights[stats.Armor], weights[stats.Stamina])
+	}
+	// EHP EP values are relative to armor, like DTPS.
+	if epValues[stats.Armor] != 1 || epValues[stats.Stamina] != 20 {
+		t.Fatalf("Expected EHP EP values of 1 for armor and 20 for stamina, got %0.3f and %0.3f", epValues[stats.Armor], epValues[stats.Stamina])
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"slices"
 	"strconv"
 	"time"
 
@@ -11,8 +12,19 @@ import (
 type Encounter struct {
 	Duration          time.Duration
 	DurationVariation time.Duration
-	Targets           []*Target
-	TargetUnits       []*Unit
+
+	// Targets which are currently spawned. Adds are added and removed as they spawn and die.
+	Targets     []*Target
+	TargetUnits []*Unit
+
+	// All targets in the encounter, including adds which aren't currently spawned.
+	AllTargets     []*Target
+	AllTargetUnits []*Unit
+	hasAdds        bool
+
+	// In health fights, the number of targets which have to die for the fight to end.
+	numRequiredTargets int
+	numRequiredAlive   int
 
 	ExecuteProportion_20 float64
 	ExecuteProportion_25 float64
@@ -24,6 +36,21 @@ type Encounter struct {
 	DamageTaken float64
 	// In health fight: set to true until we get something to base on
 	DurationIsEstimate bool
+	// In health fight: estimate the remaining duration from recent damage taken, rather than the fight average.
+	UseTimeToDieEstimate bool
+
+	// Tracks recent damage taken by all targets.
+	damageRate DamageRateEstimator
+
+	phases          []*EncounterPhase
+	currentPhase    int32
+	nextPhaseDamage float64
+
+	movement *encounterMovement
+	damage   *encounterDamage
+
+	aggroPullOutcome proto.AggroPullOutcome
+	virtualTankTps   float64
 
 	// Value to multiply by, for damage spells which are subject to the aoe cap.
 	aoeCapMultiplier float64
@@ -39,11 +66,33 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		ExecuteProportion_20: max(options.ExecuteProportion_20, 0),
 		ExecuteProportion_25: max(options.ExecuteProportion_25, 0),
 		ExecuteProportion_35: max(options.ExecuteProportion_35, 0),
-		Targets:              []*Target{},
+		UseTimeToDieEstimate: options.UseTimeToDieEstimate,
+		phases:               newEncounterPhases(options.Phases),
+		movement:             newEncounterMovement(options),
+		damage:               newEncounterDamage(options),
+		aggroPullOutcome:     options.AggroPullOutcome,
+		virtualTankTps:       max(0, options.VirtualTankTps),
 	}
+	useTargetHealth := options.UseHealth && options.UseTargetHealth
+	for targetIndex, targetOptions := range options.Targets {
+		target := NewTarget(targetOptions, int32(targetIndex))
+		target.required = !target.isAdd
+		if useTargetHealth && !target.isAdd && target.GetStat(stats.Health) > 0 {
+			// Each target tracks its own health, so that targets die individually.
+			target.EnableHealthBar()
+			target.required = !targetOptions.OptionalKill
+		}
+		encounter.AllTargets = append(encounter.AllTargets, target)
+		encounter.AllTargetUnits = append(encounter.AllTargetUnits, &target.Unit)
+		encounter.hasAdds = encounter.hasAdds || target.isAdd
+	}
+
 	// If UseHealth is set, we use the sum of targets health.
-	if options.UseHealth {
-		for _, t := range options.Targets {
+	if options.UseHealth && !useTargetHealth {
+		for i, t := range options.Targets {
+			if t.IsAdd && i > 0 {
+				continue
+			}
 			encounter.EndFightAtHealth += t.Stats[stats.Health]
 		}
 		if encounter.EndFightAtHealth == 0 {
@@ -51,19 +100,37 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		}
 	}
 
-	for targetIndex, targetOptions := range options.Targets {
-		target := NewTarget(targetOptions, int32(targetIndex))
-		encounter.Targets = append(encounter.Targets, target)
-		encounter.TargetUnits = append(encounter.TargetUnits, &target.Unit)
+	// With target health, we use the sum of the health of the targets which have to be killed.
+	if useTargetHealth {
+		for _, target := range encounter.AllTargets {
+			if target.required && target.HasHealthBar() {
+				encounter.EndFightAtHealth += target.MaxHealth()
+				encounter.numRequiredTargets++
+			}
+		}
+		if encounter.numRequiredTargets == 0 {
+			// Nothing to kill, so fall back to ending once any damage has been done to the main targets.
+			for _, target := range encounter.AllTargets {
+				target.required = !target.isAdd
+			}
+		}
+		if encounter.EndFightAtHealth == 0 {
+			encounter.EndFightAtHealth = 1 // default to something so we don't instantly end without anything.
+		}
 	}
-	if len(encounter.Targets) == 0 {
+	if len(encounter.AllTargets) == 0 {
 		// Add a dummy target. The only case where targets aren't specified is when
 		// computing character stats, and targets won't matter there.
 		target := NewTarget(&proto.Target{}, 0)
-		encounter.Targets = append(encounter.Targets, target)
-		encounter.TargetUnits = append(encounter.TargetUnits, &target.Unit)
+		encounter.AllTargets = append(encounter.AllTargets, target)
+		encounter.AllTargetUnits = append(encounter.AllTargetUnits, &target.Unit)
 	}
 
+	// All targets count as spawned until the first reset, so that spells and auras are
+	// registered for every target.
+	encounter.Targets = slices.Clone(encounter.AllTargets)
+	encounter.TargetUnits = slices.Clone(encounter.AllTargetUnits)
+
 	if encounter.EndFightAtHealth > 0 {
 		// Until we pre-sim set duration to 10m
 		encounter.Duration = time.Minute * 10
@@ -83,19 +150,20 @@ func (encounter *Encounter) updateAOECapMultiplier() {
 }
 
 func (encounter *Encounter) doneIteration(sim *Simulation) {
-	for i := range encounter.Targets {
-		target := encounter.Targets[i]
+	for i := range encounter.AllTargets {
+		target := encounter.AllTargets[i]
+		target.updateTankingTime(sim)
 		target.doneIteration(sim)
 	}
 }
 
 func (encounter *Encounter) GetMetricsProto() *proto.EncounterMetrics {
 	metrics := &proto.EncounterMetrics{
-		Targets: make([]*proto.UnitMetrics, len(encounter.Targets)),
+		Targets: make([]*proto.UnitMetrics, len(encounter.AllTargets)),
 	}
 
 	i := 0
-	for _, target := range encounter.Targets {
+	for _, target := range encounter.AllTargets {
 		metrics.Targets[i] = target.GetMetricsProto()
 		i++
 	}
@@ -108,6 +176,33 @@ type Target struct {
 	Unit
 
 	AI TargetAI
+
+	// Tracks recent damage taken by this target, for time-to-die estimates.
+	damageRate DamageRateEstimator
+
+	isAdd           bool
+	spawnTime       time.Duration
+	despawnAfter    time.Duration
+	respawnInterval time.Duration
+	despawnAction   *PendingAction
+	dying           bool
+	// Set during encounter phases which make the target untargetable. Separate from being
+	// spawned, so that targets which die or despawn during the phase stay out of the fight.
+	untargetable bool
+
+	// Whether damage taken by this target counts towards the encounter damage taken. In
+	// health fights, required targets with health have to be killed for the fight to end.
+	required bool
+
+	// Threat of each raid unit against this target, indexed by unit index.
+	threat          []float64
+	threatTargeting bool
+	tauntedUntil    time.Duration
+	// When the current tank started tanking this target, for tanking time metrics.
+	aggroSince time.Duration
+
+	// Improved Shadow Bolt charges on this target, if any Warlock can apply it.
+	isb *isbTracker
 }
 
 func NewTarget(options *proto.Target, targetIndex int32) *Target {
@@ -148,9 +243,23 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 	target.PseudoStats.InFrontOfTarget = true
 	target.PseudoStats.DamageSpread = options.DamageSpread
 
+	target.threatTargeting = options.ThreatTargeting
+
+	if options.IsAdd && targetIndex > 0 {
+		target.isAdd = true
+		target.spawnTime = DurationFromSeconds(max(0, options.SpawnTimeSeconds))
+		target.despawnAfter = DurationFromSeconds(max(0, options.DespawnAfterSeconds))
+		target.respawnInterval = DurationFromSeconds(max(0, options.RespawnIntervalSeconds))
+		if unitStats[stats.Health] > 0 {
+			target.EnableHealthBar()
+		}
+	}
+
 	preset := GetPresetTargetWithID(options.Id)
 	if preset != nil && preset.AI != nil {
 		target.AI = preset.AI()
+	} else if len(options.Abilities) > 0 {
+		target.AI = newAbilityTargetAI()
 	}
 
 	return target
@@ -159,17 +268,25 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 func (target *Target) Reset(sim *Simulation) {
 	target.Unit.reset(sim, nil)
 	target.SetGCDTimer(sim, 0)
+	target.damageRate.reset()
+	target.dying = false
+	target.untargetable = false
+	target.resetAggro(sim)
 	if target.AI != nil {
 		target.AI.Reset(sim)
 	}
 }
 
+// Returns the next spawned target after this one, wrapping around to the first target.
 func (target *Target) NextTarget() *Target {
-	nextIndex := target.Index + 1
-	if nextIndex >= target.Env.GetNumTargets() {
-		nextIndex = 0
+	targets := target.Env.Encounter.AllTargets
+	for i := 1; i < len(targets); i++ {
+		next := targets[(int(target.Index)+i)%len(targets)]
+		if next.IsSpawned() {
+			return next
+		}
 	}
-	return target.Env.GetTarget(nextIndex)
+	return target
 }
 
 func (target *Target) GetMetricsProto() *proto.UnitMetrics {
@@ -177,6 +294,9 @@ func (target *Target) GetMetricsProto() *proto.UnitMetrics {
 	metrics.Name = target.Label
 	metrics.UnitIndex = target.UnitIndex
 	metrics.Auras = target.auraTracker.GetMetricsProto()
+	if target.isb != nil {
+		metrics.ImprovedShadowBolt = target.isb.ToProto()
+	}
 	return metrics
 }
 
@@ -0,0 +1,206 @@
+package core
+
+import (
+	"slices"
+	"time"
+)
+
+// Whether this target is an add, which spawns and despawns during the fight.
+func (target *Target) IsAdd() bool {
+	return target.isAdd
+}
+
+// Whether this target is currently spawned, and not untargetable.
+func (target *Target) IsSpawned() bool {
+	return target.enabled && !target.untargetable
+}
+
+// Whether this target has died in the current iteration.
+func (target *Target) IsDead() bool {
+	return target.dying
+}
+
+func (encounter *Encounter) resetTargets(sim *Simulation) {
+	encounter.numRequiredAlive = encounter.numRequiredTargets
+	if !encounter.hasAdds && len(encounter.Targets) == len(encounter.AllTargets) {
+		return
+	}
+
+	for _, target := range encounter.AllTargets {
+		if !target.isAdd {
+			continue
+		}
+
+		target.despawnAction = nil
+		if target.spawnTime > 0 {
+			target.disable(sim)
+			target.scheduleSpawn(sim, target.spawnTime)
+			continue
+		}
+
+		// Spawned at the pull.
+		if target.despawnAfter > 0 {
+			target.scheduleDespawn(sim, target.despawnAfter)
+		}
+		if target.respawnInterval > 0 {
+			target.scheduleSpawn(sim, target.respawnInterval)
+		}
+	}
+
+	encounter.updateSpawnedTargets(sim)
+}
+
+func (target *Target) scheduleSpawn(sim *Simulation, spawnAt time.Duration) {
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     spawnAt,
+		Priority: ActionPriorityDOT,
+		OnAction: func(sim *Simulation) {
+			if target.respawnInterval > 0 {
+				target.scheduleSpawn(sim, sim.CurrentTime+target.respawnInterval)
+			}
+			target.Spawn(sim)
+		},
+	})
+}
+
+func (target *Target) scheduleDespawn(sim *Simulation, despawnAt time.Duration) {
+	target.despawnAction = StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     despawnAt,
+		Priority: ActionPriorityDOT,
+		OnAction: func(sim *Simulation) {
+			target.despawnAction = nil
+			target.Despawn(sim)
+		},
+	})
+}
+
+// Spawns the target with full health. Does nothing if it is already spawned.
+func (target *Target) Spawn(sim *Simulation) {
+	if target.enabled {
+		return
+	}
+
+	target.enabled = true
+	target.dying = false
+	target.healthBar.reset(sim)
+	target.damageRate.reset()
+	target.resetAggro(sim)
+
+	if sim.Log != nil {
+		target.Log(sim, "Spawned")
+	}
+
+	target.SetGCDTimer(sim, sim.CurrentTime)
+	target.AutoAttacks.EnableAutoSwing(sim)
+	if target.despawnAfter > 0 {
+		target.scheduleDespawn(sim, sim.CurrentTime+target.despawnAfter)
+	}
+
+	target.Env.Encounter.updateSpawnedTargets(sim)
+}
+
+// Removes the target from the fight, e.g. when it dies. Does nothing if it isn't spawned.
+func (target *Target) Despawn(sim *Simulation) {
+	if !target.enabled {
+		return
+	}
+
+	if sim.Log != nil {
+		target.Log(sim, "Despawned")
+	}
+
+	target.disable(sim)
+	target.Env.Encounter.updateSpawnedTargets(sim)
+}
+
+func (target *Target) disable(sim *Simulation) {
+	if target.despawnAction != nil {
+		target.despawnAction.Cancel(sim)
+		target.despawnAction = nil
+	}
+	if target.gcdAction != nil {
+		target.CancelGCDTimer(sim)
+	}
+	target.AutoAttacks.CancelAutoSwing(sim)
+	target.Hardcast = Hardcast{}
+	target.updateTankingTime(sim)
+	target.enabled = false
+
+	// Permanent auras such as raid debuffs are kept, so they are still up if the add respawns.
+restart:
+	for _, aura := range target.activeAuras {
+		if aura.Duration != NeverExpires {
+			aura.Deactivate(sim)
+			goto restart
+		}
+	}
+}
+
+// Targets with health die once it is exhausted, and returns the damage which was actually
+// taken. The despawn is delayed until the current hit has been fully processed, so that
+// its callbacks still see a spawned target.
+func (target *Target) takeDamage(sim *Simulation, damage float64) float64 {
+	if target.dying || damage <= 0 {
+		return 0
+	}
+
+	damage = min(damage, target.CurrentHealth())
+	target.RemoveHealth(sim, damage, target.DamageTakenHealthMetrics)
+	if target.CurrentHealth() > 0 {
+		return damage
+	}
+
+	target.dying = true
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     sim.CurrentTime,
+		Priority: ActionPriorityDOT,
+		OnAction: func(sim *Simulation) {
+			if sim.Log != nil {
+				target.Log(sim, "Died")
+			}
+
+			encounter := &target.Env.Encounter
+			if target.required && encounter.numRequiredAlive > 0 {
+				encounter.numRequiredAlive--
+				if encounter.numRequiredAlive == 0 {
+					// Every target which had to be killed is dead, so the fight is over. The
+					// target stays spawned, as actions at the current time still get to run.
+					sim.endOfCombatDuration = sim.CurrentTime
+					return
+				}
+			}
+			target.Despawn(sim)
+		},
+	})
+	return damage
+}
+
+// Updates the list of spawned targets, and everything which depends on the number of targets.
+func (encounter *Encounter) updateSpawnedTargets(sim *Simulation) {
+	// Always build new slices, as targets can spawn or die while other code is iterating the old ones.
+	encounter.Targets = slices.DeleteFunc(slices.Clone(encounter.AllTargets), func(target *Target) bool {
+		return !target.IsSpawned()
+	})
+	encounter.TargetUnits = make([]*Unit, len(encounter.Targets))
+	for i, target := range encounter.Targets {
+		encounter.TargetUnits[i] = &target.Unit
+	}
+	encounter.updateAOECapMultiplier()
+
+	// Players switch to the first remaining target when their target despawns or dies.
+	if len(encounter.TargetUnits) == 0 {
+		return
+	}
+	for _, unit := range sim.Raid.AllUnits {
+		if unit.CurrentTarget != nil && unit.CurrentTarget.Type == EnemyUnit && !encounter.AllTargets[unit.CurrentTarget.Index].IsSpawned() {
+			unit.CurrentTarget = encounter.TargetUnits[0]
+		}
+	}
+}
+
+// Limits a slice which was sized for the number of targets when a spell was registered, to
+// the number of currently spawned targets. AoEs use this so that they don't hit the same
+// target more than once while adds aren't up.
+func LimitToSpawnedTargets[T any](sim *Simulation, s []T) []T {
+	return s[:min(len(s), len(sim.Encounter.Targets))]
+}
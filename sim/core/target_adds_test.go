@@ -0,0 +1,125 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func newAddTestTarget(name string, health float64, spawnTime, despawnAfter, respawnInterval float64) *proto.Target {
+	add := newHealthTestTarget(name, health)
+	add.IsAdd = true
+	add.SpawnTimeSeconds = spawnTime
+	add.DespawnAfterSeconds = despawnAfter
+	add.RespawnIntervalSeconds = respawnInterval
+	return add
+}
+
+func TestAddSpawnLifecycle(t *testing.T) {
+	sim, _ := setupHealthSim(false, newHealthTestTarget("boss", 10000), newAddTestTarget("add", 1000, 10, 5, 20))
+	add := sim.Encounter.AllTargets[1]
+
+	if !add.IsAdd() || add.IsSpawned() || len(sim.Encounter.TargetUnits) != 1 {
+		t.Fatalf("Expected the add to not be spawned at the pull")
+	}
+
+	// Spawns at 10s, despawns after 5s and comes back every 20s.
+	for _, check := range []struct {
+		at      time.Duration
+		spawned bool
+	}{
+		{at: time.Millisecond * 9900, spawned: false},
+		{at: time.Second * 10, spawned: true},
+		{at: time.Millisecond * 14900, spawned: true},
+		{at: time.Second * 15, spawned: false},
+		{at: time.Second * 30, spawned: true},
+		{at: time.Second * 35, spawned: false},
+	} {
+		runFakeSimUntil(sim, check.at)
+		numTargets := TernaryInt(check.spawned, 2, 1)
+		if add.IsSpawned() != check.spawned || len(sim.Encounter.TargetUnits) != numTargets {
+			t.Fatalf("Expected the add to be spawned: %t at %s, with %d targets", check.spawned, check.at, numTargets)
+		}
+	}
+}
+
+func TestAddSpawnedAtPull(t *testing.T) {
+	sim, _ := setupHealthSim(false, newHealthTestTarget("boss", 10000), newAddTestTarget("add", 1000, 0, 5, 0))
+	add := sim.Encounter.AllTargets[1]
+
+	if !add.IsSpawned() || len(sim.Encounter.TargetUnits) != 2 {
+		t.Fatalf("Expected the add to be spawned at the pull")
+	}
+	runFakeSimUntil(sim, time.Second*5)
+	if add.IsSpawned() {
+		t.Fatalf("Expected the add to despawn after 5s")
+	}
+
+	// Without a respawn interval, the add doesn't come back.
+	runFakeSimUntil(sim, time.Second*60)
+	if add.IsSpawned() {
+		t.Fatalf("Expected the add to stay despawned")
+	}
+
+	// Every iteration starts over.
+	sim.Cleanup()
+	sim.Reset()
+	if !add.IsSpawned() || len(sim.Encounter.TargetUnits) != 2 {
+		t.Fatalf("Expected the add to be spawned again after a reset")
+	}
+}
+
+func TestAddDeathAndRespawn(t *testing.T) {
+	sim, fa := setupHealthSim(false, newHealthTestTarget("boss", 10000), newAddTestTarget("add", 1000, 1, 0, 10))
+	boss, add := sim.Encounter.AllTargets[0], sim.Encounter.AllTargets[1]
+
+	runFakeSimUntil(sim, time.Second)
+	fa.CurrentTarget = &add.Unit
+	fa.Nuke.CalcAndDealDamage(sim, &add.Unit, 2000, fa.Nuke.OutcomeAlwaysHit)
+	runFakeSimUntil(sim, sim.CurrentTime)
+
+	if !add.IsDead() || add.IsSpawned() || len(sim.Encounter.TargetUnits) != 1 {
+		t.Fatalf("Expected the add to die and despawn")
+	}
+	if fa.CurrentTarget != &boss.Unit {
+		t.Fatalf("Expected the player to switch back to the boss")
+	}
+	// Adds don't count towards the encounter health.
+	if sim.Encounter.DamageTaken != 0 {
+		t.Fatalf("Expected no encounter damage taken from killing an add, got %0.3f", sim.Encounter.DamageTaken)
+	}
+
+	runFakeSimUntil(sim, time.Second*11)
+	if add.IsDead() || !add.IsSpawned() || add.CurrentHealth() != 1000 {
+		t.Fatalf("Expected the add to respawn with full health")
+	}
+}
+
+func TestDespawnedTargetDamage(t *testing.T) {
+	sim, fa := setupHealthSim(false, newHealthTestTarget("boss", 10000), newAddTestTarget("add", 1000, 10, 0, 0))
+	add := sim.Encounter.AllTargets[1]
+
+	// Dots fall off when the add despawns.
+	runFakeSimUntil(sim, time.Second*10)
+	dot := fa.Spell.Dot(&add.Unit)
+	dot.Apply(sim)
+	add.Despawn(sim)
+	if dot.IsActive() || len(sim.Encounter.TargetUnits) != 1 {
+		t.Fatalf("Expected the add's dot to be removed when it despawns")
+	}
+
+	// Hits landing after the add is gone, e.g. from dots or travel time, are dropped.
+	result := fa.Nuke.CalcAndDealDamage(sim, &add.Unit, 500, fa.Nuke.OutcomeAlwaysHit)
+	if result.Damage != 0 || fa.Nuke.SpellMetrics[add.UnitIndex].TotalDamage != 0 || add.CurrentHealth() != 1000 {
+		t.Fatalf("Expected no damage to a despawned target, dealt %0.3f", result.Damage)
+	}
+	if add.threat[fa.UnitIndex] != 0 {
+		t.Fatalf("Expected no threat from hitting a despawned target")
+	}
+
+	// AoEs are limited to the spawned targets.
+	if targets := LimitToSpawnedTargets(sim, sim.Encounter.AllTargetUnits); len(targets) != 1 {
+		t.Fatalf("Expected AoEs to only hit the boss, got %d targets", len(targets))
+	}
+}
@@ -2,8 +2,10 @@ package core
 
 import (
 	"log"
+	"time"
 
 	"github.com/wowsims/sod/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
 )
 
 type TargetAI interface {
@@ -12,12 +14,34 @@ type TargetAI interface {
 	ExecuteCustomRotation(*Simulation)
 }
 
+// Optional interface for TargetAIs that publish encounter state, such as the time
+// until the next mechanic or the current boss phase, so player APLs can react to it.
+type TargetAIEncounterState interface {
+	// Returns the time until the named event next happens, or false if the AI has no such timer.
+	GetTimerRemaining(sim *Simulation, name string) (time.Duration, bool)
+
+	// Returns the current phase of the encounter, starting at 1.
+	GetPhase(sim *Simulation) int32
+}
+
+// Returns the published encounter state of the given target unit, or nil if its AI doesn't publish any.
+func (env *Environment) GetTargetAIEncounterState(unit *Unit) TargetAIEncounterState {
+	if unit == nil || unit.Type != EnemyUnit {
+		return nil
+	}
+	if state, ok := env.GetTarget(unit.Index).AI.(TargetAIEncounterState); ok {
+		return state
+	}
+	return nil
+}
+
 func (target *Target) initialize(config *proto.Target) {
 	if config == nil {
 		return
 	}
 
-	if target.CurrentTarget != nil {
+	// Targets which can pull aggro need auto attacks even if they start out untanked.
+	if target.CurrentTarget != nil || target.Env.Encounter.aggroPullOutcome == proto.AggroPullOutcome_AggroPullAttack {
 		if config.SwingSpeed > 0 {
 			aaOptions := AutoAttackOptions{
 				MainHand: Weapon{
@@ -58,6 +82,7 @@ func (target *Target) GetCharacter() *Character          { return nil }
 func (target *Target) Initialize()                       {}
 
 func (target *Target) ExecuteCustomRotation(sim *Simulation) {
+	target.updateAggro(sim)
 	if target.AI != nil {
 		target.AI.ExecuteCustomRotation(sim)
 	}
@@ -115,6 +140,34 @@ func GetPresetTargetWithID(id int32) *PresetTarget {
 	return nil
 }
 
+func GetPresetEncounterWithPath(path string) *proto.PresetEncounter {
+	for _, preset := range PresetEncounters {
+		if preset.Path == path {
+			return preset
+		}
+	}
+	return nil
+}
+
+// Returns the encounter for the given preset. Presets without settings keep the
+// settings of the base encounter, which may be nil.
+func PresetEncounterProto(preset *proto.PresetEncounter, base *proto.Encounter) *proto.Encounter {
+	var encounter *proto.Encounter
+	if preset.Settings != nil {
+		encounter = googleProto.Clone(preset.Settings).(*proto.Encounter)
+	} else if base != nil {
+		encounter = googleProto.Clone(base).(*proto.Encounter)
+	} else {
+		encounter = &proto.Encounter{}
+	}
+
+	encounter.Targets = make([]*proto.Target, len(preset.Targets))
+	for i, target := range preset.Targets {
+		encounter.Targets[i] = googleProto.Clone(target.Target).(*proto.Target)
+	}
+	return encounter
+}
+
 func AddPresetEncounter(name string, targetPaths []string) {
 	if len(targetPaths) == 0 {
 		log.Fatalf("Encounter must have targets!")
@@ -135,14 +188,43 @@ func AddPresetEncounter(name string, targetPaths []string) {
 		}
 	}
 
-	for _, preset := range PresetEncounters {
-		if preset.Path == path {
-			log.Fatalf("Preset Encounter with path %s already added!", path)
+	AddPresetEncounterProto(&proto.PresetEncounter{
+		Path:    path,
+		Targets: targetProtos,
+	})
+}
+
+// Adds a preset encounter with its own targets and settings, such as one made by a
+// fight style generator. The targets don't need to be registered as preset targets.
+func AddPresetEncounterWithSettings(path string, encounter *proto.Encounter) {
+	if len(encounter.Targets) == 0 {
+		log.Fatalf("Encounter must have targets!")
+	}
+
+	targetProtos := make([]*proto.PresetTarget, len(encounter.Targets))
+	for i, target := range encounter.Targets {
+		targetProtos[i] = &proto.PresetTarget{
+			Path:   path + "/" + target.Name,
+			Target: target,
 		}
 	}
 
-	PresetEncounters = append(PresetEncounters, &proto.PresetEncounter{
-		Path:    path,
-		Targets: targetProtos,
+	settings := googleProto.Clone(encounter).(*proto.Encounter)
+	settings.Targets = nil
+
+	AddPresetEncounterProto(&proto.PresetEncounter{
+		Path:     path,
+		Targets:  targetProtos,
+		Settings: settings,
 	})
 }
+
+// Adds a fully built preset encounter, such as one loaded from a preset pack.
+func AddPresetEncounterProto(newPreset *proto.PresetEncounter) {
+	for _, preset := range PresetEncounters {
+		if preset.Path == newPreset.Path {
+			log.Fatalf("Preset Encounter with path %s already added!", newPreset.Path)
+		}
+	}
+	PresetEncounters = append(PresetEncounters, newPreset)
+}
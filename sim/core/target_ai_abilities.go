@@ -0,0 +1,263 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// GCD used by targets between declarative abilities.
+const targetAbilityGCD = time.Millisecond * 1600
+
+// Generic TargetAI which interprets the declarative abilities in proto.Target,
+// so that bosses can be modeled without writing a custom AI.
+type abilityTargetAI struct {
+	Target    *Target
+	abilities []*targetAbility
+}
+
+type targetAbility struct {
+	config *proto.TargetAbility
+	spell  *Spell
+
+	// Debuff auras on each player, indexed by raid unit index.
+	debuffs AuraArray
+
+	initialDelay time.Duration
+	jitter       time.Duration
+
+	// When a use is skipped by chance_to_use, the ability is not rolled again until this time.
+	retryAt time.Duration
+}
+
+func newAbilityTargetAI() TargetAI {
+	return &abilityTargetAI{}
+}
+
+func (ai *abilityTargetAI) Initialize(target *Target, config *proto.Target) {
+	ai.Target = target
+
+	for _, abilityConfig := range config.Abilities {
+		ai.abilities = append(ai.abilities, ai.newTargetAbility(abilityConfig))
+	}
+}
+
+func (ai *abilityTargetAI) newTargetAbility(config *proto.TargetAbility) *targetAbility {
+	ability := &targetAbility{
+		config:       config,
+		initialDelay: DurationFromSeconds(config.InitialDelaySeconds),
+		jitter:       DurationFromSeconds(max(0, config.CooldownJitterSeconds)),
+	}
+
+	if debuffConfig := config.Debuff; debuffConfig != nil {
+		ability.debuffs = ai.Target.NewRaidAuraArray(func(unit *Unit) *Aura {
+			return makeTargetAbilityDebuff(unit, debuffConfig)
+		})
+	}
+
+	school := SpellSchoolFromProto(config.School)
+	defenseType := DefenseTypeMagic
+	procMask := ProcMaskSpellDamage
+	if school == SpellSchoolPhysical {
+		defenseType = DefenseTypeMelee
+		procMask = ProcMaskMeleeMHSpecial
+	}
+
+	flags := SpellFlagMeleeMetrics
+	interruptible := config.Interruptible && config.CastTimeSeconds > 0
+	if interruptible {
+		flags |= SpellFlagInterruptible
+	}
+
+	castConfig := CastConfig{
+		DefaultCast: Cast{
+			GCD:      targetAbilityGCD,
+			CastTime: DurationFromSeconds(config.CastTimeSeconds),
+		},
+	}
+	if config.CooldownSeconds > 0 {
+		castConfig.CD = Cooldown{
+			Timer:    ai.Target.NewTimer(),
+			Duration: DurationFromSeconds(config.CooldownSeconds),
+		}
+	}
+
+	ability.spell = ai.Target.RegisterSpell(SpellConfig{
+		ActionID:         ActionID{SpellID: config.SpellId},
+		SpellSchool:      school,
+		DefenseType:      defenseType,
+		ProcMask:         procMask,
+		Flags:            flags,
+		DamageMultiplier: 1,
+
+		Cast: castConfig,
+
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			if interruptible {
+				ai.Target.Metrics.MissedInterrupts++
+			}
+
+			for _, unit := range ai.abilityTargets(sim, config.Targeting, target) {
+				result := spell.CalcAndDealDamage(sim, unit, sim.Roll(config.MinDamage, max(config.MinDamage, config.MaxDamage)), ability.outcome(spell))
+				if interruptible {
+					ai.Target.Metrics.MissedInterruptDamage += result.Damage
+				}
+				if ability.debuffs != nil && result.Landed() {
+					debuff := ability.debuffs.Get(unit)
+					debuff.Activate(sim)
+					if debuff.MaxStacks > 0 {
+						debuff.AddStack(sim)
+					}
+					if swapStacks := config.Debuff.TankSwapStacks; swapStacks > 0 && unit == ai.Target.CurrentTarget && max(debuff.GetStacks(), 1) >= swapStacks {
+						ai.swapTanks(sim, ability.debuffs)
+					}
+				}
+			}
+		},
+	})
+
+	return ability
+}
+
+func (ability *targetAbility) outcome(spell *Spell) OutcomeApplier {
+	if spell.SpellSchool == SpellSchoolPhysical {
+		return spell.OutcomeEnemyMeleeWhite
+	}
+	return spell.OutcomeMagicHit
+}
+
+func makeTargetAbilityDebuff(unit *Unit, config *proto.TargetAbilityDebuff) *Aura {
+	label := "Target Ability Debuff " + ActionID{SpellID: config.SpellId}.String()
+	if aura := unit.GetAura(label); aura != nil {
+		// Shared by multiple abilities applying the same debuff.
+		return aura
+	}
+
+	duration := NeverExpires
+	if config.DurationSeconds > 0 {
+		duration = DurationFromSeconds(config.DurationSeconds)
+	}
+
+	aura := unit.RegisterAura(Aura{
+		Label:      label,
+		ActionID:   ActionID{SpellID: config.SpellId},
+		DispelType: DispelTypeFromProto(config.DispelType),
+		Duration:   duration,
+		MaxStacks:  max(0, config.MaxStacks),
+	})
+
+	if aura.DispelType != DispelType_None {
+		aura.ApplyOnExpire(func(aura *Aura, sim *Simulation) {
+			aura.Unit.Metrics.DispellableDebuffTime += sim.CurrentTime - aura.StartedAt()
+		})
+	}
+
+	damageTakenPerStack := config.DamageTakenPerStack
+	if damageTakenPerStack == 0 {
+		return aura
+	}
+
+	if aura.MaxStacks == 0 {
+		aura.ApplyOnGain(func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier *= 1 + damageTakenPerStack
+		}).ApplyOnExpire(func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier /= 1 + damageTakenPerStack
+		})
+	} else {
+		aura.ApplyOnStacksChange(func(aura *Aura, sim *Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier /= 1 + damageTakenPerStack*float64(oldStacks)
+			aura.Unit.PseudoStats.DamageTakenMultiplier *= 1 + damageTakenPerStack*float64(newStacks)
+		})
+	}
+	return aura
+}
+
+// Returns the units hit by an ability. The spell target is the tank, or the first
+// player in non-tank sims so that abilities still work.
+func (ai *abilityTargetAI) abilityTargets(sim *Simulation, targeting proto.TargetAbilityTargeting, tank *Unit) []*Unit {
+	players := ai.Target.Env.Raid.AllPlayerUnits
+	switch targeting {
+	case proto.TargetAbilityTargeting_AbilityTargetRandomPlayer:
+		idx := int(sim.RandomFloat("Target Ability Targeting") * float64(len(players)))
+		return []*Unit{players[min(idx, len(players)-1)]}
+	case proto.TargetAbilityTargeting_AbilityTargetAllPlayers:
+		return players
+	}
+	return []*Unit{tank}
+}
+
+// Another tank taunts the target off its current tank. The tank with the fewest stacks of
+// the debuff is chosen, and nothing happens if there is only one tank.
+func (ai *abilityTargetAI) swapTanks(sim *Simulation, debuffs AuraArray) {
+	var newTank *Unit
+	minStacks := int32(0)
+	for _, tank := range ai.Target.Env.Raid.Tanks {
+		if tank == ai.Target.CurrentTarget || !tank.IsEnabled() {
+			continue
+		}
+		stacks := int32(0)
+		if debuff := debuffs.Get(tank); debuff.IsActive() {
+			stacks = debuff.GetStacks()
+		}
+		if newTank == nil || stacks < minStacks {
+			newTank = tank
+			minStacks = stacks
+		}
+	}
+	if newTank != nil {
+		newTank.TauntTarget(sim, &ai.Target.Unit)
+	}
+}
+
+func (ai *abilityTargetAI) Reset(sim *Simulation) {
+	for _, ability := range ai.abilities {
+		ability.retryAt = 0
+	}
+}
+
+func (ability *targetAbility) canUse(sim *Simulation, target *Target) bool {
+	if sim.CurrentTime < ability.initialDelay || sim.CurrentTime < ability.retryAt {
+		return false
+	}
+
+	// Targets with their own health use it, the others use the encounter's remaining health or duration.
+	healthPercent := sim.GetRemainingDurationPercent() * 100
+	if target.HasHealthBar() {
+		healthPercent = target.CurrentHealthPercent() * 100
+	}
+	maxHealthPercent := ability.config.MaxHealthPercent
+	if maxHealthPercent == 0 {
+		maxHealthPercent = 100
+	}
+	if healthPercent < ability.config.MinHealthPercent || healthPercent > maxHealthPercent {
+		return false
+	}
+
+	return ability.spell.IsReady(sim)
+}
+
+func (ai *abilityTargetAI) ExecuteCustomRotation(sim *Simulation) {
+	target := ai.Target.CurrentTarget
+	if target == nil {
+		// For individual non tank sims we still want abilities to work
+		target = &ai.Target.Env.Raid.Parties[0].Players[0].GetCharacter().Unit
+	}
+
+	for _, ability := range ai.abilities {
+		if !ability.canUse(sim, ai.Target) {
+			continue
+		}
+
+		if chance := ability.config.ChanceToUse; chance > 0 && !sim.Proc(chance, "Target Ability Chance") {
+			ability.retryAt = sim.CurrentTime + targetAbilityGCD
+			continue
+		}
+
+		if ability.spell.Cast(sim, target) {
+			if ability.jitter > 0 && ability.spell.CD.Timer != nil {
+				ability.spell.CD.Set(ability.spell.CD.ReadyAt() + DurationFromSeconds(sim.RandomFloat("Target Ability Jitter")*ability.jitter.Seconds()))
+			}
+			return
+		}
+	}
+}
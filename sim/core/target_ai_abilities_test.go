@@ -0,0 +1,263 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Sets up a target which only uses the given abilities, with the first numTanks players tanking.
+func setupTargetAbilitySim(numPlayers int, numTanks int, abilities ...*proto.TargetAbility) (*Simulation, *Target, []*FakeAgent) {
+	rsr := fakeRaidSimRequest(&proto.Encounter{
+		Targets:  []*proto.Target{newFakeSimTarget(abilities...)},
+		Duration: 100,
+	}, numPlayers)
+	addFakeSimTanks(rsr, numTanks)
+
+	sim, agents := setupFakeSimWithRequest(rsr)
+	return sim, sim.Encounter.Targets[0], agents
+}
+
+func targetAbilitySpell(target *Target, spellID int32) *Spell {
+	return target.GetSpell(ActionID{SpellID: spellID})
+}
+
+func targetAbilityCasts(spell *Spell) int32 {
+	casts := int32(0)
+	for _, metrics := range spell.SpellMetrics {
+		casts += metrics.Casts
+	}
+	return casts
+}
+
+// Includes misses and partial resists, so that the count doesn't depend on hit rolls.
+func targetAbilityHits(spell *Spell, unit *Unit) int32 {
+	metrics := spell.SpellMetrics[unit.UnitIndex]
+	return metrics.Hits + metrics.ResistedHits + metrics.Misses
+}
+
+func TestTargetAbilityCooldowns(t *testing.T) {
+	sim, target, _ := setupTargetAbilitySim(1, 0, &proto.TargetAbility{
+		SpellId:             1,
+		School:              proto.SpellSchool_SpellSchoolShadow,
+		MinDamage:           100,
+		CooldownSeconds:     10,
+		InitialDelaySeconds: 5,
+	})
+	spell := targetAbilitySpell(target, 1)
+
+	runFakeSimUntil(sim, time.Millisecond*4900)
+	if targetAbilityCasts(spell) != 0 {
+		t.Fatalf("Expected no casts before the initial delay")
+	}
+
+	// Used at 5s, then every 10s.
+	runFakeSimUntil(sim, time.Second*36)
+	if casts := targetAbilityCasts(spell); casts != 4 {
+		t.Fatalf("Expected 4 casts by 36s, got %d", casts)
+	}
+	if readyAt := spell.CD.ReadyAt(); readyAt != time.Second*45 {
+		t.Fatalf("Expected the next cast at 45s, got %s", readyAt)
+	}
+}
+
+func TestTargetAbilityCooldownJitter(t *testing.T) {
+	sim, target, _ := setupTargetAbilitySim(1, 0, &proto.TargetAbility{
+		SpellId:               1,
+		School:                proto.SpellSchool_SpellSchoolShadow,
+		MinDamage:             100,
+		CooldownSeconds:       10,
+		CooldownJitterSeconds: 5,
+	})
+	spell := targetAbilitySpell(target, 1)
+
+	var lastCast time.Duration
+	for casts := int32(1); casts <= 5; casts++ {
+		for targetAbilityCasts(spell) < casts {
+			runFakeSimUntil(sim, sim.CurrentTime+time.Millisecond*100)
+		}
+		if casts > 1 {
+			if interval := sim.CurrentTime - lastCast; interval < time.Second*10 || interval > time.Millisecond*15100 {
+				t.Fatalf("Expected 10-15s between casts, got %s", interval)
+			}
+		}
+		lastCast = sim.CurrentTime
+	}
+}
+
+func TestTargetAbilityUseConditions(t *testing.T) {
+	// Only used in the last half of the fight.
+	sim, target, _ := setupTargetAbilitySim(1, 0, &proto.TargetAbility{
+		SpellId:          1,
+		School:           proto.SpellSchool_SpellSchoolShadow,
+		MinDamage:        100,
+		CooldownSeconds:  10,
+		MaxHealthPercent: 50,
+	})
+	spell := targetAbilitySpell(target, 1)
+	runFakeSimUntil(sim, time.Millisecond*49900)
+	if targetAbilityCasts(spell) != 0 {
+		t.Fatalf("Expected no casts above 50%% health")
+	}
+	runFakeSimUntil(sim, time.Second*51)
+	if targetAbilityCasts(spell) != 1 {
+		t.Fatalf("Expected a cast at 50%% health")
+	}
+
+	// Targets with their own health use it rather than the encounter's.
+	withHealth := newHealthTestTarget("a", 1000)
+	withHealth.Abilities = []*proto.TargetAbility{{
+		SpellId:          1,
+		School:           proto.SpellSchool_SpellSchoolShadow,
+		MinDamage:        100,
+		CooldownSeconds:  10,
+		MaxHealthPercent: 50,
+	}}
+	sim, fa := setupHealthSim(true, withHealth, newHealthTestTarget("b", 3000))
+	target = sim.Encounter.AllTargets[0]
+	spell = targetAbilitySpell(target, 1)
+	fa.Nuke.CalcAndDealDamage(sim, &target.Unit, 400, fa.Nuke.OutcomeAlwaysHit)
+	runFakeSimUntil(sim, time.Second*5)
+	if targetAbilityCasts(spell) != 0 {
+		t.Fatalf("Expected no casts at 60%% target health")
+	}
+	// The encounter is still at 85% health.
+	fa.Nuke.CalcAndDealDamage(sim, &target.Unit, 200, fa.Nuke.OutcomeAlwaysHit)
+	runFakeSimUntil(sim, time.Second*10)
+	if targetAbilityCasts(spell) != 1 {
+		t.Fatalf("Expected a cast at 40%% target health")
+	}
+
+	// Skipped uses are only rolled again after a GCD.
+	sim, target, _ = setupTargetAbilitySim(1, 0, &proto.TargetAbility{
+		SpellId:     1,
+		School:      proto.SpellSchool_SpellSchoolShadow,
+		MinDamage:   100,
+		ChanceToUse: 0.5,
+	})
+	spell = targetAbilitySpell(target, 1)
+	runFakeSimUntil(sim, time.Second*99)
+	maxCasts := int32(99/targetAbilityGCD.Seconds()) + 1
+	if casts := targetAbilityCasts(spell); casts < maxCasts/4 || casts > maxCasts*3/4 {
+		t.Fatalf("Expected about half of %d possible casts, got %d", maxCasts, casts)
+	}
+}
+
+func TestTargetAbilityTargeting(t *testing.T) {
+	ability := func(spellID int32, targeting proto.TargetAbilityTargeting) *proto.TargetAbility {
+		return &proto.TargetAbility{
+			SpellId:         spellID,
+			School:          proto.SpellSchool_SpellSchoolShadow,
+			MinDamage:       100,
+			CooldownSeconds: 2,
+			Targeting:       targeting,
+		}
+	}
+	sim, target, agents := setupTargetAbilitySim(3, 0,
+		ability(1, proto.TargetAbilityTargeting_AbilityTargetTank),
+		ability(2, proto.TargetAbilityTargeting_AbilityTargetRandomPlayer),
+		ability(3, proto.TargetAbilityTargeting_AbilityTargetAllPlayers),
+	)
+	runFakeSimUntil(sim, time.Second*99)
+
+	tankSpell := targetAbilitySpell(target, 1)
+	randomSpell := targetAbilitySpell(target, 2)
+	allSpell := targetAbilitySpell(target, 3)
+
+	// Without tanks, tank abilities hit the first player.
+	if targetAbilityHits(tankSpell, &agents[0].Unit) != targetAbilityCasts(tankSpell) {
+		t.Fatalf("Expected every tank ability cast to hit the first player")
+	}
+
+	randomCasts := targetAbilityCasts(randomSpell)
+	totalRandomHits := int32(0)
+	for _, fa := range agents {
+		if fa != agents[0] && targetAbilityHits(tankSpell, &fa.Unit) != 0 {
+			t.Fatalf("Expected tank abilities to only hit the first player")
+		}
+		if hits := targetAbilityHits(allSpell, &fa.Unit); hits != targetAbilityCasts(allSpell) {
+			t.Fatalf("Expected every cast to hit %s, got %d hits", fa.Label, hits)
+		}
+		hits := targetAbilityHits(randomSpell, &fa.Unit)
+		if hits < randomCasts/6 {
+			t.Fatalf("Expected %s to be hit by about a third of %d random casts, got %d", fa.Label, randomCasts, hits)
+		}
+		totalRandomHits += hits
+	}
+	if totalRandomHits != randomCasts {
+		t.Fatalf("Expected each random cast to hit a single player")
+	}
+}
+
+func TestTargetAbilityDebuffStacks(t *testing.T) {
+	sim, target, agents := setupTargetAbilitySim(2, 2, &proto.TargetAbility{
+		SpellId:         1,
+		School:          proto.SpellSchool_SpellSchoolShadow,
+		MinDamage:       100,
+		CooldownSeconds: 2,
+		Debuff: &proto.TargetAbilityDebuff{
+			SpellId:             2,
+			DurationSeconds:     30,
+			MaxStacks:           5,
+			DamageTakenPerStack: 0.1,
+			TankSwapStacks:      3,
+		},
+	})
+	mainTank, offTank := &agents[0].Unit, &agents[1].Unit
+	debuff := mainTank.GetAura("Target Ability Debuff " + ActionID{SpellID: 2}.String())
+
+	for stacks := int32(1); stacks <= 2; stacks++ {
+		for debuff.GetStacks() < stacks {
+			runFakeSimUntil(sim, sim.CurrentTime+time.Millisecond*100)
+		}
+		if !WithinToleranceFloat64(1+0.1*float64(stacks), mainTank.PseudoStats.DamageTakenMultiplier, 0.0001) {
+			t.Fatalf("Expected %d stacks to increase damage taken by %d%%, got a multiplier of %0.3f", stacks, stacks*10, mainTank.PseudoStats.DamageTakenMultiplier)
+		}
+		if target.CurrentTarget != mainTank {
+			t.Fatalf("Expected the main tank to keep the target at %d stacks", stacks)
+		}
+	}
+
+	// At 3 stacks, the off tank without any stacks taunts.
+	for debuff.GetStacks() < 3 {
+		runFakeSimUntil(sim, sim.CurrentTime+time.Millisecond*100)
+	}
+	if target.CurrentTarget != offTank {
+		t.Fatalf("Expected the off tank to taunt at 3 stacks")
+	}
+
+	// Once the off tank has 3 stacks too, the main tank taunts back.
+	offTankDebuff := offTank.GetAura(debuff.Label)
+	for offTankDebuff.GetStacks() < 3 {
+		runFakeSimUntil(sim, sim.CurrentTime+time.Millisecond*100)
+	}
+	if target.CurrentTarget != mainTank || debuff.GetStacks() != 3 {
+		t.Fatalf("Expected the main tank to taunt back at 3 stacks")
+	}
+}
+
+func TestTargetAbilityDebuffExpires(t *testing.T) {
+	sim, _, agents := setupTargetAbilitySim(1, 1, &proto.TargetAbility{
+		SpellId:         1,
+		School:          proto.SpellSchool_SpellSchoolShadow,
+		MinDamage:       100,
+		CooldownSeconds: 60,
+		Debuff: &proto.TargetAbilityDebuff{
+			SpellId:             2,
+			DurationSeconds:     10,
+			DamageTakenPerStack: 0.1,
+		},
+	})
+	tank := &agents[0].Unit
+	debuff := tank.GetAura("Target Ability Debuff " + ActionID{SpellID: 2}.String())
+
+	runFakeSimUntil(sim, time.Second)
+	if !debuff.IsActive() || !WithinToleranceFloat64(1.1, tank.PseudoStats.DamageTakenMultiplier, 0.0001) {
+		t.Fatalf("Expected the debuff to increase damage taken by 10%%")
+	}
+	runFakeSimUntil(sim, time.Second*11)
+	if debuff.IsActive() || tank.PseudoStats.DamageTakenMultiplier != 1 {
+		t.Fatalf("Expected the debuff to expire after 10s")
+	}
+}
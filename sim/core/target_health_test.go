@@ -0,0 +1,140 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func newHealthTestTarget(name string, health float64) *proto.Target {
+	unitStats := stats.Stats{}
+	unitStats[stats.Health] = health
+	return &proto.Target{
+		Name:    name,
+		Level:   63,
+		MobType: proto.MobType_MobTypeDemon,
+		Stats:   unitStats[:],
+	}
+}
+
+func setupHealthSim(useTargetHealth bool, targets ...*proto.Target) (*Simulation, *FakeAgent) {
+	sim := SetupFakeSimWithEncounter(&proto.Encounter{
+		Targets:         targets,
+		Duration:        180,
+		UseHealth:       true,
+		UseTargetHealth: useTargetHealth,
+	})
+	return sim, sim.Raid.Parties[0].Players[0].(*FakeAgent)
+}
+
+func TestLegacyHealthFight(t *testing.T) {
+	sim, fa := setupHealthSim(false, newHealthTestTarget("a", 1000), newHealthTestTarget("b", 3000))
+	target := sim.Encounter.AllTargets[0]
+
+	if sim.Encounter.EndFightAtHealth != 4000 {
+		t.Fatalf("Expected the fight to end at 4000 damage, got %0.3f", sim.Encounter.EndFightAtHealth)
+	}
+
+	// Targets share a single health pool, so damage isn't capped and nothing dies.
+	fa.Nuke.CalcAndDealDamage(sim, &target.Unit, 2000, fa.Nuke.OutcomeAlwaysHit)
+	runFakeSimUntil(sim, sim.CurrentTime)
+
+	if damage := fa.Nuke.SpellMetrics[target.UnitIndex].TotalDamage; sim.Encounter.DamageTaken != damage || damage <= 1000 {
+		t.Fatalf("Expected uncapped damage taken, got %0.3f (dealt %0.3f)", sim.Encounter.DamageTaken, damage)
+	}
+	if target.HasHealthBar() || target.IsDead() || len(sim.Encounter.TargetUnits) != 2 {
+		t.Fatalf("Expected targets without individual health")
+	}
+}
+
+func TestTargetDeath(t *testing.T) {
+	sim, fa := setupHealthSim(true, newHealthTestTarget("a", 1000), newHealthTestTarget("b", 3000))
+	targetA, targetB := sim.Encounter.AllTargets[0], sim.Encounter.AllTargets[1]
+
+	if sim.Encounter.EndFightAtHealth != 4000 {
+		t.Fatalf("Expected the fight to end at 4000 damage, got %0.3f", sim.Encounter.EndFightAtHealth)
+	}
+
+	fa.Nuke.CalcAndDealDamage(sim, &targetA.Unit, 2000, fa.Nuke.OutcomeAlwaysHit)
+	if sim.Encounter.DamageTaken != 1000 || targetA.CurrentHealth() != 0 {
+		t.Fatalf("Expected damage to be capped at the target's health, got %0.3f", sim.Encounter.DamageTaken)
+	}
+	if !targetA.IsDead() || !targetA.IsSpawned() {
+		t.Fatalf("Expected the target to be dying, but still spawned until the hit is processed")
+	}
+
+	// Damage to a dying target is dropped.
+	fa.Nuke.CalcAndDealDamage(sim, &targetA.Unit, 2000, fa.Nuke.OutcomeAlwaysHit)
+	if sim.Encounter.DamageTaken != 1000 {
+		t.Fatalf("Expected no damage taken by a dying target, got %0.3f", sim.Encounter.DamageTaken)
+	}
+
+	runFakeSimUntil(sim, sim.CurrentTime)
+	if targetA.IsSpawned() || len(sim.Encounter.TargetUnits) != 1 || sim.Encounter.TargetUnits[0] != &targetB.Unit {
+		t.Fatalf("Expected the dead target to despawn")
+	}
+	if fa.CurrentTarget != &targetB.Unit {
+		t.Fatalf("Expected the player to switch to the remaining target")
+	}
+	if sim.endOfCombatDuration != NeverExpires {
+		t.Fatalf("Expected the fight to continue while a required target is alive")
+	}
+}
+
+func TestOptionalKill(t *testing.T) {
+	optionalTarget := newHealthTestTarget("b", 3000)
+	optionalTarget.OptionalKill = true
+	sim, fa := setupHealthSim(true, newHealthTestTarget("a", 1000), optionalTarget)
+	targetA, targetB := sim.Encounter.AllTargets[0], sim.Encounter.AllTargets[1]
+
+	if sim.Encounter.EndFightAtHealth != 1000 {
+		t.Fatalf("Expected the fight to end at 1000 damage, got %0.3f", sim.Encounter.EndFightAtHealth)
+	}
+
+	// Damage to optional targets doesn't count towards the fight's progress.
+	fa.Nuke.CalcAndDealDamage(sim, &targetB.Unit, 500, fa.Nuke.OutcomeAlwaysHit)
+	if sim.Encounter.DamageTaken != 0 || targetB.CurrentHealth() >= 3000 {
+		t.Fatalf("Expected optional target damage to only count towards its own health")
+	}
+
+	fa.Nuke.CalcAndDealDamage(sim, &targetA.Unit, 2000, fa.Nuke.OutcomeAlwaysHit)
+	runFakeSimUntil(sim, sim.CurrentTime)
+	if sim.endOfCombatDuration != sim.CurrentTime {
+		t.Fatalf("Expected the fight to end once every required target is dead")
+	}
+}
+
+func TestTargetDeathEndsFight(t *testing.T) {
+	sim, fa := setupHealthSim(true, newHealthTestTarget("a", 1000))
+	target := sim.Encounter.AllTargets[0]
+
+	runFakeSimUntil(sim, sim.CurrentTime)
+	fa.Nuke.CalcAndDealDamage(sim, &target.Unit, 2000, fa.Nuke.OutcomeAlwaysHit)
+
+	// Actions at the time of death still run, and can still find a target.
+	ranAtDeath := false
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     sim.CurrentTime,
+		Priority: ActionPriorityLow,
+		OnAction: func(sim *Simulation) {
+			ranAtDeath = sim.Encounter.TargetUnits[0] == &target.Unit
+		},
+	})
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     sim.CurrentTime + 1,
+		Priority: ActionPriorityLow,
+		OnAction: func(sim *Simulation) {
+			t.Fatalf("Expected no actions after the last required target died")
+		},
+	})
+
+	for !sim.Step() {
+	}
+	if !ranAtDeath {
+		t.Fatalf("Expected actions at the time of death to run with the target still spawned")
+	}
+	if sim.endOfCombatDuration != sim.CurrentTime || len(sim.Encounter.TargetUnits) != 1 {
+		t.Fatalf("Expected the fight to end without despawning the last target")
+	}
+}
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+// Approximate stats of a normal (non-elite) NPC at a given level. Values for levels
+// in between are interpolated.
+type targetTemplateLevelStats struct {
+	level       int32
+	health      float64
+	armor       float64
+	attackPower float64
+	// Minimum damage of a melee swing.
+	minBaseDamage float64
+}
+
+var targetTemplateLevels = []targetTemplateLevelStats{
+	{level: 1, health: 42, armor: 20, attackPower: 10, minBaseDamage: 2},
+	{level: 10, health: 200, armor: 200, attackPower: 120, minBaseDamage: 10},
+	{level: 20, health: 600, armor: 700, attackPower: 250, minBaseDamage: 25},
+	{level: 27, health: 950, armor: 1104, attackPower: 345, minBaseDamage: 35},
+	{level: 30, health: 1200, armor: 1200, attackPower: 380, minBaseDamage: 45},
+	{level: 40, health: 1900, armor: 1900, attackPower: 510, minBaseDamage: 70},
+	{level: 42, health: 2050, armor: 2053, attackPower: 535, minBaseDamage: 75},
+	{level: 50, health: 2800, armor: 2800, attackPower: 640, minBaseDamage: 100},
+	{level: 52, health: 3000, armor: 3137, attackPower: 665, minBaseDamage: 105},
+	{level: 60, health: 4000, armor: 3400, attackPower: 770, minBaseDamage: 140},
+	{level: 63, health: 4500, armor: 3731, attackPower: 805, minBaseDamage: 160},
+}
+
+// Health and damage multipliers for each classification, relative to a normal NPC.
+var targetTemplateClassifications = map[proto.NPCClassification]struct {
+	health float64
+	damage float64
+}{
+	proto.NPCClassification_NPCClassificationNormal: {health: 1, damage: 1},
+	proto.NPCClassification_NPCClassificationElite:  {health: 3, damage: 2.5},
+	proto.NPCClassification_NPCClassificationBoss:   {health: 30, damage: 20},
+}
+
+func targetTemplateStatsForLevel(level int32) targetTemplateLevelStats {
+	levels := targetTemplateLevels
+	if level <= levels[0].level {
+		return levels[0]
+	}
+	for i := 1; i < len(levels); i++ {
+		if level <= levels[i].level {
+			low, high := levels[i-1], levels[i]
+			t := float64(level-low.level) / float64(high.level-low.level)
+			lerp := func(a, b float64) float64 { return a + (b-a)*t }
+			return targetTemplateLevelStats{
+				level:         level,
+				health:        lerp(low.health, high.health),
+				armor:         lerp(low.armor, high.armor),
+				attackPower:   lerp(low.attackPower, high.attackPower),
+				minBaseDamage: lerp(low.minBaseDamage, high.minBaseDamage),
+			}
+		}
+	}
+	last := levels[len(levels)-1]
+	last.level = level
+	return last
+}
+
+// Builds a target with level-appropriate stats for an NPC. Resistances are left at 0
+// unless overridden, as the sim already applies level based partial resists.
+func NewTargetTemplate(request *proto.TargetTemplateRequest) (*proto.Target, error) {
+	level := request.Level
+	name := request.Name
+	mobType := request.MobType
+	if preset := GetPresetTargetWithID(request.NpcId); preset != nil && request.NpcId != 0 {
+		if level == 0 {
+			level = preset.Config.Level
+		}
+		if name == "" {
+			name = preset.Config.Name
+		}
+		if mobType == proto.MobType_MobTypeUnknown {
+			mobType = preset.Config.MobType
+		}
+	}
+	if level <= 0 {
+		return nil, fmt.Errorf("no level given for NPC %d", request.NpcId)
+	}
+	if name == "" {
+		name = fmt.Sprintf("NPC %d", request.NpcId)
+	}
+
+	classification, ok := targetTemplateClassifications[request.Classification]
+	if !ok {
+		return nil, fmt.Errorf("unknown classification %s", request.Classification)
+	}
+	levelStats := targetTemplateStatsForLevel(level)
+
+	unitStats := stats.Stats{
+		stats.Health:      levelStats.health * classification.health,
+		stats.Armor:       levelStats.armor,
+		stats.AttackPower: levelStats.attackPower,
+	}
+
+	target := &proto.Target{
+		Id:            request.NpcId,
+		Name:          name,
+		Level:         level,
+		MobType:       mobType,
+		SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+		SwingSpeed:    2,
+		MinBaseDamage: levelStats.minBaseDamage * classification.damage,
+		DamageSpread:  0.3333,
+		ParryHaste:    request.Classification == proto.NPCClassification_NPCClassificationBoss,
+	}
+
+	if overrides := request.Overrides; overrides != nil {
+		if len(overrides.Stats) > int(stats.Len) {
+			return nil, fmt.Errorf("too many stat overrides, %d given but there are only %d", len(overrides.Stats), stats.Len)
+		}
+		for i, value := range overrides.Stats {
+			if value != 0 {
+				unitStats[i] = value
+			}
+		}
+		if overrides.SwingSpeed > 0 {
+			target.SwingSpeed = overrides.SwingSpeed
+		}
+		if overrides.MinBaseDamage > 0 {
+			target.MinBaseDamage = overrides.MinBaseDamage
+		}
+		if overrides.DamageSpread > 0 {
+			target.DamageSpread = overrides.DamageSpread
+		}
+		if overrides.SpellSchool != proto.SpellSchool_SpellSchoolPhysical {
+			target.SpellSchool = overrides.SpellSchool
+		}
+		target.DualWield = overrides.DualWield
+	}
+
+	target.Stats = unitStats.ToFloatArray()
+	return target, nil
+}
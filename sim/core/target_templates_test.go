@@ -0,0 +1,147 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func TestNewTargetTemplate(t *testing.T) {
+	const presetID = 990001
+	if GetPresetTargetWithID(presetID) == nil {
+		AddPresetTarget(&PresetTarget{
+			PathPrefix: "Target Template Test",
+			Config:     &proto.Target{Id: presetID, Name: "Preset", Level: 62, MobType: proto.MobType_MobTypeUndead},
+		})
+	}
+
+	type expected struct {
+		name          string
+		level         int32
+		health        float64
+		armor         float64
+		attackPower   float64
+		minBaseDamage float64
+	}
+	tests := []struct {
+		name     string
+		request  *proto.TargetTemplateRequest
+		expected expected
+		// Substring of the expected error, or "" if the template is valid.
+		err string
+	}{
+		{
+			name:     "table level",
+			request:  &proto.TargetTemplateRequest{NpcId: 1, Name: "Normal", Level: 60},
+			expected: expected{name: "Normal", level: 60, health: 4000, armor: 3400, attackPower: 770, minBaseDamage: 140},
+		},
+		{
+			// 3/8 of the way from level 52 to 60.
+			name:     "interpolated level",
+			request:  &proto.TargetTemplateRequest{NpcId: 1, Level: 55},
+			expected: expected{name: "NPC 1", level: 55, health: 3375, armor: 3235.625, attackPower: 704.375, minBaseDamage: 118.125},
+		},
+		{
+			name:     "lowest level",
+			request:  &proto.TargetTemplateRequest{Level: 1, Name: "Critter"},
+			expected: expected{name: "Critter", level: 1, health: 42, armor: 20, attackPower: 10, minBaseDamage: 2},
+		},
+		{
+			name:     "above the highest level",
+			request:  &proto.TargetTemplateRequest{Level: 70},
+			expected: expected{name: "NPC 0", level: 70, health: 4500, armor: 3731, attackPower: 805, minBaseDamage: 160},
+		},
+		{
+			name:     "elite",
+			request:  &proto.TargetTemplateRequest{Level: 60, Classification: proto.NPCClassification_NPCClassificationElite},
+			expected: expected{name: "NPC 0", level: 60, health: 12000, armor: 3400, attackPower: 770, minBaseDamage: 350},
+		},
+		{
+			name:     "boss",
+			request:  &proto.TargetTemplateRequest{Level: 63, Classification: proto.NPCClassification_NPCClassificationBoss},
+			expected: expected{name: "NPC 0", level: 63, health: 135000, armor: 3731, attackPower: 805, minBaseDamage: 3200},
+		},
+		{
+			name:     "preset level and name",
+			request:  &proto.TargetTemplateRequest{NpcId: presetID},
+			expected: expected{name: "Preset", level: 62, health: 4500 - 500.0/3, armor: 3731 - 331.0/3, attackPower: 805 - 35.0/3, minBaseDamage: 160 - 20.0/3},
+		},
+		{
+			name:     "preset with a given level",
+			request:  &proto.TargetTemplateRequest{NpcId: presetID, Level: 60, Name: "Other"},
+			expected: expected{name: "Other", level: 60, health: 4000, armor: 3400, attackPower: 770, minBaseDamage: 140},
+		},
+		{name: "missing level", request: &proto.TargetTemplateRequest{NpcId: 1}, err: "no level given for NPC 1"},
+		{name: "negative level", request: &proto.TargetTemplateRequest{Level: -1}, err: "no level given"},
+		{name: "unknown classification", request: &proto.TargetTemplateRequest{Level: 60, Classification: 7}, err: "unknown classification"},
+		{
+			name:    "too many stat overrides",
+			request: &proto.TargetTemplateRequest{Level: 60, Overrides: &proto.TargetTemplateOverrides{Stats: make([]float64, stats.Len+1)}},
+			err:     "too many stat overrides",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target, err := NewTargetTemplate(test.request)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("Expected an error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if target.Name != test.expected.name || target.Level != test.expected.level {
+				t.Fatalf("Expected %s at level %d, got %s at level %d", test.expected.name, test.expected.level, target.Name, target.Level)
+			}
+			for _, check := range []struct {
+				stat     string
+				expected float64
+				actual   float64
+			}{
+				{stat: "health", expected: test.expected.health, actual: target.Stats[stats.Health]},
+				{stat: "armor", expected: test.expected.armor, actual: target.Stats[stats.Armor]},
+				{stat: "attack power", expected: test.expected.attackPower, actual: target.Stats[stats.AttackPower]},
+				{stat: "min base damage", expected: test.expected.minBaseDamage, actual: target.MinBaseDamage},
+			} {
+				if !WithinToleranceFloat64(check.expected, check.actual, 0.001) {
+					t.Fatalf("Expected %0.3f %s, got %0.3f", check.expected, check.stat, check.actual)
+				}
+			}
+		})
+	}
+}
+
+func TestNewTargetTemplateOverrides(t *testing.T) {
+	overrideStats := stats.Stats{stats.Armor: 5000}
+	target, err := NewTargetTemplate(&proto.TargetTemplateRequest{
+		Level:          63,
+		MobType:        proto.MobType_MobTypeDragonkin,
+		Classification: proto.NPCClassification_NPCClassificationBoss,
+		Overrides: &proto.TargetTemplateOverrides{
+			Stats:       overrideStats[:],
+			SwingSpeed:  1.5,
+			SpellSchool: proto.SpellSchool_SpellSchoolFire,
+			DualWield:   true,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only non-zero stats are replaced.
+	if target.Stats[stats.Armor] != 5000 || target.Stats[stats.Health] != 135000 {
+		t.Fatalf("Expected the armor override with the generated health, got %0.3f armor and %0.3f health", target.Stats[stats.Armor], target.Stats[stats.Health])
+	}
+	if target.SwingSpeed != 1.5 || target.MinBaseDamage != 3200 || target.DamageSpread != 0.3333 {
+		t.Fatalf("Expected only the swing speed to be overridden")
+	}
+	if target.SpellSchool != proto.SpellSchool_SpellSchoolFire || !target.DualWield || target.MobType != proto.MobType_MobTypeDragonkin || !target.ParryHaste {
+		t.Fatalf("Expected the school, dual wield and mob type to be set, and bosses to parry haste")
+	}
+}
@@ -0,0 +1,213 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// How long a taunt forces the target to attack the taunting unit.
+const TauntDuration = time.Second * 3
+
+// To pull aggro, a unit has to exceed the threat of the target's current victim by
+// these ratios, depending on whether it's in melee range.
+const (
+	meleeAggroThreatRatio  = 1.1
+	rangedAggroThreatRatio = 1.3
+)
+
+func (unit *Unit) aggroThreatRatio() float64 {
+	if unit.DistanceFromTarget <= encounterMovementMeleeRange {
+		return meleeAggroThreatRatio
+	}
+	return rangedAggroThreatRatio
+}
+
+// Adds threat against an enemy target to the unit's entry in the target's threat table.
+func (unit *Unit) addThreat(sim *Simulation, target *Unit, threat float64) {
+	if threat == 0 || unit.Type == EnemyUnit || target.Type != EnemyUnit {
+		return
+	}
+	enemy := unit.Env.GetTarget(target.Index)
+	enemy.threat[unit.UnitIndex] += threat
+	enemy.checkAggroPull(sim, unit)
+}
+
+// Healing threat is split evenly between all spawned targets.
+func (unit *Unit) addHealingThreat(sim *Simulation, threat float64) {
+	targets := unit.Env.Encounter.TargetUnits
+	for _, target := range targets {
+		unit.addThreat(sim, target, threat/float64(len(targets)))
+	}
+}
+
+// The virtual tank pulls this long before the raid starts attacking.
+const virtualTankPullLead = time.Second * 2
+
+// Threat of the virtual tank, which tanks targets that no raid member tanks.
+func (target *Target) virtualTankThreat(sim *Simulation) float64 {
+	return target.Env.Encounter.virtualTankTps * max(0, sim.CurrentTime+virtualTankPullLead).Seconds()
+}
+
+// Returns the threat of the target's tank, other than the given unit. This is the current
+// victim, or the initial tank if the unit has pulled aggro. Returns false for targets
+// which have no tank.
+func (target *Target) tankThreat(sim *Simulation, unit *Unit) (float64, bool) {
+	if target.CurrentTarget != nil && target.CurrentTarget != unit {
+		return target.threat[target.CurrentTarget.UnitIndex], true
+	}
+	if target.defaultTarget != nil {
+		if target.defaultTarget == unit {
+			return 0, false
+		}
+		return target.threat[target.defaultTarget.UnitIndex], true
+	}
+	if target.Env.Encounter.virtualTankTps > 0 {
+		return target.virtualTankThreat(sim), true
+	}
+	return 0, false
+}
+
+// Returns the unit's threat as a percent of the tank's threat, or 0 if the target has no
+// tank besides the unit.
+func (target *Target) ThreatPercentOfTank(sim *Simulation, unit *Unit) float64 {
+	tankThreat, ok := target.tankThreat(sim, unit)
+	threat := target.threat[unit.UnitIndex]
+	if !ok || threat == 0 {
+		return 0
+	}
+	if tankThreat <= 0 {
+		return math.Inf(1)
+	}
+	return threat / tankThreat * 100
+}
+
+// Applies the encounter's aggro pull outcome if a player who isn't a tank overtook the tank.
+func (target *Target) checkAggroPull(sim *Simulation, unit *Unit) {
+	outcome := target.Env.Encounter.aggroPullOutcome
+	if outcome == proto.AggroPullOutcome_AggroPullIgnored || unit.Type != PlayerUnit || unit == target.CurrentTarget ||
+		!target.IsSpawned() || unit.Metrics.AggroWipe || slices.Contains(target.Env.Raid.Tanks, unit) {
+		return
+	}
+
+	tankThreat, ok := target.tankThreat(sim, unit)
+	if !ok || target.threat[unit.UnitIndex] <= tankThreat*unit.aggroThreatRatio() {
+		return
+	}
+
+	unit.Metrics.AggroPulls++
+	switch outcome {
+	case proto.AggroPullOutcome_AggroPullWipe:
+		if sim.Log != nil {
+			unit.Log(sim, "Pulled aggro from %s, wiping the raid (Threat: %0.3f, Tank: %0.3f)", target.Label, target.threat[unit.UnitIndex], tankThreat)
+		}
+		unit.Metrics.AggroWipe = true
+	case proto.AggroPullOutcome_AggroPullThreatDrop:
+		if sim.Log != nil {
+			unit.Log(sim, "Pulled aggro from %s, dropping threat (Threat: %0.3f, Tank: %0.3f)", target.Label, target.threat[unit.UnitIndex], tankThreat)
+		}
+		target.threat[unit.UnitIndex] = 0
+	case proto.AggroPullOutcome_AggroPullAttack:
+		target.setAggro(sim, unit)
+	}
+}
+
+// Makes the enemy target attack this unit for the taunt duration. The unit's threat is
+// raised to the highest on the target's threat table, so with threat targeting it keeps
+// aggro afterwards until someone overtakes it. Targets which aren't tanked can't be taunted.
+func (unit *Unit) TauntTarget(sim *Simulation, target *Unit) {
+	if target.Type != EnemyUnit || unit.Type == EnemyUnit {
+		return
+	}
+	unit.Env.GetTarget(target.Index).taunt(sim, unit)
+}
+
+func (target *Target) taunt(sim *Simulation, unit *Unit) {
+	if target.CurrentTarget == nil || !target.IsSpawned() {
+		return
+	}
+
+	target.threat[unit.UnitIndex] = max(target.threat[unit.UnitIndex], target.highestThreat())
+	target.tauntedUntil = sim.CurrentTime + TauntDuration
+	target.setAggro(sim, unit)
+}
+
+func (target *Target) highestThreat() float64 {
+	highest := 0.0
+	for _, unit := range target.Env.Raid.AllUnits {
+		highest = max(highest, target.threat[unit.UnitIndex])
+	}
+	return highest
+}
+
+// Called before the target attacks, so that it re-targets the unit with the most threat.
+// Targets which can be pulled by attacking players always use threat to pick their victim.
+func (target *Target) updateAggro(sim *Simulation) {
+	usesThreat := target.threatTargeting || target.Env.Encounter.aggroPullOutcome == proto.AggroPullOutcome_AggroPullAttack
+	if !usesThreat || target.CurrentTarget == nil || sim.CurrentTime < target.tauntedUntil {
+		return
+	}
+
+	currentThreat := target.threat[target.CurrentTarget.UnitIndex]
+	newTarget := target.CurrentTarget
+	maxThreat := 0.0
+	for _, unit := range target.Env.Raid.AllUnits {
+		threat := target.threat[unit.UnitIndex]
+		if unit.IsEnabled() && threat > currentThreat*unit.aggroThreatRatio() && threat > maxThreat {
+			newTarget = unit
+			maxThreat = threat
+		}
+	}
+
+	// The virtual tank takes back targets pulled off it the same way.
+	if target.defaultTarget == nil && target.Env.Encounter.virtualTankTps > 0 {
+		if threat := target.virtualTankThreat(sim); threat > currentThreat*meleeAggroThreatRatio && threat > maxThreat {
+			newTarget = nil
+		}
+	}
+
+	target.setAggro(sim, newTarget)
+}
+
+func (target *Target) setAggro(sim *Simulation, unit *Unit) {
+	if unit == target.CurrentTarget {
+		return
+	}
+
+	if sim.Log != nil {
+		if unit == nil {
+			target.Log(sim, "Now attacking the virtual tank (Threat: %0.3f)", target.virtualTankThreat(sim))
+		} else {
+			target.Log(sim, "Now attacking %s (Threat: %0.3f)", unit.Label, target.threat[unit.UnitIndex])
+		}
+	}
+	target.updateTankingTime(sim)
+	wasAttacking := target.CurrentTarget != nil
+	target.CurrentTarget = unit
+	target.Metrics.AggroSwaps++
+
+	// Only targets pulled off the virtual tank start or stop attacking.
+	if unit == nil {
+		target.AutoAttacks.CancelAutoSwing(sim)
+	} else if !wasAttacking {
+		target.AutoAttacks.EnableAutoSwing(sim)
+	}
+}
+
+// Credits the current tank with the time it spent tanking the target so far.
+func (target *Target) updateTankingTime(sim *Simulation) {
+	if target.CurrentTarget != nil && target.IsSpawned() {
+		target.CurrentTarget.Metrics.TankingTime += sim.CurrentTime - target.aggroSince
+	}
+	target.aggroSince = sim.CurrentTime
+}
+
+// Clears the threat table and returns the target to its initial tank, e.g. when an add respawns.
+func (target *Target) resetAggro(sim *Simulation) {
+	clear(target.threat)
+	target.tauntedUntil = 0
+	target.CurrentTarget = target.defaultTarget
+	target.aggroSince = sim.CurrentTime
+}
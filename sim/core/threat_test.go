@@ -0,0 +1,280 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Sets up the given targets against numPlayers players, with the first numTanks players tanking.
+func setupThreatSim(encounter *proto.Encounter, numPlayers int, numTanks int) (*Simulation, []*FakeAgent) {
+	if encounter.Duration == 0 {
+		encounter.Duration = 100
+	}
+	rsr := fakeRaidSimRequest(encounter, numPlayers)
+	addFakeSimTanks(rsr, numTanks)
+	return setupFakeSimWithRequest(rsr)
+}
+
+func newThreatTestTarget(name string) *proto.Target {
+	return &proto.Target{Name: name, Level: 63, MobType: proto.MobType_MobTypeDemon, SwingSpeed: 2, MinBaseDamage: 100}
+}
+
+func TestThreatTables(t *testing.T) {
+	sim, agents := setupThreatSim(&proto.Encounter{
+		Targets: []*proto.Target{newThreatTestTarget("boss"), newThreatTestTarget("other")},
+	}, 2, 1)
+	boss, other := sim.Encounter.Targets[0], sim.Encounter.Targets[1]
+	tank, dps := &agents[0].Unit, &agents[1].Unit
+
+	dps.addThreat(sim, &boss.Unit, 100)
+	if boss.threat[dps.UnitIndex] != 100 || other.threat[dps.UnitIndex] != 0 {
+		t.Fatalf("Expected threat to be added only to the target's threat table")
+	}
+	// Only enemy targets have threat tables.
+	dps.addThreat(sim, tank, 100)
+	if boss.threat[tank.UnitIndex] != 0 {
+		t.Fatalf("Expected no threat from targeting a player")
+	}
+
+	// Healing threat is split between the targets.
+	dps.addHealingThreat(sim, 100)
+	if boss.threat[dps.UnitIndex] != 150 || other.threat[dps.UnitIndex] != 50 {
+		t.Fatalf("Expected healing threat to be split evenly, got %0.3f and %0.3f", boss.threat[dps.UnitIndex], other.threat[dps.UnitIndex])
+	}
+
+	if percent := boss.ThreatPercentOfTank(sim, dps); !math.IsInf(percent, 1) {
+		t.Fatalf("Expected infinite threat against a tank without threat, got %0.3f", percent)
+	}
+	tank.addThreat(sim, &boss.Unit, 300)
+	if percent := boss.ThreatPercentOfTank(sim, dps); percent != 50 {
+		t.Fatalf("Expected 50%% of the tank's threat, got %0.3f", percent)
+	}
+	if percent := boss.ThreatPercentOfTank(sim, tank); percent != 0 {
+		t.Fatalf("Expected no percent for the tank itself, got %0.3f", percent)
+	}
+
+	// Threat is cleared every iteration.
+	sim.Cleanup()
+	sim.Reset()
+	if boss.threat[dps.UnitIndex] != 0 || boss.threat[tank.UnitIndex] != 0 {
+		t.Fatalf("Expected the threat table to be cleared after a reset")
+	}
+}
+
+func TestAggroPullOutcomes(t *testing.T) {
+	tests := []struct {
+		outcome proto.AggroPullOutcome
+		// Whether the player is at range, so has to exceed 130% of the tank's threat instead of 110%.
+		ranged       bool
+		expectPull   bool
+		expectWipe   bool
+		expectThreat float64
+		expectAggro  bool
+	}{
+		{outcome: proto.AggroPullOutcome_AggroPullIgnored, expectThreat: 1150},
+		{outcome: proto.AggroPullOutcome_AggroPullWipe, expectPull: true, expectWipe: true, expectThreat: 1150},
+		{outcome: proto.AggroPullOutcome_AggroPullThreatDrop, expectPull: true, expectThreat: 0},
+		{outcome: proto.AggroPullOutcome_AggroPullAttack, expectPull: true, expectThreat: 1150, expectAggro: true},
+		{outcome: proto.AggroPullOutcome_AggroPullAttack, ranged: true, expectThreat: 1150},
+	}
+
+	for _, test := range tests {
+		name := test.outcome.String()
+		if test.ranged {
+			name += " ranged"
+		}
+		t.Run(name, func(t *testing.T) {
+			sim, agents := setupThreatSim(&proto.Encounter{
+				Targets:          []*proto.Target{newThreatTestTarget("boss")},
+				AggroPullOutcome: test.outcome,
+			}, 2, 1)
+			boss := sim.Encounter.Targets[0]
+			tank, dps := &agents[0].Unit, &agents[1].Unit
+			if test.ranged {
+				dps.DistanceFromTarget = 30
+			}
+
+			tank.addThreat(sim, &boss.Unit, 1000)
+			dps.addThreat(sim, &boss.Unit, 1050)
+			if dps.Metrics.AggroPulls != 0 {
+				t.Fatalf("Expected no aggro pull below 110%% of the tank's threat")
+			}
+			dps.addThreat(sim, &boss.Unit, 100)
+
+			if pulled := dps.Metrics.AggroPulls == 1; pulled != test.expectPull {
+				t.Fatalf("Expected an aggro pull: %t, got %d pulls", test.expectPull, dps.Metrics.AggroPulls)
+			}
+			if dps.Metrics.AggroWipe != test.expectWipe {
+				t.Fatalf("Expected a wipe: %t", test.expectWipe)
+			}
+			if boss.threat[dps.UnitIndex] != test.expectThreat {
+				t.Fatalf("Expected %0.3f threat, got %0.3f", test.expectThreat, boss.threat[dps.UnitIndex])
+			}
+			if attacked := boss.CurrentTarget == dps; attacked != test.expectAggro {
+				t.Fatalf("Expected the boss to attack the player: %t", test.expectAggro)
+			}
+		})
+	}
+}
+
+func TestAggroPullTanksExempt(t *testing.T) {
+	sim, agents := setupThreatSim(&proto.Encounter{
+		Targets:          []*proto.Target{newThreatTestTarget("boss")},
+		AggroPullOutcome: proto.AggroPullOutcome_AggroPullWipe,
+	}, 2, 2)
+	boss := sim.Encounter.Targets[0]
+	mainTank, offTank := &agents[0].Unit, &agents[1].Unit
+
+	mainTank.addThreat(sim, &boss.Unit, 1000)
+	offTank.addThreat(sim, &boss.Unit, 5000)
+	if offTank.Metrics.AggroPulls != 0 || offTank.Metrics.AggroWipe || boss.CurrentTarget != mainTank {
+		t.Fatalf("Expected tanks to be allowed to overtake the current tank")
+	}
+}
+
+func TestThreatTargeting(t *testing.T) {
+	target := newThreatTestTarget("boss")
+	target.ThreatTargeting = true
+	sim, agents := setupThreatSim(&proto.Encounter{Targets: []*proto.Target{target}}, 2, 1)
+	boss := sim.Encounter.Targets[0]
+	tank, dps := &agents[0].Unit, &agents[1].Unit
+
+	tank.addThreat(sim, &boss.Unit, 1000)
+	dps.addThreat(sim, &boss.Unit, 1100)
+	boss.updateAggro(sim)
+	if boss.CurrentTarget != tank || boss.Metrics.AggroSwaps != 0 {
+		t.Fatalf("Expected the tank to keep aggro at 110%% of its threat")
+	}
+
+	dps.addThreat(sim, &boss.Unit, 1)
+	boss.updateAggro(sim)
+	if boss.CurrentTarget != dps || boss.Metrics.AggroSwaps != 1 {
+		t.Fatalf("Expected the boss to attack the player above 110%% of the tank's threat")
+	}
+	// Pulling aggro by threat isn't an aggro pull outcome.
+	if dps.Metrics.AggroPulls != 0 {
+		t.Fatalf("Expected no aggro pulls without an aggro pull outcome")
+	}
+
+	// Without threat targeting, the target stays on its tank.
+	sim, agents = setupThreatSim(&proto.Encounter{Targets: []*proto.Target{newThreatTestTarget("boss")}}, 2, 1)
+	boss = sim.Encounter.Targets[0]
+	agents[1].addThreat(sim, &boss.Unit, 1000)
+	boss.updateAggro(sim)
+	if boss.CurrentTarget != &agents[0].Unit {
+		t.Fatalf("Expected the boss to ignore threat without threat targeting")
+	}
+}
+
+func TestTaunt(t *testing.T) {
+	target := newThreatTestTarget("boss")
+	target.ThreatTargeting = true
+	sim, agents := setupThreatSim(&proto.Encounter{Targets: []*proto.Target{target}}, 2, 2)
+	boss := sim.Encounter.Targets[0]
+	mainTank, offTank := &agents[0].Unit, &agents[1].Unit
+
+	mainTank.addThreat(sim, &boss.Unit, 1000)
+	offTank.addThreat(sim, &boss.Unit, 100)
+	runFakeSimUntil(sim, time.Second*10)
+
+	offTank.TauntTarget(sim, &boss.Unit)
+	if boss.CurrentTarget != offTank || boss.threat[offTank.UnitIndex] != 1000 || boss.Metrics.AggroSwaps != 1 {
+		t.Fatalf("Expected the taunt to match the highest threat and swap to the off tank")
+	}
+	if mainTank.Metrics.TankingTime != time.Second*10 {
+		t.Fatalf("Expected the main tank to be credited with 10s of tanking, got %s", mainTank.Metrics.TankingTime)
+	}
+
+	// The taunt holds for 3s even if the main tank overtakes the off tank.
+	mainTank.addThreat(sim, &boss.Unit, 1000)
+	runFakeSimUntil(sim, time.Millisecond*12900)
+	boss.updateAggro(sim)
+	if boss.CurrentTarget != offTank {
+		t.Fatalf("Expected the off tank to keep aggro during the taunt")
+	}
+	runFakeSimUntil(sim, time.Second*13)
+	boss.updateAggro(sim)
+	if boss.CurrentTarget != mainTank || boss.Metrics.AggroSwaps != 2 {
+		t.Fatalf("Expected the main tank to regain aggro by threat after the taunt")
+	}
+	if offTank.Metrics.TankingTime != time.Second*3 {
+		t.Fatalf("Expected the off tank to be credited with 3s of tanking, got %s", offTank.Metrics.TankingTime)
+	}
+
+	// Targets without a tank can't be taunted.
+	sim, agents = setupThreatSim(&proto.Encounter{Targets: []*proto.Target{newThreatTestTarget("boss")}}, 1, 0)
+	boss = sim.Encounter.Targets[0]
+	agents[0].TauntTarget(sim, &boss.Unit)
+	if boss.CurrentTarget != nil || boss.threat[agents[0].UnitIndex] != 0 {
+		t.Fatalf("Expected the taunt to have no effect on an untanked target")
+	}
+}
+
+func TestTargetAbilityTankSwap(t *testing.T) {
+	// With 3 tanks, the tank with the fewest stacks taunts.
+	sim, target, agents := setupTargetAbilitySim(3, 3, &proto.TargetAbility{
+		SpellId:         1,
+		School:          proto.SpellSchool_SpellSchoolShadow,
+		MinDamage:       100,
+		CooldownSeconds: 2,
+		Debuff: &proto.TargetAbilityDebuff{
+			SpellId:         2,
+			DurationSeconds: 60,
+			MaxStacks:       5,
+			TankSwapStacks:  2,
+		},
+	})
+	label := "Target Ability Debuff " + ActionID{SpellID: 2}.String()
+	waitForStacks := func(tank *Unit, stacks int32) {
+		for tank.GetAura(label).GetStacks() < stacks {
+			runFakeSimUntil(sim, sim.CurrentTime+time.Millisecond*100)
+		}
+	}
+
+	waitForStacks(&agents[0].Unit, 2)
+	if target.CurrentTarget != &agents[1].Unit {
+		t.Fatalf("Expected the first tank without stacks to taunt")
+	}
+	waitForStacks(&agents[1].Unit, 2)
+	if target.CurrentTarget != &agents[2].Unit {
+		t.Fatalf("Expected the tank without stacks to taunt rather than the main tank")
+	}
+	waitForStacks(&agents[2].Unit, 2)
+	if target.CurrentTarget != &agents[0].Unit {
+		t.Fatalf("Expected the main tank to taunt back once every tank has stacks")
+	}
+}
+
+func TestVirtualTank(t *testing.T) {
+	sim, agents := setupThreatSim(&proto.Encounter{
+		Targets:          []*proto.Target{newThreatTestTarget("boss")},
+		AggroPullOutcome: proto.AggroPullOutcome_AggroPullAttack,
+		VirtualTankTps:   100,
+	}, 1, 0)
+	boss := sim.Encounter.Targets[0]
+	dps := &agents[0].Unit
+
+	// The virtual tank pulls 2s early.
+	dps.addThreat(sim, &boss.Unit, 150)
+	if percent := boss.ThreatPercentOfTank(sim, dps); percent != 75 {
+		t.Fatalf("Expected 75%% of the virtual tank's threat, got %0.3f", percent)
+	}
+
+	dps.addThreat(sim, &boss.Unit, 100)
+	if boss.CurrentTarget != dps || dps.Metrics.AggroPulls != 1 {
+		t.Fatalf("Expected the player to pull aggro from the virtual tank")
+	}
+
+	// The virtual tank takes the target back once it has 110% of the player's threat.
+	runFakeSimUntil(sim, time.Second)
+	boss.updateAggro(sim)
+	if boss.CurrentTarget != nil {
+		t.Fatalf("Expected the virtual tank to regain aggro")
+	}
+	if dps.Metrics.TankingTime != time.Second {
+		t.Fatalf("Expected the player to be credited with 1s of tanking, got %s", dps.Metrics.TankingTime)
+	}
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+const (
+	// Damage taken is sampled at this interval, and only samples within the window are used for estimates.
+	timeToDieSampleInterval = time.Millisecond * 500
+	timeToDieWindow         = time.Second * 10
+	timeToDieNumSamples     = int(timeToDieWindow / timeToDieSampleInterval)
+
+	// Estimates are unreliable with too little data, so fall back to the fight duration until then.
+	timeToDieMinSampleTime = time.Second * 3
+)
+
+type damageSample struct {
+	at     time.Duration
+	damage float64 // Cumulative damage taken at this time.
+}
+
+// Estimates how quickly a unit (or the whole encounter) is taking damage, using a
+// linear fit of cumulative damage taken over a rolling window.
+type DamageRateEstimator struct {
+	damageTaken float64
+
+	samples    [timeToDieNumSamples]damageSample
+	numSamples int
+	nextSample int
+}
+
+func (dre *DamageRateEstimator) reset() {
+	dre.damageTaken = 0
+	dre.numSamples = 0
+	dre.nextSample = 0
+}
+
+func (dre *DamageRateEstimator) addDamage(sim *Simulation, damage float64) {
+	if damage <= 0 {
+		return
+	}
+
+	if dre.numSamples == 0 || sim.CurrentTime >= dre.lastSample().at+timeToDieSampleInterval {
+		// Store the total from before this hit so the sample lines up with its timestamp.
+		dre.samples[dre.nextSample] = damageSample{at: sim.CurrentTime, damage: dre.damageTaken}
+		dre.nextSample = (dre.nextSample + 1) % timeToDieNumSamples
+		dre.numSamples = min(dre.numSamples+1, timeToDieNumSamples)
+	}
+
+	dre.damageTaken += damage
+}
+
+func (dre *DamageRateEstimator) lastSample() damageSample {
+	return dre.samples[(dre.nextSample+timeToDieNumSamples-1)%timeToDieNumSamples]
+}
+
+// Total damage taken so far in this iteration.
+func (dre *DamageRateEstimator) DamageTaken() float64 {
+	return dre.damageTaken
+}
+
+// Returns the estimated damage taken per second, or 0 if there is not enough data yet.
+func (dre *DamageRateEstimator) DamageRate(sim *Simulation) float64 {
+	if dre.numSamples == 0 {
+		return 0
+	}
+
+	var n, sumX, sumY, sumXX, sumXY float64
+	addPoint := func(at time.Duration, damage float64) {
+		x := (at - sim.CurrentTime).Seconds()
+		n++
+		sumX += x
+		sumY += damage
+		sumXX += x * x
+		sumXY += x * damage
+	}
+
+	oldest := sim.CurrentTime
+	for i := 0; i < dre.numSamples; i++ {
+		sample := dre.samples[i]
+		if sample.at < sim.CurrentTime-timeToDieWindow {
+			continue
+		}
+		addPoint(sample.at, sample.damage)
+		oldest = min(oldest, sample.at)
+	}
+	addPoint(sim.CurrentTime, dre.damageTaken)
+
+	if sim.CurrentTime-oldest < timeToDieMinSampleTime {
+		return 0
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if denominator <= 0 {
+		return 0
+	}
+	return max(0, (n*sumXY-sumX*sumY)/denominator)
+}
+
+// Returns the estimated time until the given amount of additional damage has been taken.
+// Falls back to the remaining fight duration if there is no usable estimate.
+func (dre *DamageRateEstimator) TimeUntilDamage(sim *Simulation, damage float64) time.Duration {
+	if damage <= 0 {
+		return 0
+	}
+	rate := dre.DamageRate(sim)
+	if rate <= 0 {
+		return sim.GetRemainingDuration()
+	}
+	return DurationFromSeconds(damage / rate)
+}
+
+// Returns the estimated time until this target dies, capped at the remaining fight duration.
+func (target *Target) TimeToDie(sim *Simulation) time.Duration {
+	return target.TimeToPercent(sim, 0)
+}
+
+// Returns the estimated time until this target reaches the given health percent (0-1),
+// capped at the remaining fight duration. Targets without health, or in fights which
+// don't end on health, use the fight duration.
+func (target *Target) TimeToPercent(sim *Simulation, percent float64) time.Duration {
+	maxHealth := target.GetStat(stats.Health)
+	if maxHealth <= 0 || sim.Encounter.EndFightAtHealth <= 0 {
+		return sim.GetRemainingDuration()
+	}
+
+	damageUntilPercent := maxHealth*(1-percent) - target.damageRate.DamageTaken()
+	return min(target.damageRate.TimeUntilDamage(sim, damageUntilPercent), sim.GetRemainingDuration())
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestDamageRateEstimator(t *testing.T) {
+	sim := &Simulation{}
+	dre := &DamageRateEstimator{}
+
+	// 1000 damage every 250ms is 4000 dps.
+	for sim.CurrentTime = 0; sim.CurrentTime < time.Second*2; sim.CurrentTime += time.Millisecond * 250 {
+		dre.addDamage(sim, 1000)
+	}
+	if rate := dre.DamageRate(sim); rate != 0 {
+		t.Fatalf("Expected no estimate before the minimum sample time, got %0.3f", rate)
+	}
+
+	for ; sim.CurrentTime < time.Second*30; sim.CurrentTime += time.Millisecond * 250 {
+		dre.addDamage(sim, 1000)
+	}
+	if rate := dre.DamageRate(sim); math.Abs(rate-4000) > 200 {
+		t.Fatalf("Expected rate near 4000, got %0.3f", rate)
+	}
+
+	// Damage slows down, the rolling window should follow.
+	for ; sim.CurrentTime < time.Second*60; sim.CurrentTime += time.Second {
+		dre.addDamage(sim, 1000)
+	}
+	if rate := dre.DamageRate(sim); math.Abs(rate-1000) > 100 {
+		t.Fatalf("Expected rate near 1000, got %0.3f", rate)
+	}
+
+	if ttd := dre.TimeUntilDamage(sim, 10000); ttd < time.Second*9 || ttd > time.Second*11 {
+		t.Fatalf("Expected time until damage near 10s, got %s", ttd)
+	}
+}
+
+func TestMajorCooldownMinTargetTimeToDie(t *testing.T) {
+	mcdm := &majorCooldownManager{character: &Character{Unit: Unit{Env: &Environment{}}}}
+	buff := &Aura{Duration: time.Second * 30}
+	mcdm.AddMajorCooldown(MajorCooldown{Spell: &Spell{RelatedSelfBuff: buff}, Type: CooldownTypeDPS})
+	mcdm.AddMajorCooldown(MajorCooldown{Spell: &Spell{RelatedSelfBuff: buff}, Type: CooldownTypeMana})
+	if mcdm.initialMajorCooldowns[0].MinTargetTimeToDie != time.Second*30 || mcdm.initialMajorCooldowns[1].MinTargetTimeToDie != 0 {
+		t.Fatalf("Expected only DPS cooldowns to default to their buff duration")
+	}
+
+	sim := SetupFakeSimWithEncounter(&proto.Encounter{
+		Targets:              []*proto.Target{newHealthTestTarget("A", 100000), newHealthTestTarget("B", 100000)},
+		Duration:             180,
+		UseHealth:            true,
+		UseTargetHealth:      true,
+		UseTimeToDieEstimate: true,
+	})
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.TargetUnits[0]
+	mcd := &MajorCooldown{
+		Spell:              fa.Spell,
+		MinTargetTimeToDie: time.Second * 30,
+		ShouldActivate:     func(*Simulation, *Character) bool { return true },
+	}
+
+	// 4000 dps on the first target, so it dies in about 20s, with about 45s left in the fight.
+	for at := time.Duration(0); at <= time.Second*5; at += time.Millisecond * 250 {
+		runFakeSimUntil(sim, at)
+		fa.Nuke.CalcAndDealDamage(sim, target, 1000, fa.Nuke.OutcomeAlwaysHit)
+	}
+	if mcd.shouldActivateHelper(sim, &fa.Character) {
+		t.Fatalf("Expected the cooldown to be held for a target dying in %s", sim.Encounter.AllTargets[0].TimeToDie(sim))
+	}
+
+	mcd.MinTargetTimeToDie = time.Second * 10
+	if !mcd.shouldActivateHelper(sim, &fa.Character) {
+		t.Fatalf("Expected the cooldown to be used when the target lives long enough")
+	}
+
+	mcd.MinTargetTimeToDie = time.Second * 30
+	endFightAtHealth := sim.Encounter.EndFightAtHealth
+	sim.Encounter.EndFightAtHealth = 0
+	if !mcd.shouldActivateHelper(sim, &fa.Character) {
+		t.Fatalf("Expected the cooldown to be used in fights which don't end on health")
+	}
+
+	sim.Encounter.EndFightAtHealth = endFightAtHealth
+	sim.Encounter.UseTimeToDieEstimate = false
+	if !mcd.shouldActivateHelper(sim, &fa.Character) {
+		t.Fatalf("Expected the cooldown to be used without time to die estimates")
+	}
+}
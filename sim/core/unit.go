@@ -56,6 +56,9 @@ type Unit struct {
 	// Amount of time following a post-GCD channel tick, to when the next action can be performed.
 	ChannelClipDelay time.Duration
 
+	// Optional network latency and reaction time distributions. Nil uses the fixed values above.
+	LatencyModel *LatencyModel
+
 	// How far this unit is from its target(s). Measured in yards, this is used
 	// for calculating spell travel time for certain spells.
 	StartDistanceFromTarget float64
@@ -132,6 +135,9 @@ type Unit struct {
 	AttackTables                []map[proto.CastType]*AttackTable
 	DynamicDamageTakenModifiers []DynamicDamageTakenModifier
 
+	// Shields absorbing damage taken by this unit, in the order they were applied.
+	activeShields []*Shield
+
 	GCD *Timer
 
 	// Used for applying the effect of a hardcast spell when casting finishes.
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Class spells used to handle encounter mechanics with the APL interrupt and dispel
+// actions. These have no effect on their own, the actions apply the interrupt or dispel.
+type utilitySpellConfig struct {
+	actionID    ActionID
+	dispelTypes []DispelType // Empty for interrupts.
+
+	cooldown       time.Duration
+	gcd            time.Duration
+	manaCost       float64 // Fraction of base mana.
+	flatManaCost   float64
+	rageCost       float64
+	energyCost     float64
+	requiresShield bool
+}
+
+var utilitySpellsByClass = map[proto.Class][]utilitySpellConfig{
+	proto.Class_ClassRogue: {
+		{actionID: ActionID{SpellID: 1769}, cooldown: time.Second * 10, gcd: time.Second, energyCost: 25}, // Kick
+	},
+	proto.Class_ClassWarrior: {
+		{actionID: ActionID{SpellID: 6554}, cooldown: time.Second * 10, rageCost: 10},                       // Pummel
+		{actionID: ActionID{SpellID: 1672}, cooldown: time.Second * 12, rageCost: 10, requiresShield: true}, // Shield Bash
+	},
+	proto.Class_ClassMage: {
+		{actionID: ActionID{SpellID: 2139}, cooldown: time.Second * 30, flatManaCost: 100},                               // Counterspell
+		{actionID: ActionID{SpellID: 475}, dispelTypes: []DispelType{DispelType_Curse}, gcd: GCDDefault, manaCost: 0.08}, // Remove Lesser Curse
+	},
+	proto.Class_ClassDruid: {
+		{actionID: ActionID{SpellID: 2782}, dispelTypes: []DispelType{DispelType_Curse}, gcd: GCDDefault, manaCost: 0.08},  // Remove Curse
+		{actionID: ActionID{SpellID: 2893}, dispelTypes: []DispelType{DispelType_Poison}, gcd: GCDDefault, manaCost: 0.07}, // Abolish Poison
+	},
+	proto.Class_ClassPriest: {
+		{actionID: ActionID{SpellID: 988}, dispelTypes: []DispelType{DispelType_Magic}, gcd: GCDDefault, manaCost: 0.14},   // Dispel Magic
+		{actionID: ActionID{SpellID: 552}, dispelTypes: []DispelType{DispelType_Disease}, gcd: GCDDefault, manaCost: 0.12}, // Abolish Disease
+	},
+	proto.Class_ClassPaladin: {
+		{actionID: ActionID{SpellID: 4987}, dispelTypes: []DispelType{DispelType_Magic, DispelType_Poison, DispelType_Disease}, gcd: GCDDefault, manaCost: 0.06}, // Cleanse
+	},
+	proto.Class_ClassShaman: {
+		// Usually registered by the class, with its damage.
+		{actionID: ActionID{SpellID: 8042}, cooldown: time.Second * 6, gcd: GCDDefault, flatManaCost: 30},                   // Earth Shock
+		{actionID: ActionID{SpellID: 526}, dispelTypes: []DispelType{DispelType_Poison}, gcd: GCDDefault, manaCost: 0.07},   // Cure Poison
+		{actionID: ActionID{SpellID: 2870}, dispelTypes: []DispelType{DispelType_Disease}, gcd: GCDDefault, manaCost: 0.07}, // Cure Disease
+	},
+}
+
+// Returns the dispel types the given spell can remove, or nil if it isn't a dispel.
+func utilitySpellDispelTypes(actionID ActionID) []DispelType {
+	for _, configs := range utilitySpellsByClass {
+		for _, config := range configs {
+			if config.actionID.SameActionIgnoreTag(actionID) {
+				return config.dispelTypes
+			}
+		}
+	}
+	return nil
+}
+
+func (config *utilitySpellConfig) isNeeded(hasInterrupts bool, dispelTypes DispelType) bool {
+	if len(config.dispelTypes) == 0 {
+		return hasInterrupts
+	}
+	for _, dispelType := range config.dispelTypes {
+		if dispelTypes&dispelType != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns whether any target ability can be interrupted, and the types of the target
+// ability debuffs which can be dispelled.
+func encounterUtilityNeeds(encounter *proto.Encounter) (bool, DispelType) {
+	hasInterrupts := false
+	dispelTypes := DispelType_None
+	if encounter == nil {
+		return hasInterrupts, dispelTypes
+	}
+
+	for _, target := range encounter.Targets {
+		for _, ability := range target.Abilities {
+			if ability.Interruptible && ability.CastTimeSeconds > 0 {
+				hasInterrupts = true
+			}
+			if ability.Debuff != nil {
+				dispelTypes |= DispelTypeFromProto(ability.Debuff.DispelType)
+			}
+		}
+	}
+	return hasInterrupts, dispelTypes
+}
+
+// Only registers the spells which have something to interrupt or dispel in the encounter.
+func (character *Character) registerUtilitySpells(hasInterrupts bool, dispelTypes DispelType) {
+	for _, config := range utilitySpellsByClass[character.Class] {
+		if character.GetSpell(config.actionID) != nil {
+			// Already registered by the class.
+			continue
+		}
+		if !config.isNeeded(hasInterrupts, dispelTypes) {
+			continue
+		}
+
+		spellConfig := SpellConfig{
+			ActionID: config.actionID,
+			Flags:    SpellFlagAPL | SpellFlagNoOnCastComplete,
+
+			Cast: CastConfig{
+				DefaultCast: Cast{
+					GCD: config.gcd,
+				},
+			},
+
+			ApplyEffects: func(_ *Simulation, _ *Unit, _ *Spell) {},
+		}
+		if config.gcd == 0 {
+			spellConfig.Flags |= SpellFlagCastTimeNoGCD
+		}
+		if config.cooldown > 0 {
+			spellConfig.Cast.CD = Cooldown{
+				Timer:    character.NewTimer(),
+				Duration: config.cooldown,
+			}
+		}
+		if len(config.dispelTypes) > 0 {
+			spellConfig.Flags |= SpellFlagHelpful
+		}
+
+		if config.requiresShield {
+			spellConfig.ExtraCastCondition = func(_ *Simulation, _ *Unit) bool {
+				return character.PseudoStats.CanBlock
+			}
+		}
+
+		switch {
+		case config.manaCost > 0:
+			spellConfig.ManaCost = ManaCostOptions{BaseCost: config.manaCost}
+		case config.flatManaCost > 0:
+			spellConfig.ManaCost = ManaCostOptions{FlatCost: config.flatManaCost}
+		case config.rageCost > 0:
+			spellConfig.RageCost = RageCostOptions{Cost: config.rageCost}
+		case config.energyCost > 0:
+			spellConfig.EnergyCost = EnergyCostOptions{Cost: config.energyCost}
+		}
+
+		character.RegisterSpell(spellConfig)
+	}
+}
@@ -0,0 +1,203 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func init() {
+	RegisterAgentFactory(
+		proto.Player_RestorationShaman{},
+		proto.Spec_SpecRestorationShaman,
+		func(char *Character, player *proto.Player) Agent {
+			fa := NewFakeElementalShaman(char, player).(*FakeAgent)
+			// Pays for the utility spells.
+			fa.EnableManaBar()
+			return fa
+		},
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_RestorationShaman)
+			if !ok {
+				panic("Invalid spec value for Restoration Shaman!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+func setupUtilitySim(abilities ...*proto.TargetAbility) (*Simulation, *FakeAgent, *Unit) {
+	rsr := fakeSimRequest(&proto.Encounter{
+		Targets:  []*proto.Target{newFakeSimTarget(abilities...)},
+		Duration: 180,
+	})
+	rsr.Raid.Parties[0].Players[0].Spec = &proto.Player_RestorationShaman{}
+
+	sim, agents := setupFakeSimWithRequest(rsr)
+	fa := agents[0]
+	fa.Rotation = &APLRotation{unit: &fa.Unit}
+	return sim, fa, sim.Encounter.TargetUnits[0]
+}
+
+func TestUtilitySpellsRegistered(t *testing.T) {
+	interruptible := &proto.TargetAbility{SpellId: 1, CastTimeSeconds: 2, Interruptible: true}
+	poison := &proto.TargetAbility{SpellId: 2, Debuff: &proto.TargetAbilityDebuff{SpellId: 3, DispelType: proto.DispelType_DispelTypePoison}}
+
+	tests := []struct {
+		name      string
+		abilities []*proto.TargetAbility
+		expected  []int32
+	}{
+		{name: "nothing to interrupt or dispel"},
+		{name: "interruptible cast", abilities: []*proto.TargetAbility{interruptible}, expected: []int32{8042}},
+		{name: "poison debuff", abilities: []*proto.TargetAbility{poison}, expected: []int32{526}},
+		{name: "both", abilities: []*proto.TargetAbility{interruptible, poison}, expected: []int32{8042, 526}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, fa, _ := setupUtilitySim(test.abilities...)
+
+			// The fake agent is a Shaman, so Counterspell is never registered.
+			for _, spellID := range []int32{8042, 526, 2870, 2139} {
+				spell := fa.GetSpell(ActionID{SpellID: spellID})
+				if expected := slices.Contains(test.expected, spellID); expected != (spell != nil) {
+					t.Fatalf("Expected utility spell %d to be registered: %t", spellID, expected)
+				}
+				if spell != nil && !spell.Flags.Matches(SpellFlagAPL) {
+					t.Fatalf("Expected utility spell %d to be usable in APLs", spellID)
+				}
+			}
+		})
+	}
+}
+
+func TestInterrupt(t *testing.T) {
+	sim, fa, target := setupUtilitySim(&proto.TargetAbility{
+		SpellId:         1,
+		School:          proto.SpellSchool_SpellSchoolShadow,
+		MinDamage:       500,
+		CastTimeSeconds: 2,
+		CooldownSeconds: 10,
+		Interruptible:   true,
+	})
+	ability := target.GetSpell(ActionID{SpellID: 1})
+	action := fa.Rotation.newActionInterrupt(&proto.APLActionInterrupt{
+		SpellId: ActionID{SpellID: 8042}.ToProto(),
+	})
+	earthShock := fa.GetSpell(ActionID{SpellID: 8042})
+
+	runFakeSimUntil(sim, time.Millisecond*500)
+	if !target.IsCastingInterruptible(sim) || !action.IsReady(sim) {
+		t.Fatalf("Expected the target's cast to be interruptible")
+	}
+
+	action.Execute(sim)
+	if target.IsCasting(sim) || action.IsReady(sim) {
+		t.Fatalf("Expected the target's cast to be interrupted")
+	}
+	if earthShock.SpellMetrics[target.UnitIndex].Casts != 1 {
+		t.Fatalf("Expected 1 interrupt cast")
+	}
+
+	// The cooldown still applies to the interrupted cast, so the next cast is from 12s to 14s.
+	runFakeSimUntil(sim, time.Second*15)
+	if ability.SpellMetrics[fa.UnitIndex].Casts != 1 {
+		t.Fatalf("Expected the target to complete 1 cast, got %d", ability.SpellMetrics[fa.UnitIndex].Casts)
+	}
+	if target.Metrics.MissedInterrupts != 1 {
+		t.Fatalf("Expected 1 missed interrupt, got %d", target.Metrics.MissedInterrupts)
+	}
+	if damage := ability.SpellMetrics[fa.UnitIndex].TotalDamage; target.Metrics.MissedInterruptDamage != damage {
+		t.Fatalf("Expected %0.3f missed interrupt damage, got %0.3f", damage, target.Metrics.MissedInterruptDamage)
+	}
+}
+
+func TestUninterruptibleCast(t *testing.T) {
+	sim, fa, target := setupUtilitySim(&proto.TargetAbility{
+		SpellId:         1,
+		School:          proto.SpellSchool_SpellSchoolShadow,
+		MinDamage:       500,
+		CastTimeSeconds: 2,
+		CooldownSeconds: 10,
+	}, &proto.TargetAbility{
+		// Never cast, only makes the encounter need interrupts.
+		SpellId:             2,
+		CastTimeSeconds:     2,
+		CooldownSeconds:     1000,
+		InitialDelaySeconds: 1000,
+		Interruptible:       true,
+	})
+	action := fa.Rotation.newActionInterrupt(&proto.APLActionInterrupt{
+		SpellId: ActionID{SpellID: 8042}.ToProto(),
+	})
+
+	runFakeSimUntil(sim, time.Millisecond*500)
+	if !target.IsCasting(sim) || action.IsReady(sim) {
+		t.Fatalf("Expected the target's cast to not be interruptible")
+	}
+
+	runFakeSimUntil(sim, time.Second*3)
+	if target.Metrics.MissedInterrupts != 0 {
+		t.Fatalf("Expected no missed interrupts for uninterruptible casts")
+	}
+}
+
+func TestDispel(t *testing.T) {
+	sim, fa, _ := setupUtilitySim(&proto.TargetAbility{
+		SpellId:             1,
+		CooldownSeconds:     1000,
+		InitialDelaySeconds: 1000,
+		Debuff: &proto.TargetAbilityDebuff{
+			SpellId:         2,
+			DurationSeconds: 30,
+			DispelType:      proto.DispelType_DispelTypePoison,
+		},
+	}, &proto.TargetAbility{
+		// Never cast, only makes the encounter need interrupts and disease dispels.
+		SpellId:             3,
+		CastTimeSeconds:     2,
+		CooldownSeconds:     1000,
+		InitialDelaySeconds: 1000,
+		Interruptible:       true,
+		Debuff: &proto.TargetAbilityDebuff{
+			SpellId:         4,
+			DurationSeconds: 30,
+			DispelType:      proto.DispelType_DispelTypeDisease,
+		},
+	})
+	debuff := fa.GetAura("Target Ability Debuff " + ActionID{SpellID: 2}.String())
+
+	if fa.Rotation.newActionDispel(&proto.APLActionDispel{SpellId: ActionID{SpellID: 8042}.ToProto()}) != nil {
+		t.Fatalf("Expected a non-dispel spell to be rejected")
+	}
+	if fa.Rotation.newActionDispel(&proto.APLActionDispel{SpellId: ActionID{SpellID: 526}.ToProto(), DispelType: proto.DispelType_DispelTypeMagic}) != nil {
+		t.Fatalf("Expected Cure Poison to be rejected for magic debuffs")
+	}
+	curePoison := fa.Rotation.newActionDispel(&proto.APLActionDispel{SpellId: ActionID{SpellID: 526}.ToProto()})
+	cureDisease := fa.Rotation.newActionDispel(&proto.APLActionDispel{SpellId: ActionID{SpellID: 2870}.ToProto()})
+
+	if curePoison.IsReady(sim) {
+		t.Fatalf("Expected no dispel without a debuff")
+	}
+
+	runFakeSimUntil(sim, time.Second)
+	debuff.Activate(sim)
+	if cureDisease.IsReady(sim) {
+		t.Fatalf("Expected Cure Disease to not remove poisons")
+	}
+	if !curePoison.IsReady(sim) {
+		t.Fatalf("Expected Cure Poison to be ready")
+	}
+
+	runFakeSimUntil(sim, time.Second*4)
+	curePoison.Execute(sim)
+	if debuff.IsActive() {
+		t.Fatalf("Expected the debuff to be dispelled")
+	}
+	if fa.Metrics.DispellableDebuffTime != time.Second*3 {
+		t.Fatalf("Expected 3s with a dispellable debuff, got %s", fa.Metrics.DispellableDebuffTime)
+	}
+}
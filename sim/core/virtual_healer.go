@@ -0,0 +1,270 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Healers which aren't simulated as players, but react to the damage a character
+// takes by casting heals on it. Unlike the flat hps model this leaves realistic gaps
+// in the healing, e.g. from reaction times, cast times and running out of mana.
+type virtualHealers struct {
+	character *Character
+	healers   []*virtualHealer
+
+	// Healing from casts which haven't landed yet, so that healers don't all heal
+	// the same damage.
+	incomingHealing float64
+}
+
+type virtualHealer struct {
+	config *proto.VirtualHealer
+	spells []*Spell
+
+	healthMetrics []*ResourceMetrics
+
+	reactionTime      time.Duration
+	emergencyHealth   float64
+	unlimitedMana     bool
+	manaPerSecond     float64
+	emergencySpellIdx int
+	largestSpellIdx   int
+
+	// Whether the healer is reacting, casting or waiting for mana.
+	busy         bool
+	mana         float64
+	lastManaTick time.Duration
+}
+
+func virtualHealerSpellAvg(spell *proto.VirtualHealerSpell) float64 {
+	return (spell.MinHeal + max(spell.MinHeal, spell.MaxHeal)) / 2
+}
+
+func (character *Character) applyVirtualHealers(configs []*proto.VirtualHealer) {
+	vhs := &virtualHealers{
+		character: character,
+	}
+
+	// Each spell gets its own tag, so that spells without a spell ID, or with the same spell ID
+	// on several healers, have separate metrics.
+	numSpells := int32(0)
+	for _, config := range configs {
+		if len(config.Spells) == 0 {
+			continue
+		}
+
+		healer := &virtualHealer{
+			config:          config,
+			reactionTime:    DurationFromSeconds(max(0, config.ReactionTimeSeconds)),
+			emergencyHealth: config.EmergencyHealthPercent / 100,
+			unlimitedMana:   config.Mana <= 0,
+			manaPerSecond:   max(0, config.Mp5) / 5,
+		}
+		if healer.emergencyHealth <= 0 {
+			healer.emergencyHealth = 0.5
+		}
+
+		for spellIdx, spellConfig := range config.Spells {
+			numSpells++
+			actionID := ActionID{OtherID: proto.OtherAction_OtherActionVirtualHealer, Tag: numSpells}
+			if spellConfig.SpellId != 0 {
+				actionID = ActionID{SpellID: spellConfig.SpellId, Tag: numSpells}
+			}
+			healer.spells = append(healer.spells, character.RegisterSpell(SpellConfig{
+				ActionID:    actionID,
+				SpellSchool: SpellSchoolHoly,
+				ProcMask:    ProcMaskSpellHealing,
+				Flags:       SpellFlagHelpful | SpellFlagNoOnCastComplete,
+			}))
+			healer.healthMetrics = append(healer.healthMetrics, character.NewHealthMetrics(actionID))
+
+			castTime := DurationFromSeconds(max(0, spellConfig.CastTimeSeconds))
+			emergencyCastTime := DurationFromSeconds(max(0, config.Spells[healer.emergencySpellIdx].CastTimeSeconds))
+			if spellIdx == 0 || castTime < emergencyCastTime ||
+				(castTime == emergencyCastTime && virtualHealerSpellAvg(spellConfig) > virtualHealerSpellAvg(config.Spells[healer.emergencySpellIdx])) {
+				healer.emergencySpellIdx = spellIdx
+			}
+			if virtualHealerSpellAvg(spellConfig) > virtualHealerSpellAvg(config.Spells[healer.largestSpellIdx]) {
+				healer.largestSpellIdx = spellIdx
+			}
+		}
+
+		vhs.healers = append(vhs.healers, healer)
+	}
+
+	if len(vhs.healers) == 0 {
+		return
+	}
+	character.Metrics.tmiIncludesHealing = true
+
+	character.RegisterResetEffect(func(sim *Simulation) {
+		vhs.incomingHealing = 0
+		for _, healer := range vhs.healers {
+			healer.busy = false
+			healer.mana = healer.config.Mana
+			healer.lastManaTick = 0
+			if healer.config.Triage == proto.VirtualHealerTriage_TriageSpam {
+				healer.busy = true
+				vhs.scheduleDecision(sim, healer, 0)
+			}
+		}
+	})
+
+	character.RegisterAura(Aura{
+		Label:    "Virtual Healers",
+		Duration: NeverExpires,
+		OnReset: func(aura *Aura, sim *Simulation) {
+			aura.Activate(sim)
+		},
+		OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			if result.Damage > 0 {
+				vhs.react(sim)
+			}
+		},
+		OnPeriodicDamageTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			if result.Damage > 0 {
+				vhs.react(sim)
+			}
+		},
+	})
+}
+
+// Wakes up idle healers, which start casting after their reaction time.
+func (vhs *virtualHealers) react(sim *Simulation) {
+	for _, healer := range vhs.healers {
+		if !healer.busy {
+			healer.busy = true
+			vhs.scheduleDecision(sim, healer, sim.CurrentTime+healer.reactionTime)
+		}
+	}
+}
+
+func (vhs *virtualHealers) scheduleDecision(sim *Simulation, healer *virtualHealer, at time.Duration) {
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     at,
+		Priority: ActionPriorityRegen,
+		OnAction: func(sim *Simulation) {
+			vhs.decide(sim, healer)
+		},
+	})
+}
+
+func (healer *virtualHealer) regenMana(sim *Simulation) {
+	if healer.unlimitedMana {
+		return
+	}
+	elapsed := (sim.CurrentTime - healer.lastManaTick).Seconds()
+	healer.mana = min(healer.config.Mana, healer.mana+elapsed*healer.manaPerSecond)
+	healer.lastManaTick = sim.CurrentTime
+}
+
+// Returns the index of the spell to cast, or -1 if the healer should stay idle.
+func (vhs *virtualHealers) chooseSpell(healer *virtualHealer) int {
+	if healer.config.Triage == proto.VirtualHealerTriage_TriageSpam {
+		return healer.largestSpellIdx
+	}
+
+	character := vhs.character
+	if healer.config.Triage == proto.VirtualHealerTriage_TriageEmergency && character.CurrentHealthPercent() < healer.emergencyHealth {
+		return healer.emergencySpellIdx
+	}
+
+	missingHealth := character.MaxHealth() - character.CurrentHealth() - vhs.incomingHealing
+	chosen := -1
+	for i, spellConfig := range healer.config.Spells {
+		avg := virtualHealerSpellAvg(spellConfig)
+		if avg <= missingHealth && (chosen == -1 || avg > virtualHealerSpellAvg(healer.config.Spells[chosen])) {
+			chosen = i
+		}
+	}
+	return chosen
+}
+
+func (vhs *virtualHealers) decide(sim *Simulation, healer *virtualHealer) {
+	healer.regenMana(sim)
+
+	spellIdx := vhs.chooseSpell(healer)
+	if spellIdx == -1 {
+		healer.busy = false
+		return
+	}
+
+	spellConfig := healer.config.Spells[spellIdx]
+	if !healer.unlimitedMana && healer.mana < spellConfig.ManaCost {
+		if healer.manaPerSecond == 0 {
+			// Out of mana for good.
+			healer.busy = false
+			return
+		}
+		waitTime := DurationFromSeconds((spellConfig.ManaCost - healer.mana) / healer.manaPerSecond)
+		vhs.scheduleDecision(sim, healer, sim.CurrentTime+max(waitTime, time.Millisecond))
+		return
+	}
+	if !healer.unlimitedMana {
+		healer.mana -= spellConfig.ManaCost
+	}
+
+	spell := healer.spells[spellIdx]
+	character := vhs.character
+	castTime := DurationFromSeconds(max(0, spellConfig.CastTimeSeconds))
+	spell.SpellMetrics[character.UnitIndex].Casts++
+	spell.SpellMetrics[character.UnitIndex].TotalCastTime += castTime
+	avg := virtualHealerSpellAvg(spellConfig)
+	vhs.incomingHealing += avg
+	if sim.Log != nil {
+		character.Log(sim, "Virtual healer started casting %s (%s cast time).", spell.ActionID, castTime)
+	}
+
+	castStart := sim.CurrentTime
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     castStart + castTime,
+		Priority: ActionPriorityRegen,
+		OnAction: func(sim *Simulation) {
+			vhs.incomingHealing -= avg
+			vhs.landHeal(sim, healer, spellIdx)
+
+			// Instant heals still trigger a global cooldown.
+			if nextDecision := castStart + GCDDefault; nextDecision > sim.CurrentTime {
+				vhs.scheduleDecision(sim, healer, nextDecision)
+			} else {
+				vhs.decide(sim, healer)
+			}
+		},
+	})
+}
+
+func (vhs *virtualHealers) landHeal(sim *Simulation, healer *virtualHealer, spellIdx int) {
+	character := vhs.character
+	spell := healer.spells[spellIdx]
+	spellConfig := healer.config.Spells[spellIdx]
+
+	heal := sim.Roll(spellConfig.MinHeal, max(spellConfig.MinHeal, spellConfig.MaxHeal))
+	isCrit := sim.Proc(healer.config.CritChance, "Virtual Healer Crit")
+	if isCrit {
+		heal *= 1.5
+	}
+	heal *= character.PseudoStats.HealingTakenMultiplier
+
+	spellMetrics := &spell.SpellMetrics[character.UnitIndex]
+	if isCrit {
+		spellMetrics.Crits++
+		spellMetrics.TotalCritHealing += heal
+	} else {
+		spellMetrics.Hits++
+	}
+	spellMetrics.TotalHealing += heal
+	spellMetrics.TotalOverhealing += max(0, heal-(character.MaxHealth()-character.CurrentHealth()))
+
+	character.GainHealth(sim, heal, healer.healthMetrics[spellIdx])
+
+	result := spell.NewResult(&character.Unit)
+	result.Damage = heal
+	if isCrit {
+		result.Outcome = OutcomeCrit
+	} else {
+		result.Outcome = OutcomeHit
+	}
+	character.OnHealTaken(sim, spell, result)
+	spell.DisposeResult(result)
+}
@@ -0,0 +1,211 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Sets up the fake caster tanking a target which doesn't attack, so all damage comes from the test.
+func setupVirtualHealerSim(healers ...*proto.VirtualHealer) (*Simulation, *FakeAgent) {
+	rsr := fakeSimRequest(&proto.Encounter{
+		Targets:  []*proto.Target{newFakeSimTarget()},
+		Duration: 180,
+	})
+	addFakeSimTanks(rsr, 1)
+	rsr.Raid.Parties[0].Players[0].HealingModel = &proto.HealingModel{
+		BurstWindow:    5,
+		VirtualHealers: healers,
+	}
+
+	sim, agents := setupFakeSimWithRequest(rsr)
+	return sim, agents[0]
+}
+
+func damageFakeAgent(sim *Simulation, fa *FakeAgent, amount float64) {
+	fa.Nuke.CalcAndDealDamage(sim, &fa.Unit, amount, fa.Nuke.OutcomeAlwaysHit)
+}
+
+// Returns the casts of all virtual healer spells with the spell ID.
+func virtualHealerCasts(fa *FakeAgent, spellID int32) int32 {
+	casts := int32(0)
+	for _, spell := range fa.Spellbook {
+		if spell.ActionID.SameActionIgnoreTag(ActionID{SpellID: spellID}) {
+			casts += spell.SpellMetrics[fa.UnitIndex].Casts
+		}
+	}
+	return casts
+}
+
+func TestVirtualHealerReactionTime(t *testing.T) {
+	sim, fa := setupVirtualHealerSim(&proto.VirtualHealer{
+		Spells:              []*proto.VirtualHealerSpell{{SpellId: 1, MinHeal: 100, CastTimeSeconds: 1}},
+		ReactionTimeSeconds: 0.5,
+	})
+
+	runFakeSimUntil(sim, time.Second)
+	if virtualHealerCasts(fa, 1) != 0 {
+		t.Fatalf("Expected no casts without damage taken")
+	}
+
+	damageFakeAgent(sim, fa, 200)
+	runFakeSimUntil(sim, time.Millisecond*1400)
+	if virtualHealerCasts(fa, 1) != 0 {
+		t.Fatalf("Expected no cast before the reaction time")
+	}
+	runFakeSimUntil(sim, time.Millisecond*1500)
+	if virtualHealerCasts(fa, 1) != 1 {
+		t.Fatalf("Expected a cast after the reaction time")
+	}
+	missingHealth := fa.MaxHealth() - fa.CurrentHealth()
+	runFakeSimUntil(sim, time.Millisecond*2500)
+	if healed := missingHealth - (fa.MaxHealth() - fa.CurrentHealth()); healed != 100 {
+		t.Fatalf("Expected the heal to land after its cast time, healed %0.3f", healed)
+	}
+}
+
+func TestVirtualHealerEfficientTriage(t *testing.T) {
+	sim, fa := setupVirtualHealerSim(&proto.VirtualHealer{
+		Spells: []*proto.VirtualHealerSpell{
+			{SpellId: 1, MinHeal: 100, CastTimeSeconds: 1},
+			{SpellId: 2, MinHeal: 300, CastTimeSeconds: 2},
+			{SpellId: 3, MinHeal: 500, CastTimeSeconds: 3},
+		},
+	})
+
+	// Missing 450 health, so only the 100 and 300 heals fit.
+	damageFakeAgent(sim, fa, 450)
+	missingHealth := fa.MaxHealth() - fa.CurrentHealth()
+	runFakeSimUntil(sim, time.Millisecond*3500)
+	if virtualHealerCasts(fa, 2) != 1 || virtualHealerCasts(fa, 3) != 0 {
+		t.Fatalf("Expected the largest heal which doesn't overheal")
+	}
+	if virtualHealerCasts(fa, 1) != 1 {
+		t.Fatalf("Expected the smallest heal for the remaining missing health")
+	}
+
+	// Less health missing than the smallest heal, so the healer stays idle.
+	runFakeSimUntil(sim, time.Second*10)
+	if fa.MaxHealth()-fa.CurrentHealth() != missingHealth-400 {
+		t.Fatalf("Expected no overhealing casts")
+	}
+	if virtualHealerCasts(fa, 1) != 1 {
+		t.Fatalf("Expected the healer to stay idle, got %d casts", virtualHealerCasts(fa, 1))
+	}
+}
+
+func TestVirtualHealerEmergencyTriage(t *testing.T) {
+	healer := &proto.VirtualHealer{
+		Spells: []*proto.VirtualHealerSpell{
+			{SpellId: 1, MinHeal: 200, CastTimeSeconds: 1.5},
+			{SpellId: 2, MinHeal: 600, CastTimeSeconds: 3},
+		},
+		Triage: proto.VirtualHealerTriage_TriageEmergency,
+	}
+	sim, fa := setupVirtualHealerSim(healer)
+
+	// Above the emergency threshold, heals are picked like the efficient triage.
+	damageFakeAgent(sim, fa, fa.MaxHealth()*0.4)
+	runFakeSimUntil(sim, time.Millisecond*10)
+	if virtualHealerCasts(fa, 2) != 1 || virtualHealerCasts(fa, 1) != 0 {
+		t.Fatalf("Expected the largest heal above the emergency threshold")
+	}
+
+	sim, fa = setupVirtualHealerSim(healer)
+	damageFakeAgent(sim, fa, fa.MaxHealth()*0.6)
+	runFakeSimUntil(sim, time.Millisecond*10)
+	if virtualHealerCasts(fa, 1) != 1 || virtualHealerCasts(fa, 2) != 0 {
+		t.Fatalf("Expected the fastest heal below the emergency threshold")
+	}
+}
+
+func TestVirtualHealerOutOfMana(t *testing.T) {
+	sim, fa := setupVirtualHealerSim(&proto.VirtualHealer{
+		Spells: []*proto.VirtualHealerSpell{{SpellId: 1, MinHeal: 100, CastTimeSeconds: 1, ManaCost: 100}},
+		Mana:   250,
+	})
+
+	damageFakeAgent(sim, fa, 1000)
+	runFakeSimUntil(sim, time.Second*10)
+	if virtualHealerCasts(fa, 1) != 2 {
+		t.Fatalf("Expected 2 casts before running out of mana, got %d", virtualHealerCasts(fa, 1))
+	}
+
+	// Without regen, the healer doesn't come back after more damage.
+	damageFakeAgent(sim, fa, 100)
+	runFakeSimUntil(sim, time.Second*20)
+	if virtualHealerCasts(fa, 1) != 2 {
+		t.Fatalf("Expected no casts without mana, got %d", virtualHealerCasts(fa, 1))
+	}
+}
+
+func TestVirtualHealerManaRegen(t *testing.T) {
+	sim, fa := setupVirtualHealerSim(&proto.VirtualHealer{
+		Spells: []*proto.VirtualHealerSpell{{SpellId: 1, MinHeal: 100, ManaCost: 100}},
+		Mana:   100,
+		Mp5:    100,
+	})
+
+	// The first cast is immediate, then the healer waits 5s for enough mana.
+	damageFakeAgent(sim, fa, 1000)
+	runFakeSimUntil(sim, time.Millisecond*4900)
+	if virtualHealerCasts(fa, 1) != 1 {
+		t.Fatalf("Expected 1 cast while waiting for mana, got %d", virtualHealerCasts(fa, 1))
+	}
+	runFakeSimUntil(sim, time.Second*5)
+	if virtualHealerCasts(fa, 1) != 2 {
+		t.Fatalf("Expected a cast once there's enough mana, got %d", virtualHealerCasts(fa, 1))
+	}
+}
+
+func TestTMIHealingEntries(t *testing.T) {
+	hasHealingEntries := func(fa *FakeAgent) bool {
+		for _, entry := range fa.Metrics.tmiList {
+			if entry.WeightedDamage < 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	sim, fa := setupVirtualHealerSim(&proto.VirtualHealer{
+		Spells: []*proto.VirtualHealerSpell{{SpellId: 1, MinHeal: 100}},
+	})
+	damageFakeAgent(sim, fa, 500)
+	runFakeSimUntil(sim, time.Second)
+	if !hasHealingEntries(fa) {
+		t.Fatalf("Expected virtual healing to count against TMI")
+	}
+
+	// Other healing, e.g. from the tank itself, isn't part of TMI.
+	sim, fa = setupVirtualHealerSim()
+	damageFakeAgent(sim, fa, 500)
+	fa.Heal.Cast(sim, &fa.Unit)
+	if fa.MaxHealth() != fa.CurrentHealth() || len(fa.Metrics.tmiList) != 1 {
+		t.Fatalf("Expected only the damage taken to count against TMI")
+	}
+	if hasHealingEntries(fa) {
+		t.Fatalf("Expected healing to not count against TMI without virtual healers")
+	}
+}
+
+func TestVirtualHealerSpellTags(t *testing.T) {
+	_, fa := setupVirtualHealerSim(&proto.VirtualHealer{
+		Spells: []*proto.VirtualHealerSpell{{MinHeal: 100}, {MinHeal: 300}, {SpellId: 1, MinHeal: 500}},
+	}, &proto.VirtualHealer{
+		Spells: []*proto.VirtualHealerSpell{{SpellId: 1, MinHeal: 500}},
+	})
+
+	expected := []ActionID{
+		{OtherID: proto.OtherAction_OtherActionVirtualHealer, Tag: 1},
+		{OtherID: proto.OtherAction_OtherActionVirtualHealer, Tag: 2},
+		{SpellID: 1, Tag: 3},
+		{SpellID: 1, Tag: 4},
+	}
+	for _, actionID := range expected {
+		if fa.GetSpell(actionID) == nil {
+			t.Fatalf("Expected a separate spell for %s", actionID)
+		}
+	}
+}
@@ -25,6 +25,7 @@ const (
 	ClassSpellMask_DruidFaerieFireFeral
 	ClassSpellMask_DruidFerociousBite
 	ClassSpellMask_DruidFrenziedRegeneration
+	ClassSpellMask_DruidHealingTouch
 	ClassSpellMask_DruidHurricane
 	ClassSpellMask_DruidInsectSwarm
 	ClassSpellMask_DruidLacerate
@@ -33,6 +34,8 @@ const (
 	ClassSpellMask_DruidMaul
 	ClassSpellMask_DruidMoonfire
 	ClassSpellMask_DruidRake
+	ClassSpellMask_DruidRegrowth
+	ClassSpellMask_DruidRejuvenation
 	ClassSpellMask_DruidRip
 	ClassSpellMask_DruidSavageRoar
 	ClassSpellMask_DruidShred
@@ -59,6 +62,8 @@ const (
 	ClassSpellMask_DruidCatFormSpells = ClassSpellMask_DruidFerociousBite | ClassSpellMask_DruidMangleCat | ClassSpellMask_DruidRake | ClassSpellMask_DruidRip |
 		ClassSpellMask_DruidSavageRoar | ClassSpellMask_DruidShred | ClassSpellMask_DruidSunfireCat | ClassSpellMask_DruidSwipeCat | ClassSpellMask_DruidTigersFury
 
+	ClassSpellMask_DruidHealingSpells = ClassSpellMask_DruidHealingTouch | ClassSpellMask_DruidRegrowth | ClassSpellMask_DruidRejuvenation
+
 	ClassSpellMask_DruidHarmfulGCDSpells = ClassSpellMask_DruidWrath | ClassSpellMask_DruidStarfire | ClassSpellMask_DruidMoonfire | ClassSpellMask_DruidInsectSwarm |
 		ClassSpellMask_DruidHurricane | ClassSpellMask_DruidStarsurge | ClassSpellMask_DruidSunfire | ClassSpellMask_DruidStarfall
 )
@@ -85,6 +90,8 @@ type Druid struct {
 	ForceOfNature        *DruidSpell
 	FrenziedRegeneration *DruidSpell
 	GiftOfTheWild        *DruidSpell
+	Growl                *DruidSpell
+	HealingTouch         []*DruidSpell
 	Hurricane            []*DruidSpell
 	Innervate            *DruidSpell
 	InsectSwarm          []*DruidSpell
@@ -98,6 +105,8 @@ type Druid struct {
 	Moonfire             []*DruidSpell
 	Rebirth              *DruidSpell
 	Rake                 *DruidSpell
+	Regrowth             []*DruidSpell
+	Rejuvenation         []*DruidSpell
 	Rip                  *DruidSpell
 	SavageRoar           *DruidSpell
 	Shred                *DruidSpell
@@ -260,6 +269,12 @@ func (druid *Druid) RegisterBalanceSpells() {
 	druid.registerWrathSpell()
 }
 
+func (druid *Druid) RegisterRestorationSpells() {
+	druid.registerHealingTouchSpell()
+	druid.registerRegrowthSpell()
+	druid.registerRejuvenationSpell()
+}
+
 // TODO: Classic feral
 func (druid *Druid) RegisterFeralCatSpells() {
 	druid.registerCatFormSpell()
@@ -279,6 +294,7 @@ func (druid *Druid) RegisterFeralTankSpells() {
 	druid.registerFrenziedRegenerationCD()
 	druid.registerMaulSpell()
 	druid.registerSwipeBearSpell()
+	druid.registerGrowlSpell()
 }
 
 func (druid *Druid) Reset(_ *core.Simulation) {
@@ -0,0 +1,69 @@
+package druid
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+)
+
+const HealingTouchRanks = 11
+
+var HealingTouchSpellId = [HealingTouchRanks + 1]int32{0, 5185, 5186, 5187, 5188, 5189, 6778, 8903, 9758, 9888, 9889, 25297}
+var HealingTouchBaseHealing = [HealingTouchRanks + 1][]float64{{0}, {37, 51}, {88, 112}, {195, 243}, {363, 445}, {572, 694}, {742, 894}, {936, 1120}, {1199, 1427}, {1516, 1796}, {1890, 2230}, {2267, 2677}}
+var HealingTouchSpellCoef = [HealingTouchRanks + 1]float64{0, 0.123, 0.314, 0.553, 0.857, 1, 1, 1, 1, 1, 1, 1}
+var HealingTouchCastTime = [HealingTouchRanks + 1]int{0, 1500, 2000, 2500, 3000, 3500, 3500, 3500, 3500, 3500, 3500, 3500}
+var HealingTouchManaCost = [HealingTouchRanks + 1]float64{0, 25, 55, 110, 185, 270, 335, 405, 495, 600, 720, 800}
+var HealingTouchLevel = [HealingTouchRanks + 1]int{0, 1, 8, 14, 20, 26, 32, 38, 44, 50, 56, 60}
+
+func (druid *Druid) registerHealingTouchSpell() {
+	druid.HealingTouch = make([]*DruidSpell, HealingTouchRanks+1)
+
+	for rank := 1; rank <= HealingTouchRanks; rank++ {
+		config := druid.newHealingTouchSpellConfig(rank)
+
+		if config.RequiredLevel <= int(druid.Level) {
+			druid.HealingTouch[rank] = druid.RegisterSpell(Humanoid, config)
+		}
+	}
+}
+
+func (druid *Druid) newHealingTouchSpellConfig(rank int) core.SpellConfig {
+	spellId := HealingTouchSpellId[rank]
+	baseHealingLow := HealingTouchBaseHealing[rank][0]
+	baseHealingHigh := HealingTouchBaseHealing[rank][1]
+	spellCoeff := HealingTouchSpellCoef[rank]
+	castTime := HealingTouchCastTime[rank]
+	manaCost := HealingTouchManaCost[rank]
+	level := HealingTouchLevel[rank]
+
+	return core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: spellId},
+		ClassSpellMask: ClassSpellMask_DruidHealingTouch,
+		SpellSchool:    core.SpellSchoolNature,
+		DefenseType:    core.DefenseTypeMagic,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          SpellFlagOmen | core.SpellFlagHelpful | core.SpellFlagAPL,
+
+		RequiredLevel: level,
+		Rank:          rank,
+
+		ManaCost: core.ManaCostOptions{
+			FlatCost:   manaCost,
+			Multiplier: 100 - 2*druid.Talents.TranquilSpirit,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond*time.Duration(castTime) - time.Millisecond*100*time.Duration(druid.Talents.ImprovedHealingTouch),
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		BonusCoefficient: spellCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, sim.Roll(baseHealingLow, baseHealingHigh), spell.OutcomeHealingCrit)
+		},
+	}
+}
@@ -7,33 +7,27 @@ import (
 // Returns the time to wait before the next action, or 0 if innervate is on CD
 // or disabled.
 func (druid *Druid) registerInnervateCD() {
+	actionID := core.ActionID{SpellID: 29166, Tag: druid.Index}
+
 	innervateTarget := druid.GetUnit(druid.SelfBuffs.InnervateTarget)
-	if innervateTarget == nil {
+	if innervateTarget == nil && !druid.HasCooldownAssignment(actionID) {
 		return
 	}
-	innervateTargetChar := druid.Env.Raid.GetPlayerFromUnit(innervateTarget).GetCharacter()
-
-	actionID := core.ActionID{SpellID: 29166, Tag: druid.Index}
 
 	innervateCD := core.InnervateCD
 
-	innervateAura := core.InnervateAura(innervateTargetChar, actionID.Tag)
-	var innervateManaThreshold float64
+	// Innervate can be cast on any raid member through raid cooldown assignments.
+	innervateAuras := druid.NewRaidAuraArray(func(unit *core.Unit) *core.Aura {
+		return core.InnervateAura(druid.Env.Raid.GetPlayerFromUnit(unit).GetCharacter(), actionID.Tag)
+	})
 
-	druid.RegisterResetEffect(func(sim *core.Simulation) {
-		if innervateTarget == &druid.Unit {
-			if druid.StartingForm.Matches(Cat) {
-				// double shift + innervate cost.
-				// Prevents not having enough mana to shift back into form if more powershift are executed
-				innervateManaThreshold = druid.CatForm.DefaultCast.Cost*2 + druid.Innervate.DefaultCast.Cost
-			} else {
-				// Threshold can be lower when casting on self because its never mid-cast.
-				innervateManaThreshold = 500
-			}
-		} else {
-			innervateManaThreshold = core.InnervateManaThreshold(innervateTargetChar)
+	// Casts from the MCD target the current enemy target, so they go to the configured target.
+	getTarget := func(target *core.Unit) *core.Unit {
+		if target == nil || target.Type == core.EnemyUnit {
+			return innervateTarget
 		}
-	})
+		return target
+	}
 
 	druid.Innervate = druid.RegisterSpell(Humanoid|Moonkin|Tree, core.SpellConfig{
 		ActionID: actionID,
@@ -53,14 +47,36 @@ func (druid *Druid) registerInnervateCD() {
 
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
 			// If target already has another innervate, don't cast.
-			return !innervateTarget.HasActiveAuraWithTag(core.InnervateAuraTag)
+			target = getTarget(target)
+			return target != nil && !target.HasActiveAuraWithTag(core.InnervateAuraTag)
 		},
 
-		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
-			innervateAura.Activate(sim)
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
+			innervateAuras.Get(getTarget(target)).Activate(sim)
 		},
 	})
 
+	if innervateTarget == nil {
+		return
+	}
+	innervateTargetChar := druid.Env.Raid.GetPlayerFromUnit(innervateTarget).GetCharacter()
+	var innervateManaThreshold float64
+
+	druid.RegisterResetEffect(func(sim *core.Simulation) {
+		if innervateTarget == &druid.Unit {
+			if druid.StartingForm.Matches(Cat) {
+				// double shift + innervate cost.
+				// Prevents not having enough mana to shift back into form if more powershift are executed
+				innervateManaThreshold = druid.CatForm.DefaultCast.Cost*2 + druid.Innervate.DefaultCast.Cost
+			} else {
+				// Threshold can be lower when casting on self because its never mid-cast.
+				innervateManaThreshold = 500
+			}
+		} else {
+			innervateManaThreshold = core.InnervateManaThreshold(innervateTargetChar)
+		}
+	})
+
 	druid.AddMajorCooldown(core.MajorCooldown{
 		Spell: druid.Innervate.Spell,
 		Type:  core.CooldownTypeMana,
@@ -88,6 +88,7 @@ func init() {
 			BonusCoefficient: 0.10,
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				damageResults := core.LimitToSpawnedTargets(sim, damageResults)
 				for idx := range damageResults {
 					damageResults[idx] = spell.CalcDamage(sim, target, sim.Roll(100, 175), spell.OutcomeMagicHitAndCrit)
 					target = sim.Environment.NextTargetUnit(target)
@@ -613,6 +614,7 @@ func (druid *Druid) newBloodbarkCleaveItem(itemID int32) {
 		DamageMultiplier: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			results := core.LimitToSpawnedTargets(sim, results)
 			for idx := range results {
 				results[idx] = spell.CalcDamage(sim, target, 5, spell.OutcomeMagicCrit)
 				target = sim.Environment.NextTargetUnit(target)
@@ -0,0 +1,95 @@
+package druid
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+)
+
+const RegrowthRanks = 9
+
+var RegrowthSpellId = [RegrowthRanks + 1]int32{0, 8936, 8938, 8939, 8940, 8941, 9750, 9856, 9857, 9858}
+var RegrowthBaseHealing = [RegrowthRanks + 1][]float64{{0}, {93, 107}, {176, 201}, {255, 290}, {336, 378}, {425, 479}, {534, 596}, {672, 746}, {839, 929}, {1003, 1110}}
+
+// Total healing of the HoT over its full duration.
+var RegrowthBaseHotHealing = [RegrowthRanks + 1]float64{0, 98, 175, 259, 343, 427, 546, 686, 861, 1064}
+
+// Penalty to the spell coefficients for ranks learned below level 20.
+var RegrowthCoefPenalty = [RegrowthRanks + 1]float64{0, 0.7, 0.925, 1, 1, 1, 1, 1, 1, 1}
+var RegrowthManaCost = [RegrowthRanks + 1]float64{0, 80, 135, 185, 230, 275, 335, 405, 485, 550}
+var RegrowthLevel = [RegrowthRanks + 1]int{0, 12, 18, 24, 30, 36, 42, 48, 54, 60}
+
+const (
+	RegrowthSpellCoef    = 0.325
+	RegrowthHotSpellCoef = 0.513
+	RegrowthTicks        = 7
+)
+
+func (druid *Druid) registerRegrowthSpell() {
+	druid.Regrowth = make([]*DruidSpell, RegrowthRanks+1)
+
+	for rank := 1; rank <= RegrowthRanks; rank++ {
+		config := druid.newRegrowthSpellConfig(rank)
+
+		if config.RequiredLevel <= int(druid.Level) {
+			druid.Regrowth[rank] = druid.RegisterSpell(Humanoid, config)
+		}
+	}
+}
+
+func (druid *Druid) newRegrowthSpellConfig(rank int) core.SpellConfig {
+	spellId := RegrowthSpellId[rank]
+	baseHealingLow := RegrowthBaseHealing[rank][0]
+	baseHealingHigh := RegrowthBaseHealing[rank][1]
+	baseTickHealing := RegrowthBaseHotHealing[rank] / RegrowthTicks
+	coefPenalty := RegrowthCoefPenalty[rank]
+	manaCost := RegrowthManaCost[rank]
+	level := RegrowthLevel[rank]
+
+	return core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: spellId},
+		ClassSpellMask: ClassSpellMask_DruidRegrowth,
+		SpellSchool:    core.SpellSchoolNature,
+		DefenseType:    core.DefenseTypeMagic,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          SpellFlagOmen | core.SpellFlagHelpful | core.SpellFlagAPL,
+
+		RequiredLevel: level,
+		Rank:          rank,
+
+		ManaCost: core.ManaCostOptions{
+			FlatCost: manaCost,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 2,
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		BonusCoefficient: RegrowthSpellCoef * coefPenalty,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: fmt.Sprintf("Regrowth (Rank %d)", rank),
+			},
+			NumberOfTicks:    RegrowthTicks,
+			TickLength:       time.Second * 3,
+			BonusCoefficient: RegrowthHotSpellCoef * coefPenalty / RegrowthTicks,
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
+				dot.Snapshot(target, baseTickHealing, isRollover)
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeTick)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, sim.Roll(baseHealingLow, baseHealingHigh), spell.OutcomeHealingCrit)
+			spell.Hot(target).Apply(sim)
+		},
+	}
+}
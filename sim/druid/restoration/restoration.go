@@ -28,7 +28,7 @@ func NewRestorationDruid(character *core.Character, options *proto.Player) *Rest
 	selfBuffs := druid.SelfBuffs{}
 
 	resto := &RestorationDruid{
-		Druid: druid.New(character, druid.Tree, selfBuffs, options.TalentsString),
+		Druid: druid.New(character, druid.Humanoid, selfBuffs, options.TalentsString),
 	}
 
 	resto.SelfBuffs.InnervateTarget = &proto.UnitReference{}
@@ -49,6 +49,7 @@ func (resto *RestorationDruid) GetDruid() *druid.Druid {
 
 func (resto *RestorationDruid) Initialize() {
 	resto.Druid.Initialize()
+	resto.RegisterRestorationSpells()
 }
 
 func (resto *RestorationDruid) Reset(sim *core.Simulation) {
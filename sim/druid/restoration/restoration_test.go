@@ -0,0 +1,73 @@
+package restoration
+
+import (
+	"testing"
+
+	_ "github.com/wowsims/sod/sim/common" // imported to get caster sets included.
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func init() {
+	RegisterRestorationDruid()
+}
+
+func TestRestoration(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:    proto.Class_ClassDruid,
+			Phase:    6,
+			Level:    60,
+			Race:     proto.Race_RaceTauren,
+			IsHealer: true,
+
+			GearSet:     core.GetGearSet("../../../ui/restoration_druid/gear_sets", "blank"),
+			Talents:     StandardTalents,
+			Buffs:       core.FullBuffsPhase6,
+			Consumes:    FullConsumes,
+			SpecOptions: core.SpecOptionsCombo{Label: "Standard", SpecOptions: PlayerOptionsStandard},
+			Rotation:    core.RotationCombo{Label: "Default", Rotation: DefaultRotation},
+
+			ItemFilter: core.ItemFilter{
+				WeaponTypes: []proto.WeaponType{
+					proto.WeaponType_WeaponTypeDagger,
+					proto.WeaponType_WeaponTypeMace,
+					proto.WeaponType_WeaponTypeOffHand,
+					proto.WeaponType_WeaponTypeStaff,
+					proto.WeaponType_WeaponTypePolearm,
+				},
+				ArmorType: proto.ArmorType_ArmorTypeLeather,
+				RangedWeaponTypes: []proto.RangedWeaponType{
+					proto.RangedWeaponType_RangedWeaponTypeIdol,
+				},
+			},
+		},
+	}))
+}
+
+var StandardTalents = "510050001--05550310031503"
+
+var FullConsumes = core.ConsumesCombo{
+	Label: "Full Consumes",
+	Consumes: &proto.Consumes{
+		Flask: proto.Flask_FlaskOfDistilledWisdom,
+		Food:  proto.Food_FoodNightfinSoup,
+	},
+}
+
+var PlayerOptionsStandard = &proto.Player_RestorationDruid{
+	RestorationDruid: &proto.RestorationDruid{
+		Options: &proto.RestorationDruid_Options{
+			InnervateTarget: &proto.UnitReference{Type: proto.UnitReference_Player, Index: 0}, // self innervate
+		},
+	},
+}
+
+var DefaultRotation = core.APLRotationFromJsonString(`{
+	"type": "TypeAPL",
+	"priorityList": [
+		{"action":{"autocastOtherCooldowns":{}}},
+		{"action":{"condition":{"not":{"val":{"auraIsActive":{"sourceUnit":{"type":"LowestHealthAlly"},"auraId":{"spellId":25299}}}}},"castSpell":{"spellId":{"spellId":25299},"target":{"type":"LowestHealthAlly"}}}},
+		{"action":{"castSpell":{"spellId":{"spellId":25297},"target":{"type":"LowestHealthAlly"}}}}
+	]
+}`)
@@ -34,6 +34,9 @@ func (druid *Druid) ApplyTalents() {
 
 	// Restoration
 	druid.applyFuror()
+	druid.applyImprovedRejuvenation()
+	druid.applyImprovedRegrowth()
+	druid.applyGiftOfNature()
 
 	druid.PseudoStats.SpiritRegenRateCasting += .05 * float64(druid.Talents.Reflection)
 }
@@ -350,6 +353,9 @@ func (druid *Druid) applyVengeance() {
 						druid.Wrath,
 						druid.Starfire,
 						druid.Moonfire,
+						druid.HealingTouch,
+						druid.Regrowth,
+						druid.Rejuvenation,
 						{druid.Starsurge},
 						{druid.Sunfire},
 						{druid.StarfallTick},
@@ -382,6 +388,9 @@ func (druid *Druid) applyMoonglow() {
 						druid.Wrath,
 						druid.Starfire,
 						druid.Moonfire,
+						druid.HealingTouch,
+						druid.Regrowth,
+						druid.Rejuvenation,
 						{druid.Starsurge},
 						{druid.Starfall},
 					},
@@ -395,3 +404,39 @@ func (druid *Druid) applyMoonglow() {
 		},
 	})
 }
+
+func (druid *Druid) applyImprovedRejuvenation() {
+	if druid.Talents.ImprovedRejuvenation == 0 {
+		return
+	}
+
+	druid.AddStaticMod(core.SpellModConfig{
+		Kind:      core.SpellMod_DamageDone_Flat,
+		ClassMask: ClassSpellMask_DruidRejuvenation,
+		IntValue:  int64(5 * druid.Talents.ImprovedRejuvenation),
+	})
+}
+
+func (druid *Druid) applyImprovedRegrowth() {
+	if druid.Talents.ImprovedRegrowth == 0 {
+		return
+	}
+
+	druid.AddStaticMod(core.SpellModConfig{
+		Kind:       core.SpellMod_BonusCrit_Flat,
+		ClassMask:  ClassSpellMask_DruidRegrowth,
+		FloatValue: 10 * float64(druid.Talents.ImprovedRegrowth) * core.SpellCritRatingPerCritChance,
+	})
+}
+
+func (druid *Druid) applyGiftOfNature() {
+	if druid.Talents.GiftOfNature == 0 {
+		return
+	}
+
+	druid.AddStaticMod(core.SpellModConfig{
+		Kind:      core.SpellMod_DamageDone_Flat,
+		ClassMask: ClassSpellMask_DruidHealingSpells,
+		IntValue:  int64(2 * druid.Talents.GiftOfNature),
+	})
+}
@@ -303,6 +303,24 @@ func (ai *VaelastraszTheCorruptAI) registerSpells() {
 func (ai *VaelastraszTheCorruptAI) Reset(*core.Simulation) {
 }
 
+func (ai *VaelastraszTheCorruptAI) GetTimerRemaining(sim *core.Simulation, name string) (time.Duration, bool) {
+	switch name {
+	case "Burning Adrenaline":
+		if ai.burningAdrenalineTime == 0 {
+			return 0, false
+		}
+		firstCastAt := time.Second * time.Duration(ai.burningAdrenalineTime)
+		return max(ai.burningAdrenalineSpell.TimeToReady(sim), firstCastAt-sim.CurrentTime), true
+	case "Flame Breath":
+		return ai.flameBreathSpell.TimeToReady(sim), true
+	}
+	return 0, false
+}
+
+func (ai *VaelastraszTheCorruptAI) GetPhase(*core.Simulation) int32 {
+	return 1
+}
+
 const BossGCD = time.Millisecond * 1600
 
 func (ai *VaelastraszTheCorruptAI) ExecuteCustomRotation(sim *core.Simulation) {
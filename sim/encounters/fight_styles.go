@@ -0,0 +1,226 @@
+package encounters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+const fightStylesPrefix = "Fight Styles"
+
+// Parameters for generating a fight style encounter.
+type FightStyleOptions struct {
+	// Copied for the boss and, with reduced health, for any adds.
+	Target *proto.Target
+
+	// Fight length in seconds.
+	Duration float64
+
+	// Number of targets for Cleave, or adds per wave for AoE Waves and Target Switching.
+	// 0 uses the style's default.
+	NumTargets int32
+}
+
+// A generic, parametrised fight, as opposed to a named boss preset.
+type FightStyle struct {
+	// Name used on the command line, e.g. "patchwerk".
+	Name string
+	// Name used for the preset encounter.
+	Label string
+
+	Description string
+
+	// Use GenerateFightStyleEncounter, which fills in defaults for unset options.
+	Generate func(options FightStyleOptions) *proto.Encounter
+}
+
+var FightStyles = []*FightStyle{
+	{
+		Name:        "patchwerk",
+		Label:       "Patchwerk",
+		Description: "A single stationary target.",
+		Generate: func(options FightStyleOptions) *proto.Encounter {
+			return newFightStyleEncounter(options)
+		},
+	},
+	{
+		Name:        "cleave",
+		Label:       "Cleave",
+		Description: "Several stationary targets which are all tanked, 2 by default.",
+		Generate: func(options FightStyleOptions) *proto.Encounter {
+			encounter := newFightStyleEncounter(options)
+			for i := int32(1); i < max(options.NumTargets, 2); i++ {
+				target := newFightStyleTarget(options.Target, fmt.Sprintf("Target %d", i+1), 1)
+				target.TankIndex = 1
+				encounter.Targets = append(encounter.Targets, target)
+			}
+			return encounter
+		},
+	},
+	{
+		Name:        "aoe-waves",
+		Label:       "AoE Waves",
+		Description: "A boss with a wave of adds every 45s, 5 adds per wave by default. Each wave stays for 15s, as its own phase.",
+		Generate: func(options FightStyleOptions) *proto.Encounter {
+			encounter := newFightStyleEncounter(options)
+			addFightStyleWaves(encounter, options, "Wave", 5, 20, 15, 45)
+			return encounter
+		},
+	},
+	{
+		Name:        "heavy-movement",
+		Label:       "Heavy Movement",
+		Description: "A single target, with players moving 20 yards every 15s and unable to act for 3s every 45s.",
+		Generate: func(options FightStyleOptions) *proto.Encounter {
+			encounter := newFightStyleEncounter(options)
+			encounter.MovementProfile = proto.EncounterMovementProfile_MovementProfileHeavy
+			return encounter
+		},
+	},
+	{
+		Name:        "execute",
+		Label:       "Execute Heavy",
+		Description: "A single target which spends half of the fight below 35% health.",
+		Generate: func(options FightStyleOptions) *proto.Encounter {
+			encounter := newFightStyleEncounter(options)
+			encounter.ExecuteProportion_20 = 0.3
+			encounter.ExecuteProportion_25 = 0.35
+			encounter.ExecuteProportion_35 = 0.5
+			return encounter
+		},
+	},
+	{
+		Name:        "target-switching",
+		Label:       "Target Switching",
+		Description: "A boss with a priority add every 30s, 1 add at a time by default. Each add stays for 10s, as its own phase.",
+		Generate: func(options FightStyleOptions) *proto.Encounter {
+			encounter := newFightStyleEncounter(options)
+			addFightStyleWaves(encounter, options, "Add", 1, 15, 10, 30)
+			return encounter
+		},
+	},
+	{
+		Name:        "intermission",
+		Label:       "Intermission",
+		Description: "A single target which becomes untargetable for 20s at 50% health, then takes 10% more damage below 20% health.",
+		Generate: func(options FightStyleOptions) *proto.Encounter {
+			encounter := newFightStyleEncounter(options)
+			encounter.Phases = []*proto.EncounterPhase{
+				{
+					Name:                "Intermission",
+					Trigger:             proto.EncounterPhaseTrigger_PhaseTriggerHealth,
+					TriggerValue:        50,
+					DurationSeconds:     20,
+					TargetsUntargetable: true,
+				},
+				{
+					Name:                        "Burn",
+					Trigger:                     proto.EncounterPhaseTrigger_PhaseTriggerHealth,
+					TriggerValue:                20,
+					TargetDamageTakenMultiplier: 1.1,
+				},
+			}
+			return encounter
+		},
+	},
+}
+
+// Returns the fight style with the given command line name, or nil if there is none.
+func GetFightStyle(name string) *FightStyle {
+	for _, style := range FightStyles {
+		if style.Name == strings.ToLower(name) {
+			return style
+		}
+	}
+	return nil
+}
+
+// Fills in defaults for unset options.
+func (options FightStyleOptions) withDefaults() FightStyleOptions {
+	if options.Target == nil {
+		options.Target = core.GetPresetTargetWithPath("SoD/Level 60").Config
+	}
+	if options.Duration <= 0 {
+		options.Duration = 180
+	}
+	return options
+}
+
+func addFightStyles() {
+	for _, style := range FightStyles {
+		core.AddPresetEncounterWithSettings(fightStylesPrefix+"/"+style.Label, style.Generate(FightStyleOptions{}.withDefaults()))
+	}
+}
+
+// Generates the encounter for the given fight style name.
+func GenerateFightStyleEncounter(name string, options FightStyleOptions) (*proto.Encounter, error) {
+	style := GetFightStyle(name)
+	if style == nil {
+		names := make([]string, len(FightStyles))
+		for i, style := range FightStyles {
+			names[i] = style.Name
+		}
+		return nil, fmt.Errorf("unknown fight style %q, must be one of: %s", name, strings.Join(names, ", "))
+	}
+	return style.Generate(options.withDefaults()), nil
+}
+
+func newFightStyleEncounter(options FightStyleOptions) *proto.Encounter {
+	return &proto.Encounter{
+		Duration:             options.Duration,
+		DurationVariation:    options.Duration / 10,
+		ExecuteProportion_20: 0.2,
+		ExecuteProportion_25: 0.25,
+		ExecuteProportion_35: 0.35,
+		Targets: []*proto.Target{
+			newFightStyleTarget(options.Target, "Boss", 1),
+		},
+	}
+}
+
+func newFightStyleTarget(base *proto.Target, name string, healthMultiplier float64) *proto.Target {
+	target := googleProto.Clone(base).(*proto.Target)
+	target.Name = name
+	if len(target.Stats) > int(stats.Health) {
+		target.Stats[stats.Health] *= healthMultiplier
+	}
+	return target
+}
+
+// Adds waves of untanked adds with a fraction of the boss health, the first spawning
+// after firstSpawn seconds, each staying for stay seconds and then returning every interval.
+// Each wave is a phase, so that rotations can check whether adds are up.
+func addFightStyleWaves(encounter *proto.Encounter, options FightStyleOptions, phaseName string, defaultNumAdds int32, firstSpawn, stay, interval float64) {
+	numAdds := options.NumTargets
+	if numAdds <= 0 {
+		numAdds = defaultNumAdds
+	}
+
+	for i := int32(0); i < numAdds; i++ {
+		add := newFightStyleTarget(options.Target, fmt.Sprintf("Add %d", i+1), 0.1)
+		add.Id = 0
+		add.Level = max(options.Target.Level-1, 1)
+		add.MinBaseDamage = 0
+		add.SwingSpeed = 0
+		add.TankIndex = -1
+		add.IsAdd = true
+		add.SpawnTimeSeconds = firstSpawn
+		add.DespawnAfterSeconds = stay
+		add.RespawnIntervalSeconds = interval
+		encounter.Targets = append(encounter.Targets, add)
+	}
+
+	// Waves can still spawn within the duration variation.
+	maxDuration := encounter.Duration + encounter.DurationVariation
+	for wave, spawnAt := 1, firstSpawn; spawnAt < maxDuration; wave, spawnAt = wave+1, spawnAt+interval {
+		encounter.Phases = append(encounter.Phases, &proto.EncounterPhase{
+			Name:            fmt.Sprintf("%s %d", phaseName, wave),
+			TriggerValue:    spawnAt,
+			DurationSeconds: stay,
+		})
+	}
+}
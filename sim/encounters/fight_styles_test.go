@@ -0,0 +1,128 @@
+package encounters
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func TestGenerateFightStyleEncounter(t *testing.T) {
+	tests := []struct {
+		name       string
+		numTargets int32
+		phases     []string
+		check      func(t *testing.T, encounter *proto.Encounter)
+	}{
+		{name: "patchwerk", numTargets: 1},
+		{name: "cleave", numTargets: 2},
+		{
+			// Waves spawn at 20s, 65s, 110s and 155s, within the 198s maximum duration.
+			name:       "aoe-waves",
+			numTargets: 6,
+			phases:     []string{"Wave 1", "Wave 2", "Wave 3", "Wave 4"},
+			check: func(t *testing.T, encounter *proto.Encounter) {
+				for i, phase := range encounter.Phases {
+					if phase.Trigger != proto.EncounterPhaseTrigger_PhaseTriggerTime || phase.TriggerValue != 20+45*float64(i) || phase.DurationSeconds != 15 {
+						t.Fatalf("Expected %s to match the add spawns, got %v", phase.Name, phase)
+					}
+				}
+				for _, add := range encounter.Targets[1:] {
+					if !add.IsAdd || add.TankIndex != -1 || add.SpawnTimeSeconds != 20 || add.DespawnAfterSeconds != 15 || add.RespawnIntervalSeconds != 45 {
+						t.Fatalf("Expected %s to be an untanked add spawning every 45s, got %v", add.Name, add)
+					}
+				}
+			},
+		},
+		{
+			name:       "heavy-movement",
+			numTargets: 1,
+			check: func(t *testing.T, encounter *proto.Encounter) {
+				if encounter.MovementProfile != proto.EncounterMovementProfile_MovementProfileHeavy {
+					t.Fatalf("Expected the heavy movement profile, got %s", encounter.MovementProfile)
+				}
+			},
+		},
+		{
+			name:       "execute",
+			numTargets: 1,
+			check: func(t *testing.T, encounter *proto.Encounter) {
+				if encounter.ExecuteProportion_35 != 0.5 {
+					t.Fatalf("Expected half of the fight below 35%% health, got %0.2f", encounter.ExecuteProportion_35)
+				}
+			},
+		},
+		{
+			name:       "target-switching",
+			numTargets: 2,
+			phases:     []string{"Add 1", "Add 2", "Add 3", "Add 4", "Add 5", "Add 6", "Add 7"},
+		},
+		{
+			name:       "intermission",
+			numTargets: 1,
+			phases:     []string{"Intermission", "Burn"},
+			check: func(t *testing.T, encounter *proto.Encounter) {
+				intermission := encounter.Phases[0]
+				if intermission.Trigger != proto.EncounterPhaseTrigger_PhaseTriggerHealth || intermission.TriggerValue != 50 || !intermission.TargetsUntargetable || intermission.DurationSeconds != 20 {
+					t.Fatalf("Expected the boss to be untargetable for 20s at 50%% health, got %v", intermission)
+				}
+			},
+		},
+	}
+
+	if len(tests) != len(FightStyles) {
+		t.Fatalf("Expected a test for each of the %d fight styles", len(FightStyles))
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			encounter, err := GenerateFightStyleEncounter(test.name, FightStyleOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if encounter.Duration != 180 || encounter.DurationVariation != 18 {
+				t.Fatalf("Expected the default 180s duration, got %0.1f +/- %0.1f", encounter.Duration, encounter.DurationVariation)
+			}
+			if len(encounter.Targets) != int(test.numTargets) || encounter.Targets[0].Name != "Boss" {
+				t.Fatalf("Expected %d targets, got %d", test.numTargets, len(encounter.Targets))
+			}
+			if len(encounter.Phases) != len(test.phases) {
+				t.Fatalf("Expected %d phases, got %d", len(test.phases), len(encounter.Phases))
+			}
+			for i, phase := range encounter.Phases {
+				if phase.Name != test.phases[i] {
+					t.Fatalf("Expected phase %d to be %s, got %s", i+1, test.phases[i], phase.Name)
+				}
+			}
+			if test.check != nil {
+				test.check(t, encounter)
+			}
+		})
+	}
+}
+
+func TestGenerateFightStyleEncounterOptions(t *testing.T) {
+	if _, err := GenerateFightStyleEncounter("unknown", FightStyleOptions{}); err == nil {
+		t.Fatalf("Expected an error for an unknown fight style")
+	}
+
+	encounter, err := GenerateFightStyleEncounter("Cleave", FightStyleOptions{Duration: 60, NumTargets: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encounter.Duration != 60 || len(encounter.Targets) != 4 {
+		t.Fatalf("Expected 4 targets for 60s, got %d for %0.1fs", len(encounter.Targets), encounter.Duration)
+	}
+
+	// Fewer waves fit in a shorter fight.
+	encounter, err = GenerateFightStyleEncounter("aoe-waves", FightStyleOptions{Duration: 60, NumTargets: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(encounter.Targets) != 4 || len(encounter.Phases) != 2 {
+		t.Fatalf("Expected 3 adds in 2 waves, got %d targets and %d phases", len(encounter.Targets), len(encounter.Phases))
+	}
+	if boss, add := encounter.Targets[0], encounter.Targets[1]; add.Stats[stats.Health] != boss.Stats[stats.Health]*0.1 || boss.Stats[stats.Health] == 0 {
+		t.Fatalf("Expected adds to have 10%% of the boss health")
+	}
+}
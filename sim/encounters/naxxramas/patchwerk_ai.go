@@ -66,6 +66,8 @@ type PatchwerkAI struct {
 	HatefulStrikePrimer *core.Spell
 	HatefulStrike       *core.Spell
 	Frenzy              *core.Spell
+
+	frenzyAura *core.Aura
 }
 
 func NewPatchwerkAI() core.AIFactory {
@@ -139,7 +141,7 @@ func (ai *PatchwerkAI) registerHatefulStrikeSpell(target *core.Target) {
 
 func (ai *PatchwerkAI) registerFrenzySpell(target *core.Target) {
 	actionID := core.ActionID{SpellID: 28131}
-	frenzyAura := target.GetOrRegisterAura(core.Aura{
+	ai.frenzyAura = target.GetOrRegisterAura(core.Aura{
 		ActionID: actionID,
 		Label:    "Frenzy",
 		Duration: 5 * time.Minute,
@@ -165,11 +167,30 @@ func (ai *PatchwerkAI) registerFrenzySpell(target *core.Target) {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			frenzyAura.Activate(sim)
+			ai.frenzyAura.Activate(sim)
 		},
 	})
 }
 
+func (ai *PatchwerkAI) GetTimerRemaining(sim *core.Simulation, name string) (time.Duration, bool) {
+	switch name {
+	case "Hateful Strike":
+		if !ai.isHatefulTank {
+			return 0, false
+		}
+		return ai.HatefulStrike.TimeToReady(sim), true
+	}
+	return 0, false
+}
+
+// Patchwerk frenzies for the final 5% of the fight.
+func (ai *PatchwerkAI) GetPhase(*core.Simulation) int32 {
+	if ai.frenzyAura.IsActive() {
+		return 2
+	}
+	return 1
+}
+
 func (ai *PatchwerkAI) ExecuteCustomRotation(sim *core.Simulation) {
 	target := ai.Target.CurrentTarget
 
@@ -79,6 +79,21 @@ const BossGCD = time.Millisecond * 1600
 func (ai *ThaddiusAI) Reset(*core.Simulation) {
 }
 
+func (ai *ThaddiusAI) GetTimerRemaining(sim *core.Simulation, name string) (time.Duration, bool) {
+	switch name {
+	case "Polarity Shift":
+		return ai.Polarity.TimeToReady(sim), true
+	case "Chain Lightning":
+		return ai.ChainLightning.TimeToReady(sim), true
+	}
+	return 0, false
+}
+
+func (ai *ThaddiusAI) GetPhase(*core.Simulation) int32 {
+	// Only the Thaddius phase of the encounter is simmed.
+	return 2
+}
+
 func (ai *ThaddiusAI) registerChainLightning(target *core.Target) {
 	actionID := core.ActionID{SpellID: 28167}
 
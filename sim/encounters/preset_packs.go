@@ -0,0 +1,218 @@
+package encounters
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/tailscale/hujson"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// File extensions of preset packs. Both are parsed as HuJSON, so comments and
+// trailing commas are allowed.
+var presetPackExtensions = []string{".json", ".hujson"}
+
+// Loads and registers every preset pack in the directory, in file name order.
+// Stops at the first invalid pack. Packs loaded before it stay registered.
+func LoadPresetPacks(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read preset pack directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !slices.Contains(presetPackExtensions, strings.ToLower(filepath.Ext(entry.Name()))) {
+			continue
+		}
+		if err := LoadPresetPack(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Loads and registers a single preset pack file. Nothing is registered if the pack is invalid.
+func LoadPresetPack(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read preset pack: %w", err)
+	}
+
+	pack, err := parsePresetPack(data)
+	if err != nil {
+		return fmt.Errorf("invalid preset pack %s: %w", path, err)
+	}
+
+	for _, presetTarget := range pack.Targets {
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: presetTargetPathPrefix(presetTarget.Path),
+			Config:     presetTarget.Target,
+		})
+	}
+	for _, encounter := range pack.Encounters {
+		core.AddPresetEncounterProto(encounter)
+	}
+	return nil
+}
+
+func parsePresetPack(data []byte) (*proto.PresetPack, error) {
+	standardized, err := hujson.Standardize(data)
+	if err != nil {
+		return nil, err
+	}
+
+	pack := &proto.PresetPack{}
+	if err := protojson.Unmarshal(standardized, pack); err != nil {
+		return nil, err
+	}
+	if err := validatePresetPack(pack); err != nil {
+		return nil, err
+	}
+	return pack, nil
+}
+
+// Checks the pack for errors, and fills in encounter targets which refer to preset targets by path.
+func validatePresetPack(pack *proto.PresetPack) error {
+	if len(pack.Targets) == 0 && len(pack.Encounters) == 0 {
+		return errors.New("no targets or encounters")
+	}
+
+	packTargets := make(map[string]*proto.Target, len(pack.Targets))
+	for i, presetTarget := range pack.Targets {
+		if err := validatePresetTarget(presetTarget); err != nil {
+			return fmt.Errorf("targets[%d] %q: %w", i, presetTarget.Path, err)
+		}
+		if _, ok := packTargets[presetTarget.Path]; ok || core.GetPresetTargetWithPath(presetTarget.Path) != nil {
+			return fmt.Errorf("targets[%d]: a preset target with path %q already exists", i, presetTarget.Path)
+		}
+		packTargets[presetTarget.Path] = presetTarget.Target
+	}
+
+	encounterPaths := make(map[string]bool, len(pack.Encounters))
+	for i, encounter := range pack.Encounters {
+		if err := validatePresetEncounter(encounter, packTargets); err != nil {
+			return fmt.Errorf("encounters[%d] %q: %w", i, encounter.Path, err)
+		}
+		if encounterPaths[encounter.Path] || core.GetPresetEncounterWithPath(encounter.Path) != nil {
+			return fmt.Errorf("encounters[%d]: a preset encounter with path %q already exists", i, encounter.Path)
+		}
+		encounterPaths[encounter.Path] = true
+	}
+	return nil
+}
+
+func validatePresetTarget(presetTarget *proto.PresetTarget) error {
+	if presetTarget.Target == nil {
+		return errors.New("missing target")
+	}
+
+	prefix := presetTargetPathPrefix(presetTarget.Path)
+	if prefix == "" {
+		return errors.New("path must be in the form \"Category/Name\"")
+	}
+	name := presetTarget.Path[len(prefix)+1:]
+	if presetTarget.Target.Name == "" {
+		presetTarget.Target.Name = name
+	} else if presetTarget.Target.Name != name {
+		return fmt.Errorf("target name %q doesn't match the end of its path", presetTarget.Target.Name)
+	}
+
+	return validateTarget(presetTarget.Target)
+}
+
+// Returns everything before the last '/' of a preset target path, or "" if there is no prefix.
+func presetTargetPathPrefix(path string) string {
+	idx := strings.LastIndex(path, "/")
+	if idx <= 0 || idx == len(path)-1 {
+		return ""
+	}
+	return path[:idx]
+}
+
+func validatePresetEncounter(encounter *proto.PresetEncounter, packTargets map[string]*proto.Target) error {
+	if encounter.Path == "" {
+		return errors.New("missing path")
+	}
+	if len(encounter.Targets) == 0 {
+		return errors.New("encounter must have targets")
+	}
+	if encounter.Settings != nil && len(encounter.Settings.Targets) > 0 {
+		return errors.New("settings can't have targets, use the encounter's targets instead")
+	}
+
+	for i, presetTarget := range encounter.Targets {
+		if presetTarget.Target == nil {
+			if target, ok := packTargets[presetTarget.Path]; ok {
+				presetTarget.Target = googleProto.Clone(target).(*proto.Target)
+			} else if preset := core.GetPresetTargetWithPath(presetTarget.Path); preset != nil {
+				presetTarget.Target = googleProto.Clone(preset.Config).(*proto.Target)
+			} else {
+				return fmt.Errorf("targets[%d]: no preset target with path %q", i, presetTarget.Path)
+			}
+			continue
+		}
+
+		if err := validateTarget(presetTarget.Target); err != nil {
+			return fmt.Errorf("targets[%d]: %w", i, err)
+		}
+		if presetTarget.Path == "" {
+			presetTarget.Path = encounter.Path + "/" + presetTarget.Target.Name
+		}
+	}
+	return nil
+}
+
+func validateTarget(target *proto.Target) error {
+	if target.Name == "" {
+		return errors.New("missing name")
+	}
+	if target.Level <= 0 {
+		return fmt.Errorf("invalid level %d", target.Level)
+	}
+	if len(target.Stats) > int(stats.Len) {
+		return fmt.Errorf("too many stats, %d given but there are only %d", len(target.Stats), stats.Len)
+	}
+	if target.MinBaseDamage < 0 || target.SwingSpeed < 0 {
+		return errors.New("damage and swing speed can't be negative")
+	}
+	if target.TankIndex < -1 {
+		return fmt.Errorf("invalid tank index %d, use -1 for untanked targets", target.TankIndex)
+	}
+
+	for i, ability := range target.Abilities {
+		if err := validateTargetAbility(ability); err != nil {
+			return fmt.Errorf("abilities[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateTargetAbility(ability *proto.TargetAbility) error {
+	if ability.SpellId <= 0 {
+		return errors.New("missing spell id")
+	}
+	if ability.MinDamage < 0 || ability.MaxDamage < 0 {
+		return errors.New("damage can't be negative")
+	}
+	if ability.CastTimeSeconds < 0 || ability.CooldownSeconds < 0 || ability.InitialDelaySeconds < 0 {
+		return errors.New("cast time, cooldown and initial delay can't be negative")
+	}
+	if ability.ChanceToUse < 0 || ability.ChanceToUse > 1 {
+		return fmt.Errorf("chance to use must be between 0 and 1, got %g", ability.ChanceToUse)
+	}
+	if ability.MinHealthPercent < 0 || ability.MaxHealthPercent < 0 || ability.MaxHealthPercent > 100 {
+		return errors.New("health percents must be between 0 and 100")
+	}
+	if debuff := ability.Debuff; debuff != nil && (debuff.TankSwapStacks < 0 || debuff.TankSwapStacks > max(debuff.MaxStacks, 1)) {
+		return fmt.Errorf("tank swap stacks must be between 0 and the debuff's max stacks, got %d", debuff.TankSwapStacks)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package encounters
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core"
+)
+
+func TestParsePresetPack(t *testing.T) {
+	tests := []struct {
+		name string
+		pack string
+		// Substring of the expected error, or "" if the pack is valid.
+		err string
+	}{
+		{
+			name: "target",
+			pack: `{"targets": [{"path": "Test/Boss", "target": {"level": 63}}]}`,
+		},
+		{
+			name: "hujson",
+			pack: `{
+				// Comments and trailing commas are allowed.
+				"targets": [{"path": "Test/Boss", "target": {"name": "Boss", "level": 63,},},],
+			}`,
+		},
+		{
+			name: "encounter with inline targets",
+			pack: `{"encounters": [{"path": "Test/Fight", "targets": [{"target": {"name": "Boss", "level": 63}}]}]}`,
+		},
+		{
+			name: "encounter with settings",
+			pack: `{"encounters": [{
+				"path": "Test/Fight",
+				"targets": [{"target": {"name": "Boss", "level": 63}}],
+				"settings": {"duration": 300, "phases": [{"name": "Burn", "trigger": "PhaseTriggerHealth", "triggerValue": 20}]},
+			}]}`,
+		},
+		{name: "invalid json", pack: `{"targets": [`, err: "unexpected EOF"},
+		{name: "unknown field", pack: `{"bosses": []}`, err: "unknown field"},
+		{name: "empty", pack: `{}`, err: "no targets or encounters"},
+		{name: "missing target", pack: `{"targets": [{"path": "Test/Boss"}]}`, err: "missing target"},
+		{name: "path without category", pack: `{"targets": [{"path": "Boss", "target": {"level": 63}}]}`, err: "path must be"},
+		{name: "path ending in a slash", pack: `{"targets": [{"path": "Test/", "target": {"level": 63}}]}`, err: "path must be"},
+		{name: "mismatched name", pack: `{"targets": [{"path": "Test/Boss", "target": {"name": "Add", "level": 63}}]}`, err: "doesn't match"},
+		{name: "missing level", pack: `{"targets": [{"path": "Test/Boss", "target": {}}]}`, err: "invalid level"},
+		{name: "negative damage", pack: `{"targets": [{"path": "Test/Boss", "target": {"level": 63, "minBaseDamage": -1}}]}`, err: "can't be negative"},
+		{name: "invalid tank index", pack: `{"targets": [{"path": "Test/Boss", "target": {"level": 63, "tankIndex": -2}}]}`, err: "invalid tank index"},
+		{
+			name: "too many stats",
+			pack: `{"targets": [{"path": "Test/Boss", "target": {"level": 63, "stats": [` + strings.Repeat("0,", 100) + `0]}}]}`,
+			err:  "too many stats",
+		},
+		{
+			name: "ability without spell",
+			pack: `{"targets": [{"path": "Test/Boss", "target": {"level": 63, "abilities": [{"minDamage": 100}]}}]}`,
+			err:  "abilities[0]: missing spell id",
+		},
+		{
+			name: "ability chance",
+			pack: `{"targets": [{"path": "Test/Boss", "target": {"level": 63, "abilities": [{"spellId": 1, "chanceToUse": 2}]}}]}`,
+			err:  "chance to use",
+		},
+		{
+			name: "ability tank swap stacks",
+			pack: `{"targets": [{"path": "Test/Boss", "target": {"level": 63, "abilities": [{"spellId": 1, "debuff": {"spellId": 2, "maxStacks": 3, "tankSwapStacks": 4}}]}}]}`,
+			err:  "tank swap stacks",
+		},
+		{
+			name: "duplicate target",
+			pack: `{"targets": [{"path": "Test/Boss", "target": {"level": 63}}, {"path": "Test/Boss", "target": {"level": 60}}]}`,
+			err:  `targets[1]: a preset target with path "Test/Boss" already exists`,
+		},
+		{
+			name: "duplicate built in target",
+			pack: `{"targets": [{"path": "SoD/Level 60", "target": {"level": 60}}]}`,
+			err:  "already exists",
+		},
+		{
+			name: "duplicate encounter",
+			pack: `{"encounters": [
+				{"path": "Test/Fight", "targets": [{"path": "SoD/Level 60"}]},
+				{"path": "Test/Fight", "targets": [{"path": "SoD/Level 60"}]},
+			]}`,
+			err: `encounters[1]: a preset encounter with path "Test/Fight" already exists`,
+		},
+		{
+			name: "duplicate built in encounter",
+			pack: `{"encounters": [{"path": "Fight Styles/Patchwerk", "targets": [{"path": "SoD/Level 60"}]}]}`,
+			err:  "already exists",
+		},
+		{name: "encounter without path", pack: `{"encounters": [{"targets": [{"path": "SoD/Level 60"}]}]}`, err: "missing path"},
+		{name: "encounter without targets", pack: `{"encounters": [{"path": "Test/Fight"}]}`, err: "must have targets"},
+		{
+			name: "encounter settings with targets",
+			pack: `{"encounters": [{"path": "Test/Fight", "targets": [{"path": "SoD/Level 60"}], "settings": {"targets": [{"level": 63}]}}]}`,
+			err:  "settings can't have targets",
+		},
+		{
+			name: "unknown target reference",
+			pack: `{"encounters": [{"path": "Test/Fight", "targets": [{"path": "Test/Missing"}]}]}`,
+			err:  `no preset target with path "Test/Missing"`,
+		},
+		{
+			name: "invalid inline target",
+			pack: `{"encounters": [{"path": "Test/Fight", "targets": [{"target": {"name": "Boss"}}]}]}`,
+			err:  `encounters[0] "Test/Fight": targets[0]: invalid level`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parsePresetPack([]byte(test.pack))
+			if test.err == "" && err != nil {
+				t.Fatalf("Expected a valid pack, got %s", err)
+			}
+			if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+				t.Fatalf("Expected an error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestPresetPackTargetReferences(t *testing.T) {
+	pack, err := parsePresetPack([]byte(`{
+		"targets": [{"path": "Test/Boss", "target": {"level": 63}}],
+		"encounters": [{
+			"path": "Test/Fight",
+			"targets": [
+				{"path": "Test/Boss"},
+				{"path": "SoD/Level 60"},
+				{"target": {"name": "Add", "level": 62}},
+			],
+		}],
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The target name is filled in from the end of its path.
+	packTarget := pack.Targets[0].Target
+	if packTarget.Name != "Boss" {
+		t.Fatalf("Expected the target to be named from its path, got %q", packTarget.Name)
+	}
+
+	targets := pack.Encounters[0].Targets
+	if targets[0].Target == nil || targets[0].Target == packTarget || targets[0].Target.Level != 63 {
+		t.Fatalf("Expected a copy of the pack's target")
+	}
+	builtIn := core.GetPresetTargetWithPath("SoD/Level 60").Config
+	if targets[1].Target == nil || targets[1].Target == builtIn || targets[1].Target.Name != builtIn.Name {
+		t.Fatalf("Expected a copy of the built in target")
+	}
+	if targets[2].Path != "Test/Fight/Add" {
+		t.Fatalf("Expected inline targets to get a path below the encounter, got %q", targets[2].Path)
+	}
+}
+
+func TestLoadPresetPacks(t *testing.T) {
+	dir := t.TempDir()
+	writePack := func(name, contents string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writePack("a.json", `{"targets": [{"path": "Load Test/Boss", "target": {"level": 63}}]}`)
+	writePack("b.hujson", `{"encounters": [{"path": "Load Test/Fight", "targets": [{"path": "Load Test/Boss"}],},],}`)
+	writePack("notes.txt", `not a pack`)
+	if err := LoadPresetPacks(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	// Packs are loaded in file name order, so later packs can refer to earlier ones.
+	if core.GetPresetTargetWithPath("Load Test/Boss") == nil || core.GetPresetEncounterWithPath("Load Test/Fight") == nil {
+		t.Fatalf("Expected the packs to be registered")
+	}
+
+	// Loading the same packs again fails on the duplicate paths.
+	if err := LoadPresetPacks(dir); err == nil || !strings.Contains(err.Error(), "a.json") {
+		t.Fatalf("Expected an error naming the invalid pack, got %v", err)
+	}
+	if err := LoadPresetPacks(filepath.Join(dir, "missing")); err == nil {
+		t.Fatalf("Expected an error for a missing directory")
+	}
+}
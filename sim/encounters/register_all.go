@@ -16,6 +16,7 @@ func init() {
 	addGnomereganMechanical("SoD")
 	addLevel40("SoD")
 	addLevel25("SoD")
+	addFightStyles()
 }
 
 func AddSingleTargetBossEncounter(presetTarget *core.PresetTarget) {
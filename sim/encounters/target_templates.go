@@ -0,0 +1,22 @@
+package encounters
+
+import (
+	"log"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Registers a preset target generated from an NPC's level and classification, and a
+// single target encounter for it.
+func AddTargetTemplateEncounter(pathPrefix string, request *proto.TargetTemplateRequest) {
+	target, err := core.NewTargetTemplate(request)
+	if err != nil {
+		log.Fatalf("Failed to generate target template for NPC %d: %s", request.NpcId, err)
+	}
+
+	AddSingleTargetBossEncounter(&core.PresetTarget{
+		PathPrefix: pathPrefix,
+		Config:     target,
+	})
+}
@@ -75,7 +75,7 @@ func (hunter *Hunter) registerExplosiveShotSpell() {
 
 				if result.Landed() {
 					curTarget := target
-					for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
+					for hitIndex := int32(0); hitIndex < min(numHits, sim.GetNumTargets()); hitIndex++ {
 						if curTarget != target {
 							baseDamage = sim.Roll(baseLowDamage, baseHighDamage) + 0.039*spell.RangedAttackPower(curTarget, false)
 							spell.CalcAndDealDamage(sim, curTarget, baseDamage, spell.OutcomeRangedCritOnly)
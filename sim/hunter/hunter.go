@@ -173,6 +173,10 @@ func (hunter *Hunter) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 func (hunter *Hunter) AddPartyBuffs(_ *proto.PartyBuffs) {
 }
 
+func (hunter *Hunter) RemoveSimulatedBuffs(_ *proto.RaidBuffs, _ *proto.PartyBuffs, debuffs *proto.Debuffs) {
+	debuffs.HuntersMark = proto.TristateEffect_TristateEffectMissing
+}
+
 func (hunter *Hunter) Initialize() {
 	hunter.OnSpellRegistered(func(spell *core.Spell) {
 		if spell.Matches(ClassSpellMask_HunterShots) {
@@ -481,10 +481,13 @@ func init() {
 				maxMultishotTargetsPerCast = min(sim.Environment.GetNumTargets(), 3)
 			},
 			OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+				if sim.GetNumTargets() == 0 {
+					return
+				}
 				// Uses same targeting code as multi-shot however the detonations occur at cast time rather than when the shots land
 				if spell.Matches(ClassSpellMask_HunterMultiShot) {
 					curTarget := sim.Environment.Encounter.TargetUnits[0]
-					for hitIndex := int32(0); hitIndex < maxMultishotTargetsPerCast; hitIndex++ {
+					for hitIndex := int32(0); hitIndex < min(maxMultishotTargetsPerCast, sim.GetNumTargets()); hitIndex++ {
 						arcaneDetonation.Cast(sim, curTarget)
 						curTarget = sim.Environment.NextTargetUnit(curTarget)
 					}
@@ -492,7 +495,7 @@ func init() {
 				// 1 explosion per target up to 5 targets per carve cast
 				if spell.Matches(ClassSpellMask_HunterCarve) {
 					curTarget := sim.Environment.Encounter.TargetUnits[0]
-					for hitIndex := int32(0); hitIndex < maxCarveTargetsPerCast; hitIndex++ {
+					for hitIndex := int32(0); hitIndex < min(maxCarveTargetsPerCast, sim.GetNumTargets()); hitIndex++ {
 						arcaneDetonation.Cast(sim, curTarget)
 						curTarget = sim.Environment.NextTargetUnit(curTarget)
 					}
@@ -78,7 +78,7 @@ func (paladin *Paladin) registerAvengersShield() {
 
 			} else {
 				interTargetTravelTime := int(float64(time.Second) * 3.0 / spell.MissileSpeed)
-				for i := 0; i < numTargets; i++ {
+				for i := 0; i < min(numTargets, int(sim.GetNumTargets())); i++ {
 					// Avenger's Shield bounces from target 1 > target 2 > target 3 at MissileSpeed.
 					// We approximate it by assuming targets are standing ~3 yds apart from each other.
 					// The damage for each target is therefore scheduled to arrive at:
@@ -48,7 +48,7 @@ func (paladin *Paladin) registerDivineStorm() {
 		ThreatMultiplier: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			results := make([]*core.SpellResult, numTargets)
+			results := make([]*core.SpellResult, min(numTargets, sim.GetNumTargets()))
 
 			for idx := range results {
 				baseDamage := spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower())
@@ -46,6 +46,7 @@ func (paladin *Paladin) registerHammerOfTheRighteous() {
 		ThreatMultiplier: 2, // verified with TinyThreat in game
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			results := core.LimitToSpawnedTargets(sim, results)
 			weapon := paladin.AutoAttacks.MH()
 			baseDamage := 3.0 * (weapon.CalculateAverageWeaponDamage(spell.MeleeAttackPower()) / weapon.SwingSpeed)
 
@@ -0,0 +1,53 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Taunts the target to attack you. If the target is tauntable and not currently
+// targeting you, causes 1 + 50% of your spell power as Holy damage.
+func (paladin *Paladin) registerHandOfReckoning() {
+	if !paladin.hasRune(proto.PaladinRune_RuneHandsHandOfReckoning) {
+		return
+	}
+
+	paladin.handOfReckoning = paladin.GetOrRegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: int32(proto.PaladinRune_RuneHandsHandOfReckoning)},
+		SpellSchool: core.SpellSchoolHoly,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagAPL,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.03,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    paladin.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		BonusCoefficient: 0.5,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			if target.CurrentTarget == &paladin.Unit || target.CurrentTarget == nil {
+				spell.CalcAndDealOutcome(sim, target, spell.OutcomeMagicHit)
+				return
+			}
+
+			result := spell.CalcAndDealDamage(sim, target, 1, spell.OutcomeMagicHitAndCrit)
+			if result.Landed() {
+				paladin.TauntTarget(sim, target)
+			}
+		},
+	})
+}
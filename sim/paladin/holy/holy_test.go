@@ -0,0 +1,74 @@
+package holy
+
+import (
+	"testing"
+
+	_ "github.com/wowsims/sod/sim/common" // imported to get caster sets included.
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func init() {
+	RegisterHolyPaladin()
+}
+
+func TestHoly(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:    proto.Class_ClassPaladin,
+			Phase:    6,
+			Level:    60,
+			Race:     proto.Race_RaceHuman,
+			IsHealer: true,
+
+			GearSet:     core.GetGearSet("../../../ui/holy_paladin/gear_sets", "blank"),
+			Talents:     StandardTalents,
+			Buffs:       core.FullBuffsPhase6,
+			Consumes:    FullConsumes,
+			SpecOptions: core.SpecOptionsCombo{Label: "Standard", SpecOptions: PlayerOptionsStandard},
+			Rotation:    core.RotationCombo{Label: "Default", Rotation: DefaultRotation},
+
+			ItemFilter: core.ItemFilter{
+				WeaponTypes: []proto.WeaponType{
+					proto.WeaponType_WeaponTypeAxe,
+					proto.WeaponType_WeaponTypeMace,
+					proto.WeaponType_WeaponTypeOffHand,
+					proto.WeaponType_WeaponTypePolearm,
+					proto.WeaponType_WeaponTypeShield,
+					proto.WeaponType_WeaponTypeSword,
+				},
+				ArmorType: proto.ArmorType_ArmorTypePlate,
+				RangedWeaponTypes: []proto.RangedWeaponType{
+					proto.RangedWeaponType_RangedWeaponTypeLibram,
+				},
+			},
+		},
+	}))
+}
+
+var StandardTalents = "05503121521051"
+
+var PlayerOptionsStandard = &proto.Player_HolyPaladin{
+	HolyPaladin: &proto.HolyPaladin{
+		Options: &proto.PaladinOptions{
+			Aura: proto.PaladinAura_DevotionAura,
+		},
+	},
+}
+
+var FullConsumes = core.ConsumesCombo{
+	Label: "Full Consumes",
+	Consumes: &proto.Consumes{
+		Flask: proto.Flask_FlaskOfDistilledWisdom,
+		Food:  proto.Food_FoodNightfinSoup,
+	},
+}
+
+var DefaultRotation = core.APLRotationFromJsonString(`{
+	"type": "TypeAPL",
+	"priorityList": [
+		{"action":{"autocastOtherCooldowns":{}}},
+		{"action":{"condition":{"cmp":{"op":"OpGt","lhs":{"missingHealth":{"sourceUnit":{"type":"LowestHealthAlly"}}},"rhs":{"const":{"val":"1500"}}}},"castSpell":{"spellId":{"spellId":25292},"target":{"type":"LowestHealthAlly"}}}},
+		{"action":{"castSpell":{"spellId":{"spellId":19943},"target":{"type":"LowestHealthAlly"}}}}
+	]
+}`)
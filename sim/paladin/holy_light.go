@@ -0,0 +1,69 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+)
+
+func (paladin *Paladin) registerHolyLight() {
+	ranks := []struct {
+		level      int32
+		spellID    int32
+		manaCost   float64
+		minHealing float64
+		maxHealing float64
+	}{
+		{level: 1, spellID: 635, manaCost: 35, minHealing: 39, maxHealing: 47},
+		{level: 6, spellID: 639, manaCost: 60, minHealing: 76, maxHealing: 90},
+		{level: 14, spellID: 647, manaCost: 110, minHealing: 159, maxHealing: 187},
+		{level: 22, spellID: 1026, manaCost: 190, minHealing: 310, maxHealing: 356},
+		{level: 30, spellID: 1042, manaCost: 275, minHealing: 491, maxHealing: 553},
+		{level: 38, spellID: 3472, manaCost: 365, minHealing: 698, maxHealing: 780},
+		{level: 46, spellID: 10328, manaCost: 465, minHealing: 945, maxHealing: 1053},
+		{level: 54, spellID: 10329, manaCost: 580, minHealing: 1246, maxHealing: 1388},
+		{level: 60, spellID: 25292, manaCost: 660, minHealing: 1590, maxHealing: 1770},
+	}
+
+	for i, rank := range ranks {
+		rank := rank
+		if paladin.Level < rank.level {
+			break
+		}
+
+		spell := paladin.RegisterSpell(core.SpellConfig{
+			ActionID:    core.ActionID{SpellID: rank.spellID},
+			SpellSchool: core.SpellSchoolHoly,
+			DefenseType: core.DefenseTypeMagic,
+			ProcMask:    core.ProcMaskSpellHealing,
+			Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
+
+			RequiredLevel: int(rank.level),
+			Rank:          i + 1,
+
+			ClassSpellMask: ClassSpellMask_PaladinHolyLight,
+
+			ManaCost: core.ManaCostOptions{
+				FlatCost: rank.manaCost,
+			},
+
+			Cast: core.CastConfig{
+				DefaultCast: core.Cast{
+					GCD:      core.GCDDefault,
+					CastTime: time.Millisecond * 2500,
+				},
+			},
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+
+			BonusCoefficient: 0.714,
+
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				spell.CalcAndDealHealing(sim, target, sim.Roll(rank.minHealing, rank.maxHealing), spell.OutcomeHealingCrit)
+			},
+		})
+
+		paladin.holyLight = append(paladin.holyLight, spell)
+	}
+}
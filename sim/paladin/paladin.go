@@ -45,6 +45,8 @@ const (
 
 	ClassSpellMask_PaladinDivineProtection
 	ClassSpellMask_PaladinAvengingWrath
+	ClassSpellMask_PaladinHolyLight
+	ClassSpellMask_PaladinFlashOfLight
 
 	ClassSpellMask_PaladinAll = 1<<iota - 1
 
@@ -96,6 +98,7 @@ type Paladin struct {
 	divineStorm       *core.Spell
 	exorcism          []*core.Spell
 	judgement         *core.Spell
+	handOfReckoning   *core.Spell
 	layOnHands        *core.Spell
 	rv                *core.Spell
 	holyShieldAura    [3]*core.Aura
@@ -103,6 +106,8 @@ type Paladin struct {
 	redoubtAura       *core.Aura
 	holyWrath         []*core.Spell
 	divineProtection  *core.Spell
+	holyLight         []*core.Spell
+	flashOfLight      []*core.Spell
 
 	// highest rank seal spell if available
 	sealOfRighteousness *core.Spell
@@ -154,7 +159,7 @@ func (paladin *Paladin) performStopAttack(sim *core.Simulation, target *core.Uni
 
 	if paladin.Options.IsUsingManualStartAttack {
 		core.StartDelayedAction(sim, core.DelayedActionOptions{
-			DoAt:     sim.CurrentTime + time.Millisecond*time.Duration(sim.RollWithLabel(50, 100, "Start attack delay")),
+			DoAt:     sim.CurrentTime + time.Millisecond*time.Duration(sim.RollWithLabel(50, 100, "Start attack delay")) + paladin.NetworkLatency(sim),
 			Priority: core.ActionPriorityAuto,
 			OnAction: func(sim *core.Simulation) {
 				paladin.AutoAttacks.EnableAutoSwing(sim)
@@ -188,7 +193,7 @@ func (paladin *Paladin) registerStartAndStopAttacks() {
 			spell.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 				oldApplyEffects(sim, target, spell)
 				if !paladin.bypassMacroOptions && sim.CurrentTime > 0 {
-					paladin.AutoAttacks.EnableAutoSwing(sim)
+					paladin.AutoAttacks.StartAttack(sim)
 				}
 			}
 		}
@@ -232,6 +237,7 @@ func (paladin *Paladin) Initialize() {
 	paladin.registerShieldOfRighteousness()
 	paladin.registerBlessingOfSanctuary()
 	paladin.registerLayOnHands()
+	paladin.registerHandOfReckoning()
 
 	paladin.enableMultiJudge = false // Was previously true in Phase 4 but disabled in Phase 5
 	paladin.lingerDuration = time.Millisecond * 400
@@ -244,6 +250,12 @@ func (paladin *Paladin) Initialize() {
 	paladin.ResetPrimarySeal(paladin.Options.PrimarySeal)
 }
 
+// Registers the direct heals, which only the healing spec uses.
+func (paladin *Paladin) RegisterHealingSpells() {
+	paladin.registerHolyLight()
+	paladin.registerFlashOfLight()
+}
+
 func (paladin *Paladin) Reset(_ *core.Simulation) {
 	paladin.ResetCurrentPaladinAura()
 	paladin.ResetPrimarySeal(paladin.Options.PrimarySeal)
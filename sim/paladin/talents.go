@@ -42,6 +42,7 @@ func (paladin *Paladin) ApplyTalents() {
 	paladin.applyRedoubt()
 	paladin.applyReckoning()
 	paladin.applyImprovedLayOnHands()
+	paladin.applyHealingLight()
 }
 
 func (paladin *Paladin) improvedSoR() float64 {
@@ -234,3 +235,15 @@ func (paladin *Paladin) applyImprovedLayOnHands() {
 		})
 	}
 }
+
+func (paladin *Paladin) applyHealingLight() {
+	if paladin.Talents.HealingLight == 0 {
+		return
+	}
+
+	paladin.AddStaticMod(core.SpellModConfig{
+		Kind:      core.SpellMod_DamageDone_Flat,
+		ClassMask: ClassSpellMask_PaladinHolyLight | ClassSpellMask_PaladinFlashOfLight,
+		IntValue:  int64(4 * paladin.Talents.HealingLight),
+	})
+}
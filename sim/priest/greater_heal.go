@@ -1,34 +1,68 @@
 package priest
 
-// func (priest *Priest) registerGreaterHealSpell() {
-// 	spellCoeff := 1.6114
-
-// 	priest.GreaterHeal = priest.RegisterSpell(core.SpellConfig{
-// 		ActionID:    core.ActionID{SpellID: 48063},
-// 		SpellSchool: core.SpellSchoolHoly,
-//      DefenseType: core.DefenseTypeMagic,
-// 		ProcMask:    core.ProcMaskSpellHealing,
-// 		Flags:       SpellFlagPriest | core.SpellFlagHelpful | core.SpellFlagAPL,
-
-// 		ManaCost: core.ManaCostOptions{
-// 			BaseCost:   0.32,
-// 			Multiplier: 1 - .05*float64(priest.Talents.ImprovedHealing),
-// 		},
-// 		Cast: core.CastConfig{
-// 			DefaultCast: core.Cast{
-// 				GCD:      core.GCDDefault,
-// 				CastTime: time.Second*3 - time.Millisecond*100*time.Duration(priest.Talents.DivineFury),
-// 			},
-// 		},
-
-// 		BonusCritRating:  float64(priest.Talents.HolySpecialization) * 1 * core.CritRatingPerCritChance,
-
-// 		DamageMultiplier: 1 + .02*float64(priest.Talents.SpiritualHealing),
-// 		ThreatMultiplier: 1 - []float64{0, .07, .14, .20}[priest.Talents.SilentResolve],
-
-// 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-// 			baseHealing := sim.Roll(3980, 4621) + spellCoeff*spell.HealingPower(target)
-// 			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
-// 		},
-// 	})
-// }
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+)
+
+const GreaterHealRanks = 5
+
+var GreaterHealSpellId = [GreaterHealRanks + 1]int32{0, 2060, 10963, 10964, 10965, 25314}
+var GreaterHealBaseHealing = [GreaterHealRanks + 1][]float64{{0}, {924, 1039}, {1178, 1318}, {1470, 1642}, {1813, 2021}, {1966, 2194}}
+var GreaterHealManaCost = [GreaterHealRanks + 1]float64{0, 370, 455, 545, 655, 710}
+var GreaterHealLevel = [GreaterHealRanks + 1]int{0, 40, 46, 52, 58, 60}
+
+const GreaterHealSpellCoef = 0.857
+
+func (priest *Priest) registerGreaterHealSpell() {
+	priest.GreaterHeal = make([]*core.Spell, GreaterHealRanks+1)
+
+	for rank := 1; rank <= GreaterHealRanks; rank++ {
+		config := priest.getGreaterHealBaseConfig(rank)
+
+		if config.RequiredLevel <= int(priest.Level) {
+			priest.GreaterHeal[rank] = priest.GetOrRegisterSpell(config)
+		}
+	}
+}
+
+func (priest *Priest) getGreaterHealBaseConfig(rank int) core.SpellConfig {
+	spellId := GreaterHealSpellId[rank]
+	baseHealingLow := GreaterHealBaseHealing[rank][0]
+	baseHealingHigh := GreaterHealBaseHealing[rank][1]
+	manaCost := GreaterHealManaCost[rank]
+	level := GreaterHealLevel[rank]
+
+	return core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: spellId},
+		ClassSpellMask: ClassSpellMask_PriestGreaterHeal,
+		SpellSchool:    core.SpellSchoolHoly,
+		DefenseType:    core.DefenseTypeMagic,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+
+		RequiredLevel: level,
+		Rank:          rank,
+
+		ManaCost: core.ManaCostOptions{
+			FlatCost:   manaCost,
+			Multiplier: 100 - 5*priest.Talents.ImprovedHealing,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second*3 - time.Millisecond*100*time.Duration(priest.Talents.DivineFury),
+			},
+		},
+
+		BonusCoefficient: GreaterHealSpellCoef,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, sim.Roll(baseHealingLow, baseHealingHigh), spell.OutcomeHealingCrit)
+		},
+	}
+}
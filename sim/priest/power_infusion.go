@@ -1,52 +1,43 @@
 package priest
 
-// "time"
-
-// "github.com/wowsims/sod/sim/core"
+import (
+	"github.com/wowsims/sod/sim/core"
+)
 
+// Only cast through raid cooldown assignments.
 func (priest *Priest) registerPowerInfusionCD() {
-	if !priest.Talents.PowerInfusion {
+	actionID := core.PowerInfusionActionID.WithTag(priest.Index)
+	if !priest.Talents.PowerInfusion || !priest.HasCooldownAssignment(actionID) {
 		return
 	}
 
-	// actionID := core.ActionID{SpellID: 10060, Tag: priest.Index}
-
-	// powerInfusionTarget := priest.GetUnit(priest.SelfBuffs.PowerInfusionTarget)
-	// if powerInfusionTarget == nil {
-	// 	return
-	// }
-	// powerInfusionAura := core.PowerInfusionAura(powerInfusionTarget, actionID.Tag)
-
-	// piSpell := priest.RegisterSpell(core.SpellConfig{
-	// 	ActionID: actionID,
-	// 	Flags:    SpellFlagPriest | core.SpellFlagHelpful,
-
-	// 	ManaCost: core.ManaCostOptions{
-	// 		BaseCost: 0.16,
-	// 	},
-	// 	Cast: core.CastConfig{
-	// 		CD: core.Cooldown{
-	// 			Timer:    priest.NewTimer(),
-	// 			Duration: time.Duration(float64(core.PowerInfusionCD)),
-	// 		},
-	// 	},
-
-	// 	ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
-	// 		powerInfusionAura.Activate(sim)
-	// 	},
-	// })
-
-	// priest.AddMajorCooldown(core.MajorCooldown{
-	// 	Spell:    piSpell,
-	// 	Priority: core.CooldownPriorityBloodlust,
-	// 	Type:     core.CooldownTypeMana,
-	// 	ShouldActivate: func(sim *core.Simulation, character *core.Character) bool {
-	// 		// How can we determine the target will be able to continue casting
-	// 		// 	for the next 15s at 20% reduced mana cost? Arbitrary value until then.
-	// 		//if powerInfusionTarget.CurrentMana() < 3000 {
-	// 		//	return false
-	// 		//}
-	// 		return !powerInfusionTarget.HasActiveAura("Bloodlust-" + core.BloodlustActionID.WithTag(-1).String())
-	// 	},
-	// })
+	priest.PowerInfusionAuras = priest.NewRaidAuraArray(func(unit *core.Unit) *core.Aura {
+		return core.PowerInfusionAura(unit, actionID.Tag)
+	})
+
+	priest.PowerInfusion = priest.RegisterSpell(core.SpellConfig{
+		ActionID: actionID,
+		Flags:    core.SpellFlagHelpful,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.16,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: core.PowerInfusionCD,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return !target.HasActiveAuraWithTag(core.PowerInfusionAuraTag)
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
+			priest.PowerInfusionAuras.Get(target).Activate(sim)
+		},
+	})
 }
@@ -21,6 +21,7 @@ const (
 	ClassSpellMask_PriestMindFlay
 	ClassSpellMask_PriestMindSear
 	ClassSpellMask_PriestMindSpike
+	ClassSpellMask_PriestRenew
 	ClassSpellMask_PriestShadowWordPain
 	ClassSpellMask_PriestShadowWordDeath
 	ClassSpellMask_PriestSmite
@@ -38,6 +39,9 @@ const (
 
 	ClassSpellMask_PriestAll = 1<<iota - 1
 
+	ClassSpellMask_PriestHealingSpells = ClassSpellMask_PriestFlashHeal | ClassSpellMask_PriestGreaterHeal | ClassSpellMask_PriestHeal |
+		ClassSpellMask_PriestRenew | ClassSpellMask_PriestPenanceHeal
+
 	PriestSpellInstant = ClassSpellMask_PriestDevouringPlague | ClassSpellMask_PriestMindFlay | ClassSpellMask_PriestMindSear |
 		ClassSpellMask_PriestShadowWordPain | ClassSpellMask_PriestShadowWordDeath | ClassSpellMask_PriestVoidPlague | ClassSpellMask_PriestVoidZone |
 		ClassSpellMask_PriestDispersion | ClassSpellMask_PriestShadowFiend | ClassSpellMask_PriestVampiricEmbrace | ClassSpellMask_PriestPenance
@@ -77,6 +81,7 @@ type Priest struct {
 	MindSpike         *core.Spell
 	Penance           *core.Spell
 	PenanceHeal       *core.Spell
+	PowerInfusion     *core.Spell
 	PowerWordShield   []*core.Spell
 	PrayerOfHealing   []*core.Spell
 	PrayerOfMending   *core.Spell
@@ -102,6 +107,7 @@ type Priest struct {
 	SurgeOfLightAura *core.Aura
 
 	MindSpikeAuras       core.AuraArray
+	PowerInfusionAuras   core.AuraArray
 	ShadowWeavingAuras   core.AuraArray
 	VampiricEmbraceAuras core.AuraArray
 	WeakenedSouls        core.AuraArray
@@ -132,6 +138,12 @@ func (priest *Priest) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 func (priest *Priest) AddPartyBuffs(_ *proto.PartyBuffs) {
 }
 
+func (priest *Priest) RemoveSimulatedBuffs(_ *proto.RaidBuffs, _ *proto.PartyBuffs, debuffs *proto.Debuffs) {
+	if priest.Talents.ShadowWeaving > 0 {
+		debuffs.ShadowWeaving = false
+	}
+}
+
 func (priest *Priest) Initialize() {
 	priest.registerMindBlast()
 	priest.registerMindFlay()
@@ -154,11 +166,11 @@ func (priest *Priest) Initialize() {
 }
 
 func (priest *Priest) RegisterHealingSpells() {
-	// priest.registerFlashHealSpell()
-	// priest.registerGreaterHealSpell()
+	priest.registerFlashHealSpell()
+	priest.registerGreaterHealSpell()
 	// priest.registerPowerWordShieldSpell()
 	// priest.registerPrayerOfHealingSpell()
-	// priest.registerRenewSpell()
+	priest.registerRenewSpell()
 }
 
 func (priest *Priest) Reset(_ *core.Simulation) {
@@ -86,6 +86,7 @@ func (priest *Priest) getShadowWordPainConfig(rank int) core.SpellConfig {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			results := core.LimitToSpawnedTargets(sim, results)
 			for idx := range results {
 				results[idx] = spell.CalcOutcome(sim, target, spell.OutcomeMagicHitNoHitCounter)
 				target = sim.Environment.NextTargetUnit(target)
@@ -31,6 +31,8 @@ func (priest *Priest) ApplyTalents() {
 	priest.applyInspiration()
 	priest.applyHolySpecialization()
 	priest.applySearingLight()
+	priest.applyImprovedRenew()
+	priest.applySpiritualHealing()
 
 	priest.PseudoStats.SchoolDamageTakenMultiplier.MultiplyMagicSchools(1 - 0.02*float64(priest.Talents.SpellWarding))
 
@@ -134,6 +136,30 @@ func (priest *Priest) applySearingLight() {
 	})
 }
 
+func (priest *Priest) applyImprovedRenew() {
+	if priest.Talents.ImprovedRenew == 0 {
+		return
+	}
+
+	priest.AddStaticMod(core.SpellModConfig{
+		Kind:      core.SpellMod_DamageDone_Flat,
+		ClassMask: ClassSpellMask_PriestRenew,
+		IntValue:  int64(5 * priest.Talents.ImprovedRenew),
+	})
+}
+
+func (priest *Priest) applySpiritualHealing() {
+	if priest.Talents.SpiritualHealing == 0 {
+		return
+	}
+
+	priest.AddStaticMod(core.SpellModConfig{
+		Kind:      core.SpellMod_DamageDone_Flat,
+		ClassMask: ClassSpellMask_PriestHealingSpells,
+		IntValue:  int64(2 * priest.Talents.SpiritualHealing),
+	})
+}
+
 func (priest *Priest) applySpiritTap() {
 	if priest.Talents.SpiritTap == 0 {
 		return
@@ -44,6 +44,7 @@ func (rogue *Rogue) registerBlunderbussSpell() {
 		ThreatMultiplier: 2,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			results := core.LimitToSpawnedTargets(sim, results)
 			rogue.BreakStealth(sim)
 			baseApDamage := spell.MeleeAttackPower() * 0.48
 
@@ -44,6 +44,7 @@ func (rogue *Rogue) registerShurikenTossSpell() {
 		ThreatMultiplier: core.TernaryFloat64(hasJustAFleshWound, 1.5, 1),
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			results := core.LimitToSpawnedTargets(sim, results)
 			rogue.BreakStealth(sim)
 			baseDamage := spell.MeleeAttackPower() * 0.50
 			var combopoints int32 = 0
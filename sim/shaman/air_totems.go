@@ -33,19 +33,31 @@ func (shaman *Shaman) registerWindfuryTotemSpell() {
 
 func (shaman *Shaman) newWindfuryTotemSpellConfig(rank int) core.SpellConfig {
 	spellId := WindfuryTotemSpellId[rank]
-	// TODO: The sim won't respect the value of a totem dropped via the APL. It uses hard-coded values from buffs.go
-	// bonusDamage := WindfuryTotemBonusDamage[rank]
 	manaCost := WindfuryTotemManaCost[rank]
 	level := WindfuryTotemLevel[rank]
 
 	duration := time.Second * 120
 
+	// Without simulated raid buffs, the party's Windfury comes from the static buff toggles.
+	var windfuryTotemAuras core.AuraArray
+	if shaman.Env.Raid.SimulateRaidBuffs {
+		windfuryTotemAuras = shaman.NewPartyAuraArray(func(unit *core.Unit) *core.Aura {
+			return core.WindfuryTotemAura(unit, duration)
+		})
+	}
+
 	spell := shaman.newTotemSpellConfig(ClassSpellMask_ShamanWindFuryTotem, spellId, manaCost)
 	spell.RequiredLevel = level
 	spell.Rank = rank
 	spell.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-		shaman.TotemExpirations[EarthTotem] = sim.CurrentTime + duration
-		shaman.ActiveTotems[EarthTotem] = spell
+		shaman.TotemExpirations[AirTotem] = sim.CurrentTime + duration
+		shaman.ActiveTotems[AirTotem] = spell
+
+		for _, aura := range windfuryTotemAuras {
+			if aura != nil {
+				aura.Activate(sim)
+			}
+		}
 	}
 	return spell
 }
@@ -83,10 +95,11 @@ func (shaman *Shaman) newGraceOfAirTotemSpellConfig(rank int) core.SpellConfig {
 
 	hasFeralSpirit := shaman.HasRune(proto.ShamanRune_RuneCloakFeralSpirit)
 
-	graceOfAirTotemAuras := make([]*core.Aura, core.TernaryInt32(hasFeralSpirit, 2, 1))
-	graceOfAirTotemAuras[0] = core.GraceOfAirTotemAura(&shaman.Unit, shaman.Level, multiplier, core.CharacterBuildPhaseNone)
+	graceOfAirTotemAuras := shaman.NewPartyAuraArray(func(unit *core.Unit) *core.Aura {
+		return core.GraceOfAirTotemAura(unit, shaman.Level, multiplier, core.CharacterBuildPhaseNone)
+	})
 	if hasFeralSpirit {
-		graceOfAirTotemAuras[1] = core.GraceOfAirTotemAura(&shaman.SpiritWolves.Unit, shaman.Level, multiplier, core.CharacterBuildPhaseNone)
+		graceOfAirTotemAuras = append(graceOfAirTotemAuras, core.GraceOfAirTotemAura(&shaman.SpiritWolves.Unit, shaman.Level, multiplier, core.CharacterBuildPhaseNone))
 	}
 
 	spell := shaman.newTotemSpellConfig(ClassSpellMask_ShamanGraceOfAirTotem, spellId, manaCost)
@@ -1,6 +1,7 @@
 package shaman
 
 import (
+	"slices"
 	"time"
 
 	"github.com/wowsims/sod/sim/core"
@@ -91,11 +92,10 @@ func (shaman *Shaman) newChainHealSpellConfig(rank int, isOverload bool) core.Sp
 		BonusCoefficient: spellCoeff,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			targets := sim.Environment.Raid.GetFirstNPlayersOrPets(targetCount)
-			curTarget := targets[0]
+			hitTargets := make([]*core.Unit, 0, targetCount)
+			curTarget := target
 			origMult := spell.GetDamageMultiplier()
-			// TODO: This bounces to most hurt friendly...
-			for hitIndex := 0; hitIndex < len(targets); hitIndex++ {
+			for curTarget != nil {
 				originalDamageMultiplier := spell.GetDamageMultiplier()
 				if hasRiptideRune && !isOverload && shaman.Riptide.Hot(curTarget).IsActive() {
 					spell.ApplyMultiplicativeDamageBonus(1.25)
@@ -109,7 +109,11 @@ func (shaman *Shaman) newChainHealSpellConfig(rank int, isOverload bool) core.Sp
 				}
 
 				spell.ApplyMultiplicativeDamageBonus(bounceCoef)
-				curTarget = targets[hitIndex]
+				hitTargets = append(hitTargets, curTarget)
+				if int32(len(hitTargets)) == targetCount {
+					break
+				}
+				curTarget = chainHealNextTarget(sim, hitTargets)
 			}
 			spell.SetMultiplicativeDamageBonus(origMult)
 		},
@@ -121,3 +125,17 @@ func (shaman *Shaman) newChainHealSpellConfig(rank int, isOverload bool) core.Sp
 
 	return spell
 }
+
+// Chain Heal jumps to the most injured ally which hasn't been healed by this cast yet.
+func chainHealNextTarget(sim *core.Simulation, hitTargets []*core.Unit) *core.Unit {
+	var next *core.Unit
+	for _, unit := range sim.Raid.AllPlayerUnits {
+		if !unit.HasHealthBar() || unit.CurrentHealth() >= unit.MaxHealth() || slices.Contains(hitTargets, unit) {
+			continue
+		}
+		if next == nil || unit.CurrentHealthPercent() < next.CurrentHealthPercent() {
+			next = unit
+		}
+	}
+	return next
+}
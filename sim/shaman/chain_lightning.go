@@ -85,7 +85,7 @@ func (shaman *Shaman) newChainLightningSpellConfig(rank int, cdTimer *core.Timer
 
 	spell.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 		origMult := spell.GetDamageMultiplier()
-		for i := 0; i < numTargets; i++ {
+		for i := 0; i < min(numTargets, int(sim.GetNumTargets())); i++ {
 			baseDamage := sim.Roll(baseDamageLow, baseDamageHigh)
 			result := spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
 			if result.Landed() && shaman.procOverload(sim, "Chain Lightning Overload", overloadProcChance) {
@@ -40,10 +40,11 @@ func (shaman *Shaman) newStrengthOfEarthTotemSpellConfig(rank int) core.SpellCon
 
 	hasFeralSpirit := shaman.HasRune(proto.ShamanRune_RuneCloakFeralSpirit)
 
-	strengthOfEarthTotemAuras := make([]*core.Aura, core.TernaryInt32(hasFeralSpirit, 2, 1))
-	strengthOfEarthTotemAuras[0] = core.StrengthOfEarthTotemAura(&shaman.Unit, shaman.Level, multiplier, core.CharacterBuildPhaseNone)
+	strengthOfEarthTotemAuras := shaman.NewPartyAuraArray(func(unit *core.Unit) *core.Aura {
+		return core.StrengthOfEarthTotemAura(unit, shaman.Level, multiplier, core.CharacterBuildPhaseNone)
+	})
 	if hasFeralSpirit {
-		strengthOfEarthTotemAuras[1] = core.StrengthOfEarthTotemAura(&shaman.SpiritWolves.Unit, shaman.Level, multiplier, core.CharacterBuildPhaseNone)
+		strengthOfEarthTotemAuras = append(strengthOfEarthTotemAuras, core.StrengthOfEarthTotemAura(&shaman.SpiritWolves.Unit, shaman.Level, multiplier, core.CharacterBuildPhaseNone))
 	}
 
 	spell := shaman.newTotemSpellConfig(ClassSpellMask_ShamanStrengthOfEarthTotem, spellId, manaCost)
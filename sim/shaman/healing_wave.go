@@ -83,19 +83,17 @@ func (shaman *Shaman) newHealingWaveSpellConfig(rank int, isOverload bool) core.
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			// TODO: Take Healing Way into account 6% stacking up to 3x
-			result := spell.CalcAndDealHealing(sim, spell.Unit, sim.Roll(baseHealingLow, baseHealingHigh), spell.OutcomeHealingCrit)
+			result := spell.CalcAndDealHealing(sim, target, sim.Roll(baseHealingLow, baseHealingHigh), spell.OutcomeHealingCrit)
 
 			if !isOverload && shaman.procOverload(sim, "Healing Wave Overload", 1) {
-				shaman.HealingWaveOverload[rank].Cast(sim, spell.Unit)
+				shaman.HealingWaveOverload[rank].Cast(sim, target)
 			}
 
 			if result.Outcome.Matches(core.OutcomeCrit) {
 				if shaman.HasRune(proto.ShamanRune_RuneFeetAncestralAwakening) {
 					shaman.ancestralHealingAmount = result.Damage * AncestralAwakeningHealMultiplier
 
-					// TODO: this should actually target the lowest health target in the raid.
-					//  does it matter in a sim? We currently only simulate tanks taking damage (multiple tanks could be handled here though.)
-					shaman.AncestralAwakening.Cast(sim, spell.Unit)
+					shaman.AncestralAwakening.Cast(sim, sim.Raid.GetLowestHealthAlly())
 				}
 			}
 		},
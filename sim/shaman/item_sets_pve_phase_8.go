@@ -154,16 +154,28 @@ func (shaman *Shaman) applyScarletEnclaveElemental6PBonus() {
 		return
 	}
 
-	numTargets := shaman.Env.GetNumTargets()
+	damageMod := shaman.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  ClassSpellMask_ShamanChainLightning,
+		Kind:       core.SpellMod_DamageDone_Pct,
+		FloatValue: 1,
+	})
 
 	core.MakePermanent(shaman.RegisterAura(core.Aura{
 		ActionID: core.ActionID{SpellID: 1226978},
 		Label:    label,
-	})).AttachSpellMod(core.SpellModConfig{
-		ClassMask:  ClassSpellMask_ShamanChainLightning,
-		Kind:       core.SpellMod_DamageDone_Pct,
-		FloatValue: 1 + max(0, 0.35*float64(3-numTargets)),
-	})
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			damageMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			damageMod.Deactivate()
+		},
+		// The number of targets can change as adds spawn and die.
+		OnApplyEffects: func(aura *core.Aura, sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			if spell.Matches(ClassSpellMask_ShamanChainLightning) {
+				damageMod.UpdateFloatValue(1 + max(0, 0.35*float64(3-sim.GetNumTargets())))
+			}
+		},
+	}))
 }
 
 var ItemSetTheSoulcrushersRage = core.NewItemSet(core.ItemSet{
@@ -53,6 +53,7 @@ func (shaman *Shaman) applyMoltenBlast() {
 		ThreatMultiplier: 2,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			results := core.LimitToSpawnedTargets(sim, results)
 			for idx := range results {
 				// Molten Blast is a magic ability but scales off of Attack Power
 				baseDamage := sim.Roll(baseDamageLow, baseDamageHigh) + apCoef*spell.MeleeAttackPower()
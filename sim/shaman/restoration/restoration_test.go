@@ -0,0 +1,73 @@
+package restoration
+
+import (
+	"testing"
+
+	_ "github.com/wowsims/sod/sim/common"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func init() {
+	RegisterRestorationShaman()
+}
+
+func TestRestoration(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:    proto.Class_ClassShaman,
+			Phase:    6,
+			Level:    60,
+			Race:     proto.Race_RaceTroll,
+			IsHealer: true,
+
+			GearSet:     core.GetGearSet("../../../ui/restoration_shaman/gear_sets", "blank"),
+			Talents:     StandardTalents,
+			Buffs:       core.FullBuffsPhase6,
+			Consumes:    FullConsumes,
+			SpecOptions: core.SpecOptionsCombo{Label: "Standard", SpecOptions: PlayerOptionsStandard},
+			Rotation:    core.RotationCombo{Label: "Default", Rotation: DefaultRotation},
+
+			ItemFilter: core.ItemFilter{
+				WeaponTypes: []proto.WeaponType{
+					proto.WeaponType_WeaponTypeAxe,
+					proto.WeaponType_WeaponTypeDagger,
+					proto.WeaponType_WeaponTypeFist,
+					proto.WeaponType_WeaponTypeMace,
+					proto.WeaponType_WeaponTypeOffHand,
+					proto.WeaponType_WeaponTypeShield,
+					proto.WeaponType_WeaponTypeStaff,
+				},
+				ArmorType: proto.ArmorType_ArmorTypeMail,
+				RangedWeaponTypes: []proto.RangedWeaponType{
+					proto.RangedWeaponType_RangedWeaponTypeTotem,
+				},
+			},
+		},
+	}))
+}
+
+var StandardTalents = "-5005-510303310053151"
+
+var PlayerOptionsStandard = &proto.Player_RestorationShaman{
+	RestorationShaman: &proto.RestorationShaman{
+		Options: &proto.RestorationShaman_Options{},
+	},
+}
+
+var FullConsumes = core.ConsumesCombo{
+	Label: "Full Consumes",
+	Consumes: &proto.Consumes{
+		Flask: proto.Flask_FlaskOfDistilledWisdom,
+		Food:  proto.Food_FoodNightfinSoup,
+	},
+}
+
+var DefaultRotation = core.APLRotationFromJsonString(`{
+	"type": "TypeAPL",
+	"priorityList": [
+		{"action":{"autocastOtherCooldowns":{}}},
+		{"action":{"condition":{"cmp":{"op":"OpGe","lhs":{"numInjuredAllies":{"healthPercent":80}},"rhs":{"const":{"val":"3"}}}},"castSpell":{"spellId":{"spellId":10623},"target":{"type":"LowestHealthAlly"}}}},
+		{"action":{"castSpell":{"spellId":{"spellId":25357},"target":{"type":"LowestHealthAlly"}}}}
+	]
+}`)
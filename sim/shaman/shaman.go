@@ -252,6 +252,12 @@ func (shaman *Shaman) AddRaidBuffs(_ *proto.RaidBuffs) {
 	// Buffs are handled explicitly through APLs now
 }
 
+func (shaman *Shaman) RemoveSimulatedBuffs(raidBuffs *proto.RaidBuffs, _ *proto.PartyBuffs, _ *proto.Debuffs) {
+	raidBuffs.StrengthOfEarthTotem = proto.TristateEffect_TristateEffectMissing
+	raidBuffs.GraceOfAirTotem = proto.TristateEffect_TristateEffectMissing
+	raidBuffs.ManaSpringTotem = proto.TristateEffect_TristateEffectMissing
+}
+
 func (shaman *Shaman) Initialize() {
 	// Core abilities
 	shaman.registerChainLightningSpell()
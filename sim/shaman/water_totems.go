@@ -112,12 +112,19 @@ func (shaman *Shaman) registerManaSpringTotemSpell() {
 
 func (shaman *Shaman) newManaSpringTotemSpellConfig(rank int) core.SpellConfig {
 	spellId := ManaSpringTotemSpellId[rank]
-	// TODO: The sim won't respect the value of a totem dropped via the APL. It uses hard-coded values from buffs.go
-	// manaRestoreBase := ManaSpringTotemManaRestore[rank]
 	manaCost := ManaSpringTotemManaCost[rank]
 	level := ManaSpringTotemLevel[rank]
 
 	duration := time.Second * 60
+	multiplier := 1 + 0.05*float64(shaman.Talents.RestorativeTotems)
+
+	// Without simulated raid buffs, the party's Mana Spring comes from the static buff toggles.
+	var manaSpringTotemAuras core.AuraArray
+	if shaman.Env.Raid.SimulateRaidBuffs {
+		manaSpringTotemAuras = shaman.NewPartyAuraArray(func(unit *core.Unit) *core.Aura {
+			return core.ManaSpringTotemAura(unit, shaman.Level, multiplier, core.CharacterBuildPhaseNone)
+		})
+	}
 
 	spell := shaman.newTotemSpellConfig(ClassSpellMask_ShamanManaSpringTotem, spellId, manaCost)
 	spell.RequiredLevel = level
@@ -125,6 +132,10 @@ func (shaman *Shaman) newManaSpringTotemSpellConfig(rank int) core.SpellConfig {
 	spell.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
 		shaman.TotemExpirations[WaterTotem] = sim.CurrentTime + duration
 		shaman.ActiveTotems[WaterTotem] = spell
+
+		for _, aura := range manaSpringTotemAuras {
+			aura.Activate(sim)
+		}
 	}
 	return spell
 }
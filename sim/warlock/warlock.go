@@ -226,6 +226,12 @@ func (warlock *Warlock) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 	))
 }
 
+func (warlock *Warlock) RemoveSimulatedBuffs(_ *proto.RaidBuffs, _ *proto.PartyBuffs, debuffs *proto.Debuffs) {
+	if warlock.Talents.ImprovedShadowBolt > 0 {
+		debuffs.ImprovedShadowBolt = false
+	}
+}
+
 func (warlock *Warlock) Reset(sim *core.Simulation) {
 	warlock.setDefaultActivePet()
 	warlock.SacrificedPet = nil
@@ -89,7 +89,6 @@ func (warrior *Warrior) registerCleaveSpell(realismICD *core.Cooldown) {
 	flatDamageBonus *= []float64{1, 1.4, 1.8, 2.2}[warrior.Talents.ImprovedCleave]
 
 	warrior.CleaveTargetCount += 2
-	targetCount := warrior.Env.GetNumTargets()
 
 	warrior.Cleave = warrior.RegisterSpell(AnyStance, core.SpellConfig{
 		ActionID:       core.ActionID{SpellID: spellID},
@@ -112,7 +111,7 @@ func (warrior *Warrior) registerCleaveSpell(realismICD *core.Cooldown) {
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			curTarget := target
-			for i := int32(0); i < min(targetCount, warrior.CleaveTargetCount); i++ {
+			for i := int32(0); i < min(sim.GetNumTargets(), warrior.CleaveTargetCount); i++ {
 				baseDamage := flatDamageBonus + spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower())
 				spell.CalcAndDealDamage(sim, curTarget, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
 				curTarget = sim.Environment.NextTargetUnit(curTarget)
@@ -268,8 +268,6 @@ func (warrior *Warrior) applyScarletEnclaveProtection6PBonus() {
 
 // If Cleave hits fewer than its maximum number of targets, it deals 25% more damage for each unused bounce.
 func (warrior *Warrior) ApplyFallenRegalityWarriorBonus(aura *core.Aura) {
-	targetCount := warrior.Env.GetNumTargets()
-
 	cleaveDamageMod := warrior.AddDynamicMod(core.SpellModConfig{
 		Kind:       core.SpellMod_DamageDone_Pct,
 		ClassMask:  ClassSpellMask_WarriorCleave,
@@ -279,7 +277,7 @@ func (warrior *Warrior) ApplyFallenRegalityWarriorBonus(aura *core.Aura) {
 	aura.ApplyOnGain(func(aura *core.Aura, sim *core.Simulation) {
 		cleaveDamageMod.Activate()
 		// The cleave target count is set during initializing, so set the value here
-		cleaveDamageMod.UpdateFloatValue(1 + float64(warrior.CleaveTargetCount-targetCount)*0.25)
+		cleaveDamageMod.UpdateFloatValue(1 + float64(warrior.CleaveTargetCount-sim.GetNumTargets())*0.25)
 	}).ApplyOnExpire(func(aura *core.Aura, sim *core.Simulation) {
 		cleaveDamageMod.Activate()
 	})
@@ -0,0 +1,33 @@
+package warrior
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+)
+
+func (warrior *Warrior) registerTauntSpell() {
+	warrior.Taunt = warrior.RegisterSpell(DefensiveStance, core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 355},
+		SpellSchool: core.SpellSchoolPhysical,
+		DefenseType: core.DefenseTypeMagic,
+		Flags:       core.SpellFlagAPL,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    warrior.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			result := spell.CalcAndDealOutcome(sim, target, spell.OutcomeMagicHit)
+			if result.Landed() {
+				warrior.TauntTarget(sim, target)
+			}
+		},
+	})
+}
@@ -68,6 +68,7 @@ func (warrior *Warrior) registerThunderClapSpell() {
 		ThreatMultiplier: threatMultiplier,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			results := core.LimitToSpawnedTargets(sim, results)
 			for idx := range results {
 				results[idx] = spell.CalcDamage(sim, target, info.baseDamage+apCoef*spell.MeleeAttackPower(), spell.OutcomeMagicHitAndCrit)
 				target = sim.Environment.NextTargetUnit(target)
@@ -134,6 +134,7 @@ type Warrior struct {
 	Hamstring         *WarriorSpell
 	Rampage           *WarriorSpell
 	Shockwave         *WarriorSpell
+	Taunt             *WarriorSpell
 
 	HeroicStrike       *WarriorSpell
 	HeroicStrikeQueue  *WarriorSpell
@@ -169,6 +170,13 @@ func (warrior *Warrior) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 func (warrior *Warrior) AddPartyBuffs(_ *proto.PartyBuffs) {
 }
 
+func (warrior *Warrior) RemoveSimulatedBuffs(raidBuffs *proto.RaidBuffs, _ *proto.PartyBuffs, debuffs *proto.Debuffs) {
+	raidBuffs.BattleShout = proto.TristateEffect_TristateEffectMissing
+	debuffs.SunderArmor = false
+	debuffs.DemoralizingShout = proto.TristateEffect_TristateEffectMissing
+	debuffs.ThunderClap = proto.TristateEffect_TristateEffectMissing
+}
+
 func (warrior *Warrior) RegisterSpell(stanceMask Stance, config core.SpellConfig) *WarriorSpell {
 	ws := &WarriorSpell{
 		StanceMask: stanceMask,
@@ -263,6 +271,7 @@ func (warrior *Warrior) Initialize() {
 	warrior.registerWhirlwindSpell()
 	warrior.registerRendSpell()
 	warrior.registerHamstringSpell()
+	warrior.registerTauntSpell()
 
 	// The sim often re-enables heroic strike in an unrealistic amount of time.
 	// This can cause an unrealistic immediate double-hit around wild strikes procs
@@ -25,6 +25,7 @@ import (
 	"github.com/wowsims/sod/sim/core"
 	proto "github.com/wowsims/sod/sim/core/proto"
 	"github.com/wowsims/sod/sim/core/simsignals"
+	"github.com/wowsims/sod/sim/encounters"
 
 	googleProto "google.golang.org/protobuf/proto"
 )
@@ -48,9 +49,16 @@ func main() {
 	var host = flag.String("host", "localhost:3333", "URL to host the interface on.")
 	var launch = flag.Bool("launch", true, "auto launch browser")
 	var skipVersionCheck = flag.Bool("nvc", false, "set true to skip version check")
+	var presetsDir = flag.String("presets", "", "Directory of preset packs (JSON/HuJSON files with extra preset targets and encounters) to load at startup.")
 
 	flag.Parse()
 
+	if *presetsDir != "" {
+		if err := encounters.LoadPresetPacks(*presetsDir); err != nil {
+			log.Fatalf("Failed to load preset packs: %s", err)
+		}
+	}
+
 	fmt.Printf("Version: %s\n", Version)
 	if !*skipVersionCheck && Version != "development" {
 		go func() {
@@ -106,6 +114,18 @@ var handlers = map[string]apiHandler{
 	"/computeStats": {msg: func() googleProto.Message { return &proto.ComputeStatsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.ComputeStats(msg.(*proto.ComputeStatsRequest))
 	}},
+	"/aplLint": {msg: func() googleProto.Message { return &proto.APLLintRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.LintAPL(msg.(*proto.APLLintRequest))
+	}},
+	"/presetEncounters": {msg: func() googleProto.Message { return &proto.PresetEncountersRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.GetPresetEncounters(msg.(*proto.PresetEncountersRequest))
+	}},
+	"/targetTemplate": {msg: func() googleProto.Message { return &proto.TargetTemplateRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.TargetTemplate(msg.(*proto.TargetTemplateRequest))
+	}},
+	"/partyOptimizer": {msg: func() googleProto.Message { return &proto.PartyOptimizerRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RunPartyOptimizer(msg.(*proto.PartyOptimizerRequest))
+	}},
 	"/abortById": {msg: func() googleProto.Message { return &proto.AbortRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		requestId := msg.(*proto.AbortRequest).RequestId
 		triggered := simsignals.AbortById(requestId)
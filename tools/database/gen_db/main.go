@@ -229,6 +229,8 @@ func main() {
 	atlasDBProto := atlaslootDB.ToUIProto()
 	db.MergeZones(atlasDBProto.Zones)
 	db.MergeNpcs(atlasDBProto.Npcs)
+	db.MergeNpcs(database.NpcOverrides)
+	AttachTargetTemplates(db)
 	db.MergeFactions(atlasDBProto.Factions)
 
 	db.WriteBinaryAndJson(fmt.Sprintf("%s/db.bin", dbDir), fmt.Sprintf("%s/db.json", dbDir))
@@ -281,6 +283,28 @@ func AttachFactionInformation(db *database.WowDatabase, factionRestrictions map[
 	}
 }
 
+// Generates target templates for NPCs with a known level.
+func AttachTargetTemplates(db *database.WowDatabase) {
+	for _, npc := range db.Npcs {
+		if npc.Level == 0 {
+			continue
+		}
+
+		target, err := core.NewTargetTemplate(&proto.TargetTemplateRequest{
+			NpcId:          npc.Id,
+			Name:           npc.Name,
+			Level:          npc.Level,
+			Classification: npc.Classification,
+			MobType:        npc.MobType,
+			Overrides:      npc.TemplateOverrides,
+		})
+		if err != nil {
+			log.Fatalf("Failed to generate target template for NPC %d: %s", npc.Id, err)
+		}
+		npc.TargetTemplate = target
+	}
+}
+
 // Filters out entities which shouldn't be included in the sim.
 func ApplySimmableFilters(db *database.WowDatabase) {
 	db.Items = core.FilterMap(db.Items, simmableItemFilter)
@@ -279,6 +279,18 @@ var ExtraItemIcons = []int32{
 	18262,
 }
 
+// Level and classification of NPCs, used to generate their target templates.
+var NpcOverrides = []*proto.UINPC{
+	{Id: 213334, Level: 27, Classification: proto.NPCClassification_NPCClassificationBoss, MobType: proto.MobType_MobTypeBeast, TemplateOverrides: &proto.TargetTemplateOverrides{
+		Stats: stats.Stats{stats.Health: 127_393}.ToFloatArray(),
+	}}, // Aku'mai
+	{Id: 10184, Level: 63, Classification: proto.NPCClassification_NPCClassificationBoss, MobType: proto.MobType_MobTypeDragonkin}, // Onyxia
+	{Id: 11502, Level: 63, Classification: proto.NPCClassification_NPCClassificationBoss, MobType: proto.MobType_MobTypeElemental}, // Ragnaros
+	{Id: 13020, Level: 63, Classification: proto.NPCClassification_NPCClassificationBoss, MobType: proto.MobType_MobTypeDragonkin}, // Vaelastrasz the Corrupt
+	{Id: 11583, Level: 63, Classification: proto.NPCClassification_NPCClassificationBoss, MobType: proto.MobType_MobTypeDragonkin}, // Nefarian
+	{Id: 16028, Level: 63, Classification: proto.NPCClassification_NPCClassificationBoss, MobType: proto.MobType_MobTypeUndead},    // Patchwerk
+}
+
 var SpellIconoverrides = []*proto.IconData{
 	{Id: 415068, Name: "Exorcism (Rank 1)"},
 	{Id: 415069, Name: "Exorcism (Rank 2)"},
@@ -244,11 +244,23 @@ export class HealingMetricsTable extends MetricsTable<ActionMetrics> {
 				getValue: (metric: ActionMetrics) => metric.healingCritPercent,
 				getDisplayString: (metric: ActionMetrics) => formatToPercent(metric.healingCritPercent, { fallbackString: '-' }),
 			},
+			{
+				name: 'Overheal %',
+				tooltip: TOOLTIP_METRIC_LABELS['Overheal %'],
+				getValue: (metric: ActionMetrics) => metric.overhealingPercent,
+				getDisplayString: (metric: ActionMetrics) => formatToPercent(metric.overhealingPercent, { fallbackString: '-' }),
+			},
 			{
 				name: 'HPET',
 				getValue: (metric: ActionMetrics) => metric.healingThroughput,
 				getDisplayString: (metric: ActionMetrics) => formatToCompactNumber(metric.healingThroughput, { fallbackString: '-' }),
 			},
+			{
+				name: 'EHPS',
+				tooltip: TOOLTIP_METRIC_LABELS['EHPS'],
+				getValue: (metric: ActionMetrics) => metric.effectiveHps,
+				getDisplayString: (metric: ActionMetrics) => formatToNumber(metric.effectiveHps, { minimumFractionDigits: 2, fallbackString: '-' }),
+			},
 			{
 				name: 'HPS',
 				sort: ColumnSortType.Descending,
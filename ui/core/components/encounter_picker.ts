@@ -5,7 +5,18 @@ import { NumberPicker } from '../components/number_picker.js';
 import * as Mechanics from '../constants/mechanics.js';
 import { Encounter } from '../encounter.js';
 import { IndividualSimUI } from '../individual_sim_ui.js';
-import { InputType, MobType, SpellSchool, Stat, Target, Target as TargetProto, TargetInput } from '../proto/common.js';
+import {
+	AggroPullOutcome,
+	EncounterDamageProfile,
+	EncounterMovementProfile,
+	InputType,
+	MobType,
+	SpellSchool,
+	Stat,
+	Target,
+	Target as TargetProto,
+	TargetInput,
+} from '../proto/common.js';
 import { statNames } from '../proto_utils/names.js';
 import { Stats } from '../proto_utils/stats.js';
 import { isHealingSpec, isTankSpec } from '../proto_utils/utils.js';
@@ -173,7 +184,95 @@ class AdvancedEncounterModal extends BaseModal {
 					encounter.setUseHealth(eventID, newValue);
 				},
 			});
+			new BooleanPicker<Encounter>(header, encounter, {
+				id: 'encounter-use-time-to-die-estimate',
+				label: 'Estimate Time To Die',
+				labelTooltip: 'When using health, estimates the remaining fight duration from recent damage taken instead of the average damage so far. Automatic cooldowns are also held for targets expected to die before their effect ends.',
+				inline: true,
+				changedEvent: (encounter: Encounter) => encounter.changeEmitter,
+				getValue: (encounter: Encounter) => encounter.getUseTimeToDieEstimate(),
+				setValue: (eventID: EventID, encounter: Encounter, newValue: boolean) => {
+					encounter.setUseTimeToDieEstimate(eventID, newValue);
+				},
+				showWhen: (encounter: Encounter) => encounter.getUseHealth(),
+			});
+			new BooleanPicker<Encounter>(header, encounter, {
+				id: 'encounter-use-target-health',
+				label: 'Individual Target Health',
+				labelTooltip:
+					'When using health, each target dies once its own health is exhausted, and the fight ends when every target which is not an add or an optional kill is dead.',
+				inline: true,
+				changedEvent: (encounter: Encounter) => encounter.changeEmitter,
+				getValue: (encounter: Encounter) => encounter.getUseTargetHealth(),
+				setValue: (eventID: EventID, encounter: Encounter, newValue: boolean) => {
+					encounter.setUseTargetHealth(eventID, newValue);
+				},
+				showWhen: (encounter: Encounter) => encounter.getUseHealth(),
+			});
 		}
+		new EnumPicker<Encounter>(header, encounter, {
+			id: 'encounter-movement-profile',
+			label: 'Movement',
+			labelTooltip:
+				'Forces players to move or stop acting at regular intervals. Light: 10 yards every 60s. Medium: 15 yards every 30s and 2s of downtime every 90s. Heavy: 20 yards every 15s and 3s of downtime every 45s.',
+			values: [
+				{ name: 'None', value: EncounterMovementProfile.MovementProfileNone },
+				{ name: 'Light', value: EncounterMovementProfile.MovementProfileLight },
+				{ name: 'Medium', value: EncounterMovementProfile.MovementProfileMedium },
+				{ name: 'Heavy', value: EncounterMovementProfile.MovementProfileHeavy },
+			],
+			changedEvent: (encounter: Encounter) => encounter.movementChangeEmitter,
+			getValue: (encounter: Encounter) => encounter.getMovementProfile(),
+			setValue: (eventID: EventID, encounter: Encounter, newValue: number) => {
+				encounter.setMovementProfile(eventID, newValue);
+			},
+		});
+		new EnumPicker<Encounter>(header, encounter, {
+			id: 'encounter-damage-profile',
+			label: 'Raid Damage',
+			labelTooltip:
+				'Damage taken by all players from the encounter, reduced by armor and resistances. Light: 150 Nature to everyone every 20s. Medium: 250 Shadow to everyone every 15s and 800 Physical to a random player every 10s. Heavy: 400 Fire to everyone every 10s, 800 Physical to 2 random players every 8s and 2000 Shadow to everyone every 90s.',
+			values: [
+				{ name: 'None', value: EncounterDamageProfile.DamageProfileNone },
+				{ name: 'Light', value: EncounterDamageProfile.DamageProfileLight },
+				{ name: 'Medium', value: EncounterDamageProfile.DamageProfileMedium },
+				{ name: 'Heavy', value: EncounterDamageProfile.DamageProfileHeavy },
+			],
+			changedEvent: (encounter: Encounter) => encounter.damageChangeEmitter,
+			getValue: (encounter: Encounter) => encounter.getDamageProfile(),
+			setValue: (eventID: EventID, encounter: Encounter, newValue: number) => {
+				encounter.setDamageProfile(eventID, newValue);
+			},
+		});
+		new EnumPicker<Encounter>(header, encounter, {
+			id: 'encounter-aggro-pull-outcome',
+			label: 'Aggro Pulls',
+			labelTooltip:
+				'What happens when a player who is not a tank exceeds the tank\'s threat by 110% in melee range or 130% at range. Wipe: counts the iteration as a wipe. Threat Drop: the player drops all threat. Attack: the target attacks the player until the tank takes it back.',
+			values: [
+				{ name: 'Ignored', value: AggroPullOutcome.AggroPullIgnored },
+				{ name: 'Wipe', value: AggroPullOutcome.AggroPullWipe },
+				{ name: 'Threat Drop', value: AggroPullOutcome.AggroPullThreatDrop },
+				{ name: 'Attack', value: AggroPullOutcome.AggroPullAttack },
+			],
+			changedEvent: (encounter: Encounter) => encounter.threatChangeEmitter,
+			getValue: (encounter: Encounter) => encounter.getAggroPullOutcome(),
+			setValue: (eventID: EventID, encounter: Encounter, newValue: number) => {
+				encounter.setAggroPullOutcome(eventID, newValue);
+			},
+		});
+		new NumberPicker(header, encounter, {
+			id: 'encounter-virtual-tank-tps',
+			label: 'Virtual Tank TPS',
+			labelTooltip: 'Threat per second of the tank for targets which are not tanked by a player in the raid. 0 means such targets have no tank.',
+			positive: true,
+			changedEvent: (encounter: Encounter) => encounter.threatChangeEmitter,
+			getValue: (encounter: Encounter) => encounter.getVirtualTankTps(),
+			setValue: (eventID: EventID, encounter: Encounter, newValue: number) => {
+				encounter.setVirtualTankTps(eventID, newValue);
+			},
+			showWhen: (encounter: Encounter) => encounter.getAggroPullOutcome() != AggroPullOutcome.AggroPullIgnored,
+		});
 		new ListPicker<Encounter, TargetProto>(targetsElem, this.encounter, {
 			extraCssClasses: ['targets-picker', 'mb-0'],
 			itemLabel: 'Target',
@@ -229,6 +328,7 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 	private readonly levelPicker: Input<null, number>;
 	private readonly mobTypePicker: Input<null, number>;
 	private readonly tankIndexPicker: Input<null, number>;
+	private readonly threatTargetingPicker: Input<null, boolean>;
 	private readonly statPickers: Array<Input<null, number>>;
 	private readonly swingSpeedPicker: Input<null, number>;
 	private readonly minBaseDamagePicker: Input<null, number>;
@@ -237,6 +337,11 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 	private readonly parryHastePicker: Input<null, boolean>;
 	private readonly spellSchoolPicker: Input<null, number>;
 	private readonly damageSpreadPicker: Input<null, number>;
+	private readonly isAddPicker: Input<null, boolean>;
+	private readonly spawnTimePicker: Input<null, number>;
+	private readonly despawnAfterPicker: Input<null, number>;
+	private readonly respawnIntervalPicker: Input<null, number>;
+	private readonly optionalKillPicker: Input<null, boolean>;
 	private readonly targetInputPickers: ListPicker<Encounter, TargetInput>;
 
 	private getTarget(): TargetProto {
@@ -370,6 +475,89 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 				encounter.targetsChangeEmitter.emit(eventID);
 			},
 		});
+		this.threatTargetingPicker = new BooleanPicker(section1, null, {
+			id: 'target-picker-threat-targeting',
+			extraCssClasses: ['threat-metrics'],
+			label: 'Threat Targeting',
+			labelTooltip: 'This enemy attacks whoever has the most threat once they exceed 110% of its current target. Otherwise it only switches targets when taunted.',
+			inline: true,
+			reverse: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().threatTargeting,
+			setValue: (eventID: EventID, _: null, newValue: boolean) => {
+				this.getTarget().threatTargeting = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+			showWhen: () => this.getTarget().tankIndex >= 0,
+		});
+
+		this.isAddPicker = new BooleanPicker(section1, null, {
+			id: 'target-picker-is-add',
+			label: 'Add',
+			labelTooltip: 'Adds spawn and despawn during the fight, and die once their health is exhausted. The first target cannot be an add.',
+			inline: true,
+			reverse: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().isAdd,
+			setValue: (eventID: EventID, _: null, newValue: boolean) => {
+				this.getTarget().isAdd = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+			showWhen: () => this.targetIndex > 0,
+		});
+		this.spawnTimePicker = new NumberPicker(section1, null, {
+			id: 'target-picker-spawn-time',
+			label: 'Spawn Time',
+			labelTooltip: 'Time in seconds after the pull at which this add first spawns.',
+			float: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().spawnTimeSeconds,
+			setValue: (eventID: EventID, _: null, newValue: number) => {
+				this.getTarget().spawnTimeSeconds = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+			showWhen: () => this.targetIndex > 0 && this.getTarget().isAdd,
+		});
+		this.despawnAfterPicker = new NumberPicker(section1, null, {
+			id: 'target-picker-despawn-after',
+			label: 'Despawn After',
+			labelTooltip: 'Time in seconds this add stays before despawning. Set to 0 to keep it until it dies.',
+			float: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().despawnAfterSeconds,
+			setValue: (eventID: EventID, _: null, newValue: number) => {
+				this.getTarget().despawnAfterSeconds = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+			showWhen: () => this.targetIndex > 0 && this.getTarget().isAdd,
+		});
+		this.respawnIntervalPicker = new NumberPicker(section1, null, {
+			id: 'target-picker-respawn-interval',
+			label: 'Respawn Interval',
+			labelTooltip: 'For adds which come in waves, the time in seconds between spawns. Set to 0 to only spawn once.',
+			float: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().respawnIntervalSeconds,
+			setValue: (eventID: EventID, _: null, newValue: number) => {
+				this.getTarget().respawnIntervalSeconds = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+			showWhen: () => this.targetIndex > 0 && this.getTarget().isAdd,
+		});
+		this.optionalKillPicker = new BooleanPicker(section1, null, {
+			id: 'target-picker-optional-kill',
+			label: 'Optional Kill',
+			labelTooltip: 'When using individual target health, the fight can end while this target is still alive.',
+			inline: true,
+			reverse: true,
+			changedEvent: () => encounter.changeEmitter,
+			getValue: () => this.getTarget().optionalKill,
+			setValue: (eventID: EventID, _: null, newValue: boolean) => {
+				this.getTarget().optionalKill = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+			showWhen: () => encounter.getUseHealth() && encounter.getUseTargetHealth() && !(this.targetIndex > 0 && this.getTarget().isAdd),
+		});
 
 		this.targetInputPickers = makeTargetInputsPicker(section1, encounter, this.targetIndex);
 
@@ -499,6 +687,7 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 			level: this.levelPicker.getInputValue(),
 			mobType: this.mobTypePicker.getInputValue(),
 			tankIndex: this.tankIndexPicker.getInputValue(),
+			threatTargeting: this.threatTargetingPicker.getInputValue(),
 			swingSpeed: this.swingSpeedPicker.getInputValue(),
 			minBaseDamage: this.minBaseDamagePicker.getInputValue(),
 			dualWield: this.dualWieldPicker.getInputValue(),
@@ -506,12 +695,18 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 			parryHaste: this.parryHastePicker.getInputValue(),
 			spellSchool: this.spellSchoolPicker.getInputValue(),
 			damageSpread: this.damageSpreadPicker.getInputValue(),
+			isAdd: this.isAddPicker.getInputValue(),
+			spawnTimeSeconds: this.spawnTimePicker.getInputValue(),
+			despawnAfterSeconds: this.despawnAfterPicker.getInputValue(),
+			respawnIntervalSeconds: this.respawnIntervalPicker.getInputValue(),
+			optionalKill: this.optionalKillPicker.getInputValue(),
 			stats: this.statPickers
 				.map(picker => picker.getInputValue())
 				.map((statValue, i) => new Stats().withStat(ALL_TARGET_STATS[i].stat, statValue))
 				.reduce((totalStats, curStats) => totalStats.add(curStats))
 				.asArray(),
 			targetInputs: this.targetInputPickers.getInputValue(),
+			abilities: this.getTarget().abilities,
 		});
 	}
 	setInputValue(newValue: TargetProto) {
@@ -522,6 +717,7 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 		this.levelPicker.setInputValue(newValue.level);
 		this.mobTypePicker.setInputValue(newValue.mobType);
 		this.tankIndexPicker.setInputValue(newValue.tankIndex);
+		this.threatTargetingPicker.setInputValue(newValue.threatTargeting);
 		this.swingSpeedPicker.setInputValue(newValue.swingSpeed);
 		this.minBaseDamagePicker.setInputValue(newValue.minBaseDamage);
 		this.dualWieldPicker.setInputValue(newValue.dualWield);
@@ -529,6 +725,11 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 		this.parryHastePicker.setInputValue(newValue.parryHaste);
 		this.spellSchoolPicker.setInputValue(newValue.spellSchool);
 		this.damageSpreadPicker.setInputValue(newValue.damageSpread);
+		this.isAddPicker.setInputValue(newValue.isAdd);
+		this.spawnTimePicker.setInputValue(newValue.spawnTimeSeconds);
+		this.despawnAfterPicker.setInputValue(newValue.despawnAfterSeconds);
+		this.respawnIntervalPicker.setInputValue(newValue.respawnIntervalSeconds);
+		this.optionalKillPicker.setInputValue(newValue.optionalKill);
 		ALL_TARGET_STATS.forEach((statData, i) => this.statPickers[i].setInputValue(newValue.stats[statData.stat]));
 		this.targetInputPickers.setInputValue(newValue.targetInputs);
 	}
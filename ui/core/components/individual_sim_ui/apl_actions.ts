@@ -13,6 +13,8 @@ import {
 	APLActionChangeTarget,
 	APLActionChannelSpell,
 	APLActionCustomRotation,
+	APLActionDispel,
+	APLActionInterrupt,
 	APLActionItemSwap,
 	APLActionItemSwap_SwapSet as ItemSwapSet,
 	APLActionMove,
@@ -635,6 +637,30 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 			}),
 		],
 	}),
+	['interrupt']: inputBuilder({
+		label: 'Interrupt',
+		submenu: ['Encounter'],
+		shortDescription: "Casts an interrupt spell, such as Kick or Counterspell, to interrupt the target's cast.",
+		fullDescription: `
+			<p>Only ready while the target is casting an interruptible ability. Interruptible casts which complete are reported as missed interrupts on the target.</p>
+		`,
+		newValue: () => APLActionInterrupt.create(),
+		fields: [AplHelpers.actionIdFieldConfig('spellId', 'castable_spells', ''), AplHelpers.unitFieldConfig('target', 'targets')],
+	}),
+	['dispel']: inputBuilder({
+		label: 'Dispel',
+		submenu: ['Encounter'],
+		shortDescription: 'Casts a dispel spell, such as Remove Lesser Curse or Cleanse, to remove a debuff from a player.',
+		fullDescription: `
+			<p>Only ready while the player has a debuff which the spell can remove. Time spent with dispellable debuffs is reported in the results.</p>
+		`,
+		newValue: () => APLActionDispel.create(),
+		fields: [
+			AplHelpers.actionIdFieldConfig('spellId', 'castable_spells', ''),
+			AplHelpers.unitFieldConfig('target', 'aura_sources'),
+			AplHelpers.dispelTypeFieldConfig('dispelType'),
+		],
+	}),
 	['customRotation']: inputBuilder({
 		label: 'Custom Rotation',
 		//submenu: ['Misc'],
@@ -1,14 +1,14 @@
 import { ref } from 'tsx-vanilla';
 
 import { Player, UnitMetadata } from '../../player';
-import { ActionID, ItemSlot, OtherAction, UnitReference, UnitReference_Type as UnitType } from '../../proto/common';
+import { ActionID, DispelType, ItemSlot, OtherAction, UnitReference, UnitReference_Type as UnitType } from '../../proto/common';
 import { UIRune as Rune } from '../../proto/ui';
 import { ActionId, defaultTargetIcon, getPetIconFromName } from '../../proto_utils/action_id';
 import { itemTypeNames } from '../../proto_utils/names';
 import { EventID } from '../../typed_event';
 import { bucket, randomUUID } from '../../utils';
 import { BooleanPicker } from '../boolean_picker';
-import { DropdownPicker, DropdownPickerConfig, DropdownValueConfig } from '../dropdown_picker.jsx';
+import { DropdownPicker, DropdownPickerConfig, DropdownValueConfig, TextDropdownPicker } from '../dropdown_picker.jsx';
 import { Input, InputConfig } from '../input.jsx';
 import { AdaptiveStringPicker } from '../inputs/string_picker';
 import { NumberPicker, NumberPickerConfig } from '../number_picker';
@@ -379,6 +379,7 @@ const unitSets: Record<
 					.map((petMetadata, i) => UnitReference.create({ type: UnitType.Pet, index: i, owner: UnitReference.create({ type: UnitType.Self }) })),
 				UnitReference.create({ type: UnitType.CurrentTarget }),
 				player.sim.encounter.targetsMetadata.asList().map((targetMetadata, i) => UnitReference.create({ type: UnitType.Target, index: i })),
+				UnitReference.create({ type: UnitType.LowestHealthAlly }),
 			].flat();
 		},
 	},
@@ -402,6 +403,7 @@ const unitSets: Record<
 			return [
 				undefined,
 				player.sim.encounter.targetsMetadata.asList().map((_targetMetadata, i) => UnitReference.create({ type: UnitType.Target, index: i })),
+				UnitReference.create({ type: UnitType.LowestHealthAlly }),
 			].flat();
 		},
 	},
@@ -452,6 +454,12 @@ export class APLUnitPicker extends UnitPicker<Player<any>> {
 				iconUrl: 'fa-bullseye',
 				text: 'Current Target',
 			};
+		} else if (ref.type == UnitType.LowestHealthAlly) {
+			return {
+				value: ref,
+				iconUrl: 'fa-heart-pulse',
+				text: 'Lowest Health Ally',
+			};
 		} else if (ref.type == UnitType.Player) {
 			const player = thisPlayer.sim.raid.getPlayer(ref.index);
 			if (player) {
@@ -664,6 +672,27 @@ export function unitFieldConfig(
 	};
 }
 
+export function dispelTypeFieldConfig(field: string): APLPickerBuilderFieldConfig<any, any> {
+	return {
+		field: field,
+		newValue: () => DispelType.DispelTypeNone,
+		factory: (parent, player, config) =>
+			new TextDropdownPicker(parent, player, {
+				id: randomUUID(),
+				...config,
+				defaultLabel: 'Any',
+				equals: (a, b) => a === b,
+				values: [
+					{ label: 'Any', value: DispelType.DispelTypeNone },
+					{ label: 'Magic', value: DispelType.DispelTypeMagic },
+					{ label: 'Curse', value: DispelType.DispelTypeCurse },
+					{ label: 'Poison', value: DispelType.DispelTypePoison },
+					{ label: 'Disease', value: DispelType.DispelTypeDisease },
+				],
+			}),
+	};
+}
+
 export function booleanFieldConfig(
 	field: string,
 	label?: string,
@@ -14,6 +14,8 @@ import {
 	APLValueAutoSwingTime_SwingType as AutoSwingType,
 	APLValueAutoTimeToNext,
 	APLValueAutoTimeToNext_AttackType as AutoAttackType,
+	APLValueBossPhase,
+	APLValueBossTimerRemaining,
 	APLValueCatEnergyAfterDuration,
 	APLValueCatExcessEnergy,
 	APLValueCatNewSavageRoarDuration,
@@ -34,18 +36,23 @@ import {
 	APLValueDotIsActive,
 	APLValueDotRemainingTime,
 	APLValueEnergyThreshold,
+	APLValueExternalBuffTimeToNext,
 	APLValueFrontOfTarget,
 	APLValueGCDIsReady,
 	APLValueGCDTimeToReady,
+	APLValueHasDispellableDebuff,
 	APLValueIsExecutePhase,
 	APLValueIsExecutePhase_ExecutePhaseThreshold as ExecutePhaseThreshold,
+	APLValueIsTanking,
 	APLValueMath,
 	APLValueMath_MathOperator as MathOperator,
 	APLValueMax,
 	APLValueMaxEnergy,
 	APLValueMin,
+	APLValueMissingHealth,
 	APLValueNot,
 	APLValueNumberTargets,
+	APLValueNumInjuredAllies,
 	APLValueOr,
 	APLValueRemainingTime,
 	APLValueRemainingTimePercent,
@@ -63,7 +70,13 @@ import {
 	APLValueSpellIsReady,
 	APLValueSpellTimeToReady,
 	APLValueSpellTravelTime,
+	APLValueTargetIsAlive,
+	APLValueTargetIsCasting,
+	APLValueTargetIsCastingInterruptible,
 	APLValueTargetMobType,
+	APLValueTargetTimeToDie,
+	APLValueTargetTimeToPercent,
+	APLValueThreatPercentOfTank,
 	APLValueTimeToEnergyTick,
 	APLValueTotemRemainingTime,
 	APLValueWarlockCurrentPetMana,
@@ -631,6 +644,96 @@ const valueKindFactories: { [f in NonNullable<APLValueKind>]: ValueKindConfig<AP
 		newValue: APLValueTargetMobType.create,
 		fields: [AplHelpers.unitFieldConfig('target', 'targets'), targetMobTypeFieldConfig('mobType')],
 	}),
+	bossTimerRemaining: inputBuilder({
+		label: 'Boss Timer Remaining',
+		submenu: ['Encounter'],
+		shortDescription: 'Time until the named boss mechanic next happens, e.g. <b>Polarity Shift</b>.',
+		fullDescription: `
+			<p>Only supported by boss encounters which publish their timers. If the boss has no timer with this name, returns the remaining fight duration.</p>
+		`,
+		newValue: APLValueBossTimerRemaining.create,
+		fields: [AplHelpers.unitFieldConfig('target', 'targets'), AplHelpers.stringFieldConfig('timerName')],
+	}),
+	bossPhase: inputBuilder({
+		label: 'Boss Phase',
+		submenu: ['Encounter'],
+		shortDescription: 'The current phase of the encounter, starting at 1.',
+		newValue: APLValueBossPhase.create,
+		fields: [AplHelpers.unitFieldConfig('target', 'targets')],
+	}),
+	targetIsCasting: inputBuilder({
+		label: 'Target Is Casting',
+		submenu: ['Encounter'],
+		shortDescription: '<b>True</b> if the selected target is casting or channeling the specified spell, or any spell if none is selected, otherwise <b>False</b>.',
+		newValue: APLValueTargetIsCasting.create,
+		fields: [AplHelpers.unitFieldConfig('target', 'targets'), AplHelpers.actionIdFieldConfig('spellId', 'castable_spells', 'target', 'currentTarget')],
+	}),
+	targetIsCastingInterruptible: inputBuilder({
+		label: 'Target Is Casting Interruptible',
+		submenu: ['Encounter'],
+		shortDescription: '<b>True</b> if the selected target is casting a spell which can be interrupted, otherwise <b>False</b>.',
+		newValue: APLValueTargetIsCastingInterruptible.create,
+		fields: [AplHelpers.unitFieldConfig('target', 'targets')],
+	}),
+	hasDispellableDebuff: inputBuilder({
+		label: 'Has Dispellable Debuff',
+		submenu: ['Encounter'],
+		shortDescription: '<b>True</b> if the selected player has a debuff of the specified dispel type, or any type if none is selected, otherwise <b>False</b>.',
+		newValue: APLValueHasDispellableDebuff.create,
+		fields: [AplHelpers.unitFieldConfig('target', 'aura_sources'), AplHelpers.dispelTypeFieldConfig('dispelType')],
+	}),
+	isTanking: inputBuilder({
+		label: 'Is Tanking',
+		submenu: ['Encounter'],
+		shortDescription: '<b>True</b> if the selected target is attacking you, otherwise <b>False</b>.',
+		fullDescription: `
+			<p>Targets only switch tanks when taunted, or when they use threat targeting and another unit has more threat.</p>
+		`,
+		newValue: APLValueIsTanking.create,
+		fields: [AplHelpers.unitFieldConfig('target', 'targets')],
+	}),
+	threatPercentOfTank: inputBuilder({
+		label: 'Threat Percent of Tank',
+		submenu: ['Encounter'],
+		shortDescription: 'Your threat on the selected target, as a percent of the tank\'s threat.',
+		fullDescription: `
+			<p>Melee players pull aggro above <b>110%</b> and ranged players above <b>130%</b>. Returns <b>0</b> if the target has no tank besides you.</p>
+		`,
+		newValue: APLValueThreatPercentOfTank.create,
+		fields: [AplHelpers.unitFieldConfig('target', 'targets')],
+	}),
+	targetTimeToDie: inputBuilder({
+		label: 'Target Time To Die',
+		submenu: ['Encounter'],
+		shortDescription: 'Estimated time until the selected target dies, based on recent damage taken.',
+		fullDescription: `
+			<p>Capped at the remaining fight duration. Until enough damage has been taken for an estimate, the remaining fight duration is used.</p>
+		`,
+		newValue: APLValueTargetTimeToDie.create,
+		fields: [AplHelpers.unitFieldConfig('target', 'targets')],
+	}),
+	targetIsAlive: inputBuilder({
+		label: 'Target Is Alive',
+		submenu: ['Encounter'],
+		shortDescription: '<b>True</b> if the selected target is spawned and has not died, otherwise <b>False</b>.',
+		fullDescription: `
+			<p>Targets only die in health based fights, or if they are adds with health.</p>
+		`,
+		newValue: APLValueTargetIsAlive.create,
+		fields: [AplHelpers.unitFieldConfig('target', 'targets')],
+	}),
+	targetTimeToPercent: inputBuilder({
+		label: 'Target Time To Health (%)',
+		submenu: ['Encounter'],
+		shortDescription: 'Estimated time until the selected target reaches the specified health percent, based on recent damage taken.',
+		newValue: APLValueTargetTimeToPercent.create,
+		fields: [
+			AplHelpers.unitFieldConfig('target', 'targets'),
+			AplHelpers.numberFieldConfig('healthPercent', true, {
+				label: 'Health (%)',
+			}),
+		],
+	}),
 
 	// Resources
 	currentHealth: inputBuilder({
@@ -640,6 +743,27 @@ const valueKindFactories: { [f in NonNullable<APLValueKind>]: ValueKindConfig<AP
 		newValue: APLValueCurrentHealth.create,
 		fields: [AplHelpers.unitFieldConfig('sourceUnit', 'aura_sources')],
 	}),
+	missingHealth: inputBuilder({
+		label: 'Missing Health',
+		submenu: ['Resources'],
+		shortDescription: 'Amount of Health missing from the unit, i.e. the most that can be healed without overhealing.',
+		newValue: APLValueMissingHealth.create,
+		fields: [AplHelpers.unitFieldConfig('sourceUnit', 'aura_sources')],
+	}),
+	numInjuredAllies: inputBuilder({
+		label: 'Num Injured Allies',
+		submenu: ['Resources'],
+		shortDescription: 'Number of raid members whose Health is below the given percent.',
+		newValue: () =>
+			APLValueNumInjuredAllies.create({
+				healthPercent: 100,
+			}),
+		fields: [
+			AplHelpers.numberFieldConfig('healthPercent', true, {
+				label: 'Health (%)',
+			}),
+		],
+	}),
 	currentHealthPercent: inputBuilder({
 		label: 'Health (%)',
 		submenu: ['Resources'],
@@ -868,6 +992,16 @@ const valueKindFactories: { [f in NonNullable<APLValueKind>]: ValueKindConfig<AP
 		newValue: APLValueAuraRemainingTime.create,
 		fields: [AplHelpers.unitFieldConfig('sourceUnit', 'aura_sources'), AplHelpers.actionIdFieldConfig('auraId', 'auras', 'sourceUnit')],
 	}),
+	externalBuffTimeToNext: inputBuilder({
+		label: 'External Buff Time To Next',
+		submenu: ['Aura'],
+		shortDescription: 'Time until another raid member casts this buff on you through a raid cooldown assignment.',
+		fullDescription: `
+			<p>Returns <b>0</b> while the cast is only waiting on its condition, and a very large value if no more casts are planned.</p>
+		`,
+		newValue: APLValueExternalBuffTimeToNext.create,
+		fields: [AplHelpers.actionIdFieldConfig('spellId', 'auras', '')],
+	}),
 	auraNumStacks: inputBuilder({
 		label: 'Aura Num Stacks',
 		submenu: ['Aura'],
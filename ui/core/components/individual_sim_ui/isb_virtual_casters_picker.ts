@@ -0,0 +1,145 @@
+import { Player } from '../../player.js';
+import { IsbVirtualCaster, IsbVirtualCaster_Type as IsbVirtualCasterType } from '../../proto/api.js';
+import { EventID } from '../../typed_event.js';
+import { EnumPicker } from '../enum_picker.js';
+import { Input } from '../input.js';
+import { ListItemPickerConfig, ListPicker } from '../list_picker.js';
+import { NumberPicker } from '../number_picker.js';
+
+export const makeIsbVirtualCastersPicker = (parent: HTMLElement, player: Player<any>): ListPicker<Player<any>, IsbVirtualCaster> =>
+	new ListPicker<Player<any>, IsbVirtualCaster>(parent, player, {
+		extraCssClasses: ['isb-virtual-casters-picker'],
+		title: 'Virtual Casters',
+		titleTooltip: `
+			<p>Warlocks and Shadow Priests outside the sim, who apply or consume Improved Shadow Bolt charges on a fixed schedule.</p>
+			<p>Replaces the settings above when set. Virtual Warlocks are only used with the Improved Shadow Bolt debuff.</p>
+		`,
+		itemLabel: 'Caster',
+		changedEvent: (player: Player<any>) => player.changeEmitter,
+		getValue: (player: Player<any>) => player.getIsbVirtualCasters(),
+		setValue: (eventID: EventID, player: Player<any>, newValue: Array<IsbVirtualCaster>) => player.setIsbVirtualCasters(eventID, newValue),
+		newItem: () =>
+			IsbVirtualCaster.create({
+				type: IsbVirtualCasterType.Warlock,
+				castInterval: 3,
+				crit: 25,
+			}),
+		copyItem: (oldItem: IsbVirtualCaster) => IsbVirtualCaster.clone(oldItem),
+		newItemPicker: (
+			parent: HTMLElement,
+			_listPicker: ListPicker<Player<any>, IsbVirtualCaster>,
+			index: number,
+			config: ListItemPickerConfig<Player<any>, IsbVirtualCaster>,
+		) => new IsbVirtualCasterPicker(parent, player, index, config),
+	});
+
+class IsbVirtualCasterPicker extends Input<Player<any>, IsbVirtualCaster> {
+	private readonly player: Player<any>;
+	private readonly casterIndex: number;
+
+	private readonly typePicker: Input<null, number>;
+	private readonly startDelayPicker: Input<null, number>;
+	private readonly castIntervalPicker: Input<null, number>;
+	private readonly skipEveryPicker: Input<null, number>;
+	private readonly critPicker: Input<null, number>;
+
+	private getCaster(): IsbVirtualCaster {
+		return this.player.getIsbVirtualCasters()[this.casterIndex] || IsbVirtualCaster.create();
+	}
+
+	private updateCaster(eventID: EventID, update: (caster: IsbVirtualCaster) => void) {
+		const casters = this.player.getIsbVirtualCasters();
+		if (!casters[this.casterIndex]) {
+			return;
+		}
+		update(casters[this.casterIndex]);
+		this.player.setIsbVirtualCasters(eventID, casters);
+	}
+
+	constructor(parent: HTMLElement, player: Player<any>, casterIndex: number, config: ListItemPickerConfig<Player<any>, IsbVirtualCaster>) {
+		super(parent, 'isb-virtual-caster-picker-root', player, config);
+		this.player = player;
+		this.casterIndex = casterIndex;
+
+		this.typePicker = new EnumPicker<null>(this.rootElem, null, {
+			id: `isb-virtual-caster-type-${casterIndex}`,
+			label: 'Class',
+			values: [
+				{ name: 'Warlock', value: IsbVirtualCasterType.Warlock },
+				{ name: 'Shadow Priest', value: IsbVirtualCasterType.ShadowPriest },
+			],
+			changedEvent: () => player.changeEmitter,
+			getValue: () => this.getCaster().type,
+			setValue: (eventID: EventID, _: null, newValue: number) => this.updateCaster(eventID, caster => (caster.type = newValue)),
+		});
+
+		this.startDelayPicker = new NumberPicker<null>(this.rootElem, null, {
+			id: `isb-virtual-caster-start-delay-${casterIndex}`,
+			label: 'Start Delay',
+			labelTooltip: 'Seconds until the first cast.',
+			float: true,
+			positive: true,
+			changedEvent: () => player.changeEmitter,
+			getValue: () => this.getCaster().startDelay,
+			setValue: (eventID: EventID, _: null, newValue: number) => this.updateCaster(eventID, caster => (caster.startDelay = newValue)),
+		});
+
+		this.castIntervalPicker = new NumberPicker<null>(this.rootElem, null, {
+			id: `isb-virtual-caster-cast-interval-${casterIndex}`,
+			label: 'Cast Interval',
+			labelTooltip: 'Seconds between casts. For Shadow Priests, each cast consumes one charge.',
+			float: true,
+			positive: true,
+			changedEvent: () => player.changeEmitter,
+			getValue: () => this.getCaster().castInterval,
+			setValue: (eventID: EventID, _: null, newValue: number) => this.updateCaster(eventID, caster => (caster.castInterval = newValue)),
+		});
+
+		this.skipEveryPicker = new NumberPicker<null>(this.rootElem, null, {
+			id: `isb-virtual-caster-skip-every-${casterIndex}`,
+			label: 'Skip Every',
+			labelTooltip: 'Skips every Nth cast, starting with the first. 0 never skips a cast.',
+			positive: true,
+			changedEvent: () => player.changeEmitter,
+			getValue: () => this.getCaster().skipEvery,
+			setValue: (eventID: EventID, _: null, newValue: number) => this.updateCaster(eventID, caster => (caster.skipEvery = newValue)),
+		});
+
+		this.critPicker = new NumberPicker<null>(this.rootElem, null, {
+			id: `isb-virtual-caster-crit-${casterIndex}`,
+			label: 'SB Crit',
+			labelTooltip: 'Percent chance for the Shadow Bolt to crit and apply Improved Shadow Bolt.',
+			float: true,
+			positive: true,
+			changedEvent: () => player.changeEmitter,
+			getValue: () => this.getCaster().crit,
+			setValue: (eventID: EventID, _: null, newValue: number) => this.updateCaster(eventID, caster => (caster.crit = newValue)),
+			showWhen: () => this.getCaster().type == IsbVirtualCasterType.Warlock,
+		});
+
+		this.init();
+	}
+
+	getInputElem(): HTMLElement | null {
+		return null;
+	}
+	getInputValue(): IsbVirtualCaster {
+		return IsbVirtualCaster.create({
+			type: this.typePicker.getInputValue(),
+			startDelay: this.startDelayPicker.getInputValue(),
+			castInterval: this.castIntervalPicker.getInputValue(),
+			skipEvery: this.skipEveryPicker.getInputValue(),
+			crit: this.critPicker.getInputValue(),
+		});
+	}
+	setInputValue(newValue: IsbVirtualCaster) {
+		if (!newValue) {
+			return;
+		}
+		this.typePicker.setInputValue(newValue.type);
+		this.startDelayPicker.setInputValue(newValue.startDelay);
+		this.castIntervalPicker.setInputValue(newValue.castInterval);
+		this.skipEveryPicker.setInputValue(newValue.skipEvery);
+		this.critPicker.setInputValue(newValue.crit);
+	}
+}
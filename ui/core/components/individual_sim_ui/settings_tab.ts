@@ -23,8 +23,9 @@ import { MultiIconPicker, MultiIconPickerItemConfig } from '../multi_icon_picker
 import { NumberPicker } from '../number_picker';
 import { SavedDataManager } from '../saved_data_manager';
 import { SimTab } from '../sim_tab';
-import { IsbConfig } from './../other_inputs';
+import { IsbConfig, LatencyConfig } from './../other_inputs';
 import { ConsumesPicker } from './consumes_picker';
+import { makeIsbVirtualCastersPicker } from './isb_virtual_casters_picker';
 import { ItemSwapPicker } from './item_swap_picker';
 import { PresetConfigurationCategory, PresetConfigurationPicker } from './preset_configuration_picker';
 
@@ -76,6 +77,7 @@ export class SettingsTab extends SimTab {
 			this.buildConsumesSection();
 			this.buildOtherSettings();
 			this.buildIsbSettings();
+			this.buildLatencySettings();
 
 			if (!this.simUI.isWithinRaidSim) {
 				this.buildBuffsSettings();
@@ -227,6 +229,7 @@ export class SettingsTab extends SimTab {
 			});
 
 			this.configureInputSection(contentBlock.bodyElement, IsbConfig);
+			makeIsbVirtualCastersPicker(contentBlock.bodyElement, this.simUI.player);
 
 			TypedEvent.onAny([this.simUI.player.talentsChangeEmitter, this.simUI.player.getRaid()!.debuffsChangeEmitter]).on(() => {
 				const isWlAndIsb = (this.simUI.player as Player<Spec.SpecWarlock>)?.getTalents().improvedShadowBolt > 0;
@@ -241,6 +244,16 @@ export class SettingsTab extends SimTab {
 		}
 	}
 
+	private buildLatencySettings() {
+		if (!this.simUI.isWithinRaidSim) {
+			const contentBlock = new ContentBlock(this.column2, 'latency-settings', {
+				header: { title: 'Latency', tooltip: LatencyConfig.tooltip },
+			});
+
+			this.configureInputSection(contentBlock.bodyElement, LatencyConfig);
+		}
+	}
+
 	private buildBuffsSettings() {
 		const buffOptions = relevantStatOptions(BuffDebuffInputs.RAID_BUFFS_CONFIG, this.simUI);
 		const miscBuffOptions = relevantStatOptions(BuffDebuffInputs.MISC_BUFFS_CONFIG, this.simUI);
@@ -360,6 +373,7 @@ export class SettingsTab extends SimTab {
 					);
 					simUI.player.setReactionTime(eventID, newSettings.reactionTimeMs);
 					simUI.player.setChannelClipDelay(eventID, newSettings.channelClipDelayMs);
+					simUI.player.setLatencyModel(eventID, newSettings.latencyModel);
 					simUI.player.setInFrontOfTarget(eventID, newSettings.inFrontOfTarget);
 					simUI.player.setDistanceFromTarget(eventID, newSettings.distanceFromTarget);
 					simUI.player.setHealingModel(eventID, newSettings.healingModel || HealingModel.create());
@@ -419,6 +433,7 @@ export class SettingsTab extends SimTab {
 			itemSwap: this.simUI.player.itemSwapSettings.toProto(),
 			reactionTimeMs: this.simUI.player.getReactionTime(),
 			channelClipDelayMs: this.simUI.player.getChannelClipDelay(),
+			latencyModel: this.simUI.player.getLatencyModel(),
 			inFrontOfTarget: this.simUI.player.getInFrontOfTarget(),
 			distanceFromTarget: this.simUI.player.getDistanceFromTarget(),
 			healingModel: this.simUI.player.getHealingModel(),
@@ -2,7 +2,8 @@ import { BooleanPicker } from '../components/boolean_picker.js';
 import { CURRENT_LEVEL_CAP } from '../constants/mechanics.js';
 import { CURRENT_PHASE } from '../constants/other.js';
 import { Player } from '../player.js';
-import { Spec, UnitReference } from '../proto/common.js';
+import { LatencyDistribution, LatencyModel, LatencyProfile } from '../proto/api.js';
+import { Spec, UnitReference, VirtualHealer, VirtualHealerTriage } from '../proto/common.js';
 import { emptyUnitReference } from '../proto_utils/utils.js';
 import { Sim } from '../sim.js';
 import { EventID, TypedEvent } from '../typed_event.js';
@@ -153,7 +154,7 @@ export const IsbSbFrequencey = {
 	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
 		player.setIsbSbFrequency(eventID, newValue);
 	},
-	showWhen: (player: Player<any>) => player.getRaid()?.getDebuffs().improvedShadowBolt == true,
+	showWhen: (player: Player<any>) => player.getRaid()?.getDebuffs().improvedShadowBolt == true && player.getIsbVirtualCasters().length == 0,
 };
 
 export const IsbCrit = {
@@ -169,7 +170,7 @@ export const IsbCrit = {
 	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
 		player.setIsbCrit(eventID, newValue);
 	},
-	showWhen: (player: Player<any>) => player.getRaid()?.getDebuffs().improvedShadowBolt == true,
+	showWhen: (player: Player<any>) => player.getRaid()?.getDebuffs().improvedShadowBolt == true && player.getIsbVirtualCasters().length == 0,
 };
 
 export const IsbWarlocks = {
@@ -184,7 +185,7 @@ export const IsbWarlocks = {
 	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
 		player.setIsbWarlocks(eventID, newValue);
 	},
-	showWhen: (player: Player<any>) => player.getRaid()?.getDebuffs().improvedShadowBolt == true,
+	showWhen: (player: Player<any>) => player.getRaid()?.getDebuffs().improvedShadowBolt == true && player.getIsbVirtualCasters().length == 0,
 };
 
 export const IsbSpriests = {
@@ -199,7 +200,8 @@ export const IsbSpriests = {
 		player.setIsbSpriests(eventID, newValue);
 	},
 	showWhen: (player: Player<any>) =>
-		player.getRaid()?.getDebuffs().improvedShadowBolt == true || (player as Player<Spec.SpecWarlock>)?.getTalents().improvedShadowBolt > 0,
+		(player.getRaid()?.getDebuffs().improvedShadowBolt == true || (player as Player<Spec.SpecWarlock>)?.getTalents().improvedShadowBolt > 0) &&
+		player.getIsbVirtualCasters().length == 0,
 };
 
 export const IsbConfig = {
@@ -207,6 +209,77 @@ export const IsbConfig = {
 	inputs: [IsbUsingShadowflame, IsbSbFrequencey, IsbCrit, IsbWarlocks, IsbSpriests],
 };
 
+const updateLatencyModel = (eventID: EventID, player: Player<any>, update: (model: LatencyModel) => void) => {
+	const model = player.getLatencyModel() || LatencyModel.create();
+	update(model);
+	player.setLatencyModel(eventID, model);
+};
+
+const updateNetworkLatency = (eventID: EventID, player: Player<any>, update: (profile: LatencyProfile) => void) => {
+	updateLatencyModel(eventID, player, model => {
+		model.network = model.network || LatencyProfile.create();
+		update(model.network);
+	});
+};
+
+export const NetworkLatencyDistribution = {
+	id: 'network-latency-distribution',
+	type: 'enum' as const,
+	label: 'Latency Distribution',
+	values: [
+		{ name: 'Fixed', value: LatencyDistribution.LatencyFixed },
+		{ name: 'Normal', value: LatencyDistribution.LatencyNormal },
+		{ name: 'Log-normal', value: LatencyDistribution.LatencyLogNormal },
+	],
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getLatencyModel()?.network?.distribution || LatencyDistribution.LatencyFixed,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		updateNetworkLatency(eventID, player, profile => (profile.distribution = newValue));
+	},
+};
+
+export const NetworkLatency = {
+	id: 'network-latency',
+	type: 'number' as const,
+	label: 'Latency',
+	labelTooltip: 'Average time for an action to reach the server, in milliseconds.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getLatencyModel()?.network?.meanMs || 0,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		updateNetworkLatency(eventID, player, profile => (profile.meanMs = newValue));
+	},
+};
+
+export const NetworkLatencyDeviation = {
+	id: 'network-latency-deviation',
+	type: 'number' as const,
+	label: 'Latency +/-',
+	labelTooltip: 'Standard deviation of the latency, in milliseconds.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getLatencyModel()?.network?.stddevMs || 0,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		updateNetworkLatency(eventID, player, profile => (profile.stddevMs = newValue));
+	},
+	showWhen: (player: Player<any>) => (player.getLatencyModel()?.network?.distribution || LatencyDistribution.LatencyFixed) != LatencyDistribution.LatencyFixed,
+};
+
+export const SpellQueueWindow = {
+	id: 'spell-queue-window',
+	type: 'number' as const,
+	label: 'Spell Queue Window',
+	labelTooltip: 'Casts pressed within this many milliseconds of the GCD or current cast ending are queued, hiding up to this much latency.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getLatencyModel()?.spellQueueWindowMs || 0,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		updateLatencyModel(eventID, player, model => (model.spellQueueWindowMs = newValue));
+	},
+};
+
+export const LatencyConfig = {
+	tooltip: 'Network latency added to casts, channels and /startattack macros.',
+	inputs: [NetworkLatencyDistribution, NetworkLatency, NetworkLatencyDeviation, SpellQueueWindow],
+};
+
 export const TankAssignment = {
 	id: 'tank-assignment',
 	type: 'enum' as const,
@@ -293,6 +366,44 @@ export const HealingCadenceVariation = {
 	enableWhen: (player: Player<any>) => (player.getRaid()?.getTanks() || []).find(tank => UnitReference.equals(tank, player.makeUnitReference())) != null,
 };
 
+// A level 60 priest casting max rank Greater Heal and Flash Heal.
+const defaultVirtualHealer = (): VirtualHealer =>
+	VirtualHealer.create({
+		spells: [
+			{ spellId: 25314, minHeal: 1966, maxHeal: 2194, castTimeSeconds: 2.5, manaCost: 710 },
+			{ spellId: 10917, minHeal: 812, maxHeal: 959, castTimeSeconds: 1.5, manaCost: 380 },
+		],
+		reactionTimeSeconds: 0.5,
+		mana: 8000,
+		mp5: 150,
+		critChance: 0.15,
+		triage: VirtualHealerTriage.TriageEmergency,
+		emergencyHealthPercent: 50,
+	});
+
+export const VirtualHealers = {
+	id: 'virtual-healers',
+	type: 'number' as const,
+	float: false,
+	label: 'Virtual Healers',
+	labelTooltip: `
+		<p>Number of simulated healers reacting to the damage taken. Each one casts Greater Heal and Flash Heal on the tank, with a reaction time and a limited mana pool, so gaps in the healing show up in Chance of Death and TMI.</p>
+		<p class="mb-0">This is in addition to Incoming HPS, so set that to a low value when using virtual healers.</p>
+	`,
+	changedEvent: (player: Player<any>) => player.getRaid()!.changeEmitter,
+	getValue: (player: Player<any>) => player.getHealingModel().virtualHealers.length,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const healingModel = player.getHealingModel();
+		const virtualHealers = healingModel.virtualHealers.slice(0, Math.max(0, newValue));
+		while (virtualHealers.length < newValue) {
+			virtualHealers.push(defaultVirtualHealer());
+		}
+		healingModel.virtualHealers = virtualHealers;
+		player.setHealingModel(eventID, healingModel);
+	},
+	enableWhen: (player: Player<any>) => (player.getRaid()?.getTanks() || []).find(tank => UnitReference.equals(tank, player.makeUnitReference())) != null,
+};
+
 export const BurstWindow = {
 	id: 'burst-window',
 	type: 'number' as const,
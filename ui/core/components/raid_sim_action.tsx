@@ -66,11 +66,16 @@ export interface ResultMetrics {
 	dpasp: string;
 	dtps: string;
 	tmi: string;
+	ehp: string;
+	spike: string;
+	ttd: string;
 	dur: string;
 	hps: string;
+	ehps: string;
 	tps: string;
 	tto: string;
 	oom: string;
+	move: string;
 }
 
 export interface ResultMetricCategories {
@@ -94,8 +99,13 @@ export class RaidSimResultsManager {
 		dtps: 'threat',
 		tmi: 'threat',
 		cod: 'threat',
+		ehp: 'threat',
+		spike: 'threat',
+		ttd: 'threat',
 		tto: 'healing',
 		hps: 'healing',
+		ehps: 'healing',
+		move: 'damage',
 	};
 
 	static resultMetricClasses: { [ResultMetrics: string]: string } = {
@@ -104,11 +114,16 @@ export class RaidSimResultsManager {
 		dpasp: 'results-sim-dpasp',
 		dtps: 'results-sim-dtps',
 		tmi: 'results-sim-tmi',
+		ehp: 'results-sim-ehp',
+		spike: 'results-sim-spike',
+		ttd: 'results-sim-ttd',
 		dur: 'results-sim-dur',
 		hps: 'results-sim-hps',
+		ehps: 'results-sim-ehps',
 		tps: 'results-sim-tps',
 		tto: 'results-sim-tto',
 		oom: 'results-sim-oom',
+		move: 'results-sim-move',
 	};
 
 	static metricsClasses: { [ResultMetricCategories: string]: string } = {
@@ -198,7 +213,12 @@ export class RaidSimResultsManager {
 		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['dps']}`, 'Damage Per Second');
 		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['dpasp']}`, 'Demonic Pact Average Spell Power');
 		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['tto']}`, 'Time To OOM');
+		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['move']}`, 'DPS lost to forced movement and downtime');
 		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['hps']}`, 'Healing+Shielding Per Second, including overhealing.');
+		setResultTooltip(
+			`.${RaidSimResultsManager.resultMetricClasses['ehps']}`,
+			'Effective Healing Per Second, excluding overhealing and shielding which absorbed no damage.',
+		);
 		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['tps']}`, 'Threat Per Second');
 		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['dtps']}`, 'Damage Taken Per Second');
 		setResultTooltip(
@@ -225,6 +245,21 @@ export class RaidSimResultsManager {
 				</p>
 			</>,
 		);
+		setResultTooltip(
+			`.${RaidSimResultsManager.resultMetricClasses['ehp']}`,
+			<>
+				<p>Effective Health (EHP)</p>
+				<p className="mb-0">
+					The raw melee damage from the primary target needed to kill you from full health, after armor, blocks and avoidance. Compare it against DTPS
+					to decide between avoidance and mitigation.
+				</p>
+			</>,
+		);
+		setResultTooltip(
+			`.${RaidSimResultsManager.resultMetricClasses['spike']}`,
+			'Largest damage taken within a TMI burst window, averaged over all iterations.',
+		);
+		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['ttd']}`, 'Time To Death, ignoring all incoming healing.');
 
 		if (!this.simUI.isIndividualSim()) {
 			[...this.simUI.resultsViewer.contentElem.querySelectorAll(`.${RaidSimResultsManager.resultMetricClasses['dpasp']}`)].forEach(e => e.remove());
@@ -234,6 +269,9 @@ export class RaidSimResultsManager {
 			[...this.simUI.resultsViewer.contentElem.querySelectorAll(`.${RaidSimResultsManager.resultMetricClasses['dtps']}`)].forEach(e => e.remove());
 			[...this.simUI.resultsViewer.contentElem.querySelectorAll(`.${RaidSimResultsManager.resultMetricClasses['tmi']}`)].forEach(e => e.remove());
 			[...this.simUI.resultsViewer.contentElem.querySelectorAll(`.${RaidSimResultsManager.resultMetricClasses['cod']}`)].forEach(e => e.remove());
+			[...this.simUI.resultsViewer.contentElem.querySelectorAll(`.${RaidSimResultsManager.resultMetricClasses['ehp']}`)].forEach(e => e.remove());
+			[...this.simUI.resultsViewer.contentElem.querySelectorAll(`.${RaidSimResultsManager.resultMetricClasses['spike']}`)].forEach(e => e.remove());
+			[...this.simUI.resultsViewer.contentElem.querySelectorAll(`.${RaidSimResultsManager.resultMetricClasses['ttd']}`)].forEach(e => e.remove());
 		}
 
 		const simReferenceSetButton = this.simUI.resultsViewer.contentElem.querySelector<HTMLSpanElement>('.results-sim-set-reference');
@@ -298,6 +336,11 @@ export class RaidSimResultsManager {
 		this.formatToplineResult(`.${RaidSimResultsManager.resultMetricClasses['dps']} .results-reference-diff`, res => res.raidMetrics.dps, 2);
 		if (this.simUI.isIndividualSim()) {
 			this.formatToplineResult(`.${RaidSimResultsManager.resultMetricClasses['hps']} .results-reference-diff`, res => res.raidMetrics.hps, 2);
+			this.formatToplineResult(
+				`.${RaidSimResultsManager.resultMetricClasses['ehps']} .results-reference-diff`,
+				res => res.getFirstPlayer()!.effectiveHps,
+				2,
+			);
 			this.formatToplineResult(`.${RaidSimResultsManager.resultMetricClasses['dpasp']} .results-reference-diff`, res => res.getPlayers()[0]!.dpasp, 2);
 			this.formatToplineResult(`.${RaidSimResultsManager.resultMetricClasses['tto']} .results-reference-diff`, res => res.getFirstPlayer()!.tto, 2);
 			this.formatToplineResult(`.${RaidSimResultsManager.resultMetricClasses['tps']} .results-reference-diff`, res => res.getFirstPlayer()!.tps, 2);
@@ -315,6 +358,14 @@ export class RaidSimResultsManager {
 				true,
 				true,
 			);
+			this.formatToplineResult(`.${RaidSimResultsManager.resultMetricClasses['ehp']} .results-reference-diff`, res => res.getFirstPlayer()!.ehp, 0);
+			this.formatToplineResult(
+				`.${RaidSimResultsManager.resultMetricClasses['spike']} .results-reference-diff`,
+				res => res.getFirstPlayer()!.maxSpike,
+				0,
+				true,
+			);
+			this.formatToplineResult(`.${RaidSimResultsManager.resultMetricClasses['ttd']} .results-reference-diff`, res => res.getFirstPlayer()!.timeToDeath, 2);
 		} else {
 			this.formatToplineResult(
 				`.${RaidSimResultsManager.resultMetricClasses['dtps']} .results-reference-diff`,
@@ -424,7 +475,20 @@ export class RaidSimResultsManager {
 		if (players.length === 1) {
 			const playerMetrics = players[0];
 			if (playerMetrics.getTargetIndex(filter) === null) {
-				const { chanceOfDeath, dps: dpsMetrics, dpasp: dpaspMetrics, tps: tpsMetrics, dtps: dtpsMetrics, tmi: tmiMetrics } = playerMetrics;
+				const {
+					chanceOfDeath,
+					dps: dpsMetrics,
+					dpasp: dpaspMetrics,
+					tps: tpsMetrics,
+					dtps: dtpsMetrics,
+					tmi: tmiMetrics,
+					movementDpsLost: movementMetrics,
+					hps: hpsMetrics,
+					effectiveHps: effectiveHpsMetrics,
+					ehp: ehpMetrics,
+					maxSpike: maxSpikeMetrics,
+					timeToDeath: timeToDeathMetrics,
+				} = playerMetrics;
 
 				resultColumns.push({
 					name: 'DPS',
@@ -442,6 +506,30 @@ export class RaidSimResultsManager {
 					});
 				}
 
+				if (movementMetrics.avg) {
+					resultColumns.push({
+						name: 'MOVE',
+						average: movementMetrics.avg,
+						stdev: movementMetrics.stdev,
+						classes: this.getResultsLineClasses('move'),
+					});
+				}
+
+				if (hpsMetrics.avg) {
+					resultColumns.push({
+						name: 'HPS',
+						average: hpsMetrics.avg,
+						stdev: hpsMetrics.stdev,
+						classes: this.getResultsLineClasses('hps'),
+					});
+					resultColumns.push({
+						name: 'EHPS',
+						average: effectiveHpsMetrics.avg,
+						stdev: effectiveHpsMetrics.stdev,
+						classes: this.getResultsLineClasses('ehps'),
+					});
+				}
+
 				resultColumns.push({
 					name: 'TPS',
 					average: tpsMetrics.avg,
@@ -470,6 +558,28 @@ export class RaidSimResultsManager {
 					classes: this.getResultsLineClasses('cod'),
 					unit: 'percentage',
 				});
+
+				if (ehpMetrics.avg) {
+					resultColumns.push({
+						name: 'EHP',
+						average: ehpMetrics.avg,
+						stdev: ehpMetrics.stdev,
+						classes: this.getResultsLineClasses('ehp'),
+					});
+					resultColumns.push({
+						name: 'Spike',
+						average: maxSpikeMetrics.avg,
+						stdev: maxSpikeMetrics.stdev,
+						classes: this.getResultsLineClasses('spike'),
+					});
+					resultColumns.push({
+						name: 'TTD',
+						average: timeToDeathMetrics.avg,
+						stdev: timeToDeathMetrics.stdev,
+						classes: this.getResultsLineClasses('ttd'),
+						unit: 'seconds',
+					});
+				}
 			} else {
 				const actions = simResult.getRaidIndexedActionMetrics(filter);
 				if (!!actions.length) {
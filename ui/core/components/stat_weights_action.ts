@@ -134,7 +134,8 @@ function scaledEpValue(stat: UnitStat, epRatios: number[], result: StatWeightsRe
 		(result.tps?.epValues ? epRatios[2] * stat.getProtoValue(result.tps.epValues) : 0) +
 		(result.dtps?.epValues ? epRatios[3] * stat.getProtoValue(result.dtps.epValues) : 0) +
 		(result.tmi?.epValues ? epRatios[4] * stat.getProtoValue(result.tmi.epValues) : 0) +
-		(result.pDeath?.epValues ? epRatios[5] * stat.getProtoValue(result.pDeath.epValues) : 0)
+		(result.pDeath?.epValues ? epRatios[5] * stat.getProtoValue(result.pDeath.epValues) : 0) +
+		(result.ehp?.epValues ? epRatios[6] * stat.getProtoValue(result.ehp.epValues) : 0)
 	);
 }
 
@@ -277,6 +278,18 @@ class EpWeightsMenu extends BaseModal {
 									<i class="fa fa-copy"></i>
 								</a>
 							</th>
+							<th class="threat-metrics type-weight">
+								<span>EHP Weight</span>
+								<a href="javascript:void(0)" role="button" class="col-action">
+									<i class="fa fa-copy"></i>
+								</a>
+							</th>
+							<th class="threat-metrics type-ep">
+								<span>EHP EP</span>
+								<a href="javascript:void(0)" role="button" class="col-action">
+									<i class="fa fa-copy"></i>
+								</a>
+							</th>
 							<th style="text-align: center">
 								<span>Current EP</span>
 								<a href="javascript:void(0)" role="button" class="col-action">
@@ -311,6 +324,10 @@ class EpWeightsMenu extends BaseModal {
 							</td>
 							<td class="threat-metrics type-ratio type-ep experimental">
 							</td>
+							<td class="threat-metrics type-ratio type-weight">
+							</td>
+							<td class="threat-metrics type-ratio type-ep">
+							</td>
 							<td style="text-align: center; vertical-align: middle;">
 								<button class="btn btn-primary compute-ep">
 									<i class="fas fa-calculator"></i>
@@ -580,7 +597,20 @@ class EpWeightsMenu extends BaseModal {
 			() => this.getPrevSimResult().pDeath!.epValues,
 			() => this.getTankEpRefStat(),
 		);
-		makeUpdateWeights(colActionButtons[12], 'Current EP Weights. Used to sort the gear selector menus.', 'Restore Default EP', () =>
+		makeUpdateWeights(
+			colActionButtons[12],
+			'Per-point increase in EHP (Effective Health against melee attacks) for each stat.',
+			'Copy to Current EP',
+			() => this.getPrevSimResult().ehp!.weights,
+		);
+		makeUpdateWeights(
+			colActionButtons[13],
+			'EP (Equivalency Points) for EHP (Effective Health against melee attacks) for each stat.',
+			'Copy to Current EP',
+			() => this.getPrevSimResult().ehp!.epValues,
+			() => this.getTankEpRefStat(),
+		);
+		makeUpdateWeights(colActionButtons[14], 'Current EP Weights. Used to sort the gear selector menus.', 'Restore Default EP', () =>
 			this.simUI.individualConfig.defaults.epWeights.toProto(),
 		);
 
@@ -634,7 +664,8 @@ class EpWeightsMenu extends BaseModal {
 				const scaledDtpsEp = Stats.fromProto(results.dtps!.epValues).scale(epRatios[3]);
 				const scaledTmiEp = Stats.fromProto(results.tmi!.epValues).scale(epRatios[4]);
 				const scaledPDeathEp = Stats.fromProto(results.pDeath!.epValues).scale(epRatios[5]);
-				const newEp = scaledDpsEp.add(scaledHpsEp).add(scaledTpsEp).add(scaledDtpsEp).add(scaledTmiEp).add(scaledPDeathEp);
+				const scaledEhpEp = Stats.fromProto(results.ehp?.epValues).scale(epRatios[6]);
+				const newEp = scaledDpsEp.add(scaledHpsEp).add(scaledTpsEp).add(scaledDtpsEp).add(scaledTmiEp).add(scaledPDeathEp).add(scaledEhpEp);
 				this.setEpWeightsWithoutExcluded(newEp);
 			} else {
 				const scaledDpsWeights = Stats.fromProto(results.dps!.weights).scale(epRatios[0]);
@@ -643,12 +674,14 @@ class EpWeightsMenu extends BaseModal {
 				const scaledDtpsWeights = Stats.fromProto(results.dtps!.weights).scale(epRatios[3]);
 				const scaledTmiWeights = Stats.fromProto(results.tmi!.weights).scale(epRatios[4]);
 				const scaledPDeathWeights = Stats.fromProto(results.pDeath!.weights).scale(epRatios[5]);
+				const scaledEhpWeights = Stats.fromProto(results.ehp?.weights).scale(epRatios[6]);
 				const newWeights = scaledDpsWeights
 					.add(scaledHpsWeights)
 					.add(scaledTpsWeights)
 					.add(scaledDtpsWeights)
 					.add(scaledTmiWeights)
-					.add(scaledPDeathWeights);
+					.add(scaledPDeathWeights)
+					.add(scaledEhpWeights);
 				this.setEpWeightsWithoutExcluded(newWeights);
 			}
 			this.updateTable();
@@ -722,6 +755,7 @@ class EpWeightsMenu extends BaseModal {
 			${this.makeTableRowCells(stat, result?.dtps, 'threat-metrics', rowTotalEp, epRatios[3])}
 			${this.makeTableRowCells(stat, result?.tmi, 'threat-metrics experimental', rowTotalEp, epRatios[4])}
 			${this.makeTableRowCells(stat, result?.pDeath, 'threat-metrics experimental', rowTotalEp, epRatios[5])}
+			${this.makeTableRowCells(stat, result?.ehp, 'threat-metrics', rowTotalEp, epRatios[6])}
 			<td class="current-ep"></td>
 		`;
 
@@ -829,6 +863,7 @@ class EpWeightsMenu extends BaseModal {
 			normaliseValue(this.simUI.tankRefStat, result.dtps!);
 			normaliseValue(this.simUI.tankRefStat, result.tmi!);
 			normaliseValue(this.simUI.tankRefStat, result.pDeath!);
+			if (result.ehp) normaliseValue(this.simUI.tankRefStat, result.ehp);
 		}
 		return result;
 	}
@@ -885,6 +920,12 @@ class EpWeightsMenu extends BaseModal {
 					epValues: new Stats().toProto(),
 					epValuesStdev: new Stats().toProto(),
 				},
+				ehp: {
+					weights: new Stats().toProto(),
+					weightsStdev: new Stats().toProto(),
+					epValues: new Stats().toProto(),
+					epValuesStdev: new Stats().toProto(),
+				},
 			})
 		);
 	}
@@ -45,11 +45,16 @@ export const TOOLTIP_METRIC_LABELS = {
 	HPM: 'Healing / Mana',
 	HPET: 'Healing / Avg Cast Time',
 	HPS: 'Healing / Encounter Duration',
+	EHPS: '(Healing - Overhealing + Absorbed Shielding) / Encounter Duration',
+	'Overheal %': 'Overhealing / Healing',
 	// Damage taken metrics
 	'Damage Taken': 'Total Damage taken',
 	DTPS: 'Damage Taken / Encounter Duration',
 	COD: 'Chance of Death',
 	TMI: 'Theck-Meloree Index',
+	EHP: 'Raw melee damage needed to kill from full health, after armor and avoidance',
+	Spike: 'Largest damage taken in a burst window',
+	TTD: 'Seconds until the damage taken adds up to max health, ignoring healing',
 	// Cast metrics
 	Casts: 'Casts',
 	CPM: 'Casts / (Encounter Duration / 60 Seconds)',
@@ -1,6 +1,17 @@
 import * as Mechanics from './constants/mechanics.js';
 import { UnitMetadataList } from './player.js';
-import { Encounter as EncounterProto, PresetEncounter, PresetTarget, Target as TargetProto } from './proto/common.js';
+import {
+	AggroPullOutcome,
+	Encounter as EncounterProto,
+	EncounterDamageEvent,
+	EncounterDamageProfile,
+	EncounterMovementEvent,
+	EncounterMovementProfile,
+	EncounterPhase,
+	PresetEncounter,
+	PresetTarget,
+	Target as TargetProto,
+} from './proto/common.js';
 import { Sim } from './sim.js';
 import { EventID, TypedEvent } from './typed_event.js';
 
@@ -20,14 +31,27 @@ export class Encounter {
 	private executeProportion25 = DEFAULT_EXECUTE_25;
 	private executeProportion35 = DEFAULT_EXECUTE_35;
 	private useHealth = false;
+	private useTimeToDieEstimate = false;
+	private useTargetHealth = false;
 
 	targets!: Array<TargetProto>;
+	private phases: Array<EncounterPhase> = [];
+	private movementEvents: Array<EncounterMovementEvent> = [];
+	private movementProfile: EncounterMovementProfile = EncounterMovementProfile.MovementProfileNone;
+	private damageEvents: Array<EncounterDamageEvent> = [];
+	private damageProfile: EncounterDamageProfile = EncounterDamageProfile.DamageProfileNone;
+	private aggroPullOutcome: AggroPullOutcome = AggroPullOutcome.AggroPullIgnored;
+	private virtualTankTps = 0;
 	targetsMetadata: UnitMetadataList;
 	presetTargets!: Array<PresetTarget>;
 
 	readonly targetsChangeEmitter = new TypedEvent<void>();
 	readonly durationChangeEmitter = new TypedEvent<void>();
 	readonly executeProportionChangeEmitter = new TypedEvent<void>();
+	readonly phasesChangeEmitter = new TypedEvent<void>();
+	readonly movementChangeEmitter = new TypedEvent<void>();
+	readonly damageChangeEmitter = new TypedEvent<void>();
+	readonly threatChangeEmitter = new TypedEvent<void>();
 
 	// Emits when any of the above emitters emit.
 	readonly changeEmitter = new TypedEvent<void>();
@@ -42,9 +66,15 @@ export class Encounter {
 
 			this.targets = [presetTarget.target!];
 
-			[this.targetsChangeEmitter, this.durationChangeEmitter, this.executeProportionChangeEmitter].forEach(emitter =>
-				emitter.on(eventID => this.changeEmitter.emit(eventID)),
-			);
+			[
+				this.targetsChangeEmitter,
+				this.durationChangeEmitter,
+				this.executeProportionChangeEmitter,
+				this.phasesChangeEmitter,
+				this.movementChangeEmitter,
+				this.damageChangeEmitter,
+				this.threatChangeEmitter,
+			].forEach(emitter => emitter.on(eventID => this.changeEmitter.emit(eventID)));
 		});
 	}
 
@@ -111,12 +141,112 @@ export class Encounter {
 		this.executeProportionChangeEmitter.emit(eventID);
 	}
 
+	getUseTimeToDieEstimate(): boolean {
+		return this.useTimeToDieEstimate;
+	}
+	setUseTimeToDieEstimate(eventID: EventID, newUseTimeToDieEstimate: boolean) {
+		if (newUseTimeToDieEstimate == this.useTimeToDieEstimate) return;
+
+		this.useTimeToDieEstimate = newUseTimeToDieEstimate;
+		this.durationChangeEmitter.emit(eventID);
+	}
+
+	getUseTargetHealth(): boolean {
+		return this.useTargetHealth;
+	}
+	setUseTargetHealth(eventID: EventID, newUseTargetHealth: boolean) {
+		if (newUseTargetHealth == this.useTargetHealth) return;
+
+		this.useTargetHealth = newUseTargetHealth;
+		this.durationChangeEmitter.emit(eventID);
+	}
+
+	getPhases(): Array<EncounterPhase> {
+		return this.phases.map(phase => EncounterPhase.clone(phase));
+	}
+	setPhases(eventID: EventID, newPhases: Array<EncounterPhase>) {
+		if (newPhases.length == this.phases.length && newPhases.every((phase, i) => EncounterPhase.equals(phase, this.phases[i]))) return;
+
+		this.phases = newPhases.map(phase => EncounterPhase.clone(phase));
+		this.phasesChangeEmitter.emit(eventID);
+	}
+
+	getMovementEvents(): Array<EncounterMovementEvent> {
+		return this.movementEvents.map(event => EncounterMovementEvent.clone(event));
+	}
+	setMovementEvents(eventID: EventID, newEvents: Array<EncounterMovementEvent>) {
+		if (newEvents.length == this.movementEvents.length && newEvents.every((event, i) => EncounterMovementEvent.equals(event, this.movementEvents[i])))
+			return;
+
+		this.movementEvents = newEvents.map(event => EncounterMovementEvent.clone(event));
+		this.movementChangeEmitter.emit(eventID);
+	}
+
+	getMovementProfile(): EncounterMovementProfile {
+		return this.movementProfile;
+	}
+	setMovementProfile(eventID: EventID, newProfile: EncounterMovementProfile) {
+		if (newProfile == this.movementProfile) return;
+
+		this.movementProfile = newProfile;
+		this.movementChangeEmitter.emit(eventID);
+	}
+
+	getDamageEvents(): Array<EncounterDamageEvent> {
+		return this.damageEvents.map(event => EncounterDamageEvent.clone(event));
+	}
+	setDamageEvents(eventID: EventID, newEvents: Array<EncounterDamageEvent>) {
+		if (newEvents.length == this.damageEvents.length && newEvents.every((event, i) => EncounterDamageEvent.equals(event, this.damageEvents[i]))) return;
+
+		this.damageEvents = newEvents.map(event => EncounterDamageEvent.clone(event));
+		this.damageChangeEmitter.emit(eventID);
+	}
+
+	getDamageProfile(): EncounterDamageProfile {
+		return this.damageProfile;
+	}
+	setDamageProfile(eventID: EventID, newProfile: EncounterDamageProfile) {
+		if (newProfile == this.damageProfile) return;
+
+		this.damageProfile = newProfile;
+		this.damageChangeEmitter.emit(eventID);
+	}
+
+	getAggroPullOutcome(): AggroPullOutcome {
+		return this.aggroPullOutcome;
+	}
+	setAggroPullOutcome(eventID: EventID, newOutcome: AggroPullOutcome) {
+		if (newOutcome == this.aggroPullOutcome) return;
+
+		this.aggroPullOutcome = newOutcome;
+		this.threatChangeEmitter.emit(eventID);
+	}
+
+	getVirtualTankTps(): number {
+		return this.virtualTankTps;
+	}
+	setVirtualTankTps(eventID: EventID, newTps: number) {
+		if (newTps == this.virtualTankTps) return;
+
+		this.virtualTankTps = newTps;
+		this.threatChangeEmitter.emit(eventID);
+	}
+
 	matchesPreset(preset: PresetEncounter): boolean {
+		if (preset.settings && !EncounterProto.equals(EncounterProto.create({ ...this.toProto(), targets: [] }), preset.settings)) {
+			return false;
+		}
 		return preset.targets.length == this.targets.length && this.targets.every((t, i) => TargetProto.equals(t, preset.targets[i].target));
 	}
 
 	applyPreset(eventID: EventID, preset: PresetEncounter) {
-		this.targets = preset.targets.map(presetTarget => presetTarget.target || TargetProto.create());
+		const targets = preset.targets.map(presetTarget => presetTarget.target || TargetProto.create());
+		if (preset.settings) {
+			// Generated fight styles replace the whole encounter.
+			this.fromProto(eventID, EncounterProto.create({ ...preset.settings, targets }));
+			return;
+		}
+		this.targets = targets;
 		this.targetsChangeEmitter.emit(eventID);
 	}
 
@@ -133,7 +263,16 @@ export class Encounter {
 			executeProportion25: this.executeProportion25,
 			executeProportion35: this.executeProportion35,
 			useHealth: this.useHealth,
+			useTimeToDieEstimate: this.useTimeToDieEstimate,
+			useTargetHealth: this.useTargetHealth,
 			targets: this.targets,
+			phases: this.phases,
+			movementEvents: this.movementEvents,
+			movementProfile: this.movementProfile,
+			damageEvents: this.damageEvents,
+			damageProfile: this.damageProfile,
+			aggroPullOutcome: this.aggroPullOutcome,
+			virtualTankTps: this.virtualTankTps,
 		});
 	}
 
@@ -145,6 +284,15 @@ export class Encounter {
 			this.setExecuteProportion25(eventID, proto.executeProportion25);
 			this.setExecuteProportion35(eventID, proto.executeProportion35);
 			this.setUseHealth(eventID, proto.useHealth);
+			this.setUseTimeToDieEstimate(eventID, proto.useTimeToDieEstimate);
+			this.setUseTargetHealth(eventID, proto.useTargetHealth);
+			this.setPhases(eventID, proto.phases);
+			this.setMovementEvents(eventID, proto.movementEvents);
+			this.setMovementProfile(eventID, proto.movementProfile);
+			this.setDamageEvents(eventID, proto.damageEvents);
+			this.setDamageProfile(eventID, proto.damageProfile);
+			this.setAggroPullOutcome(eventID, proto.aggroPullOutcome);
+			this.setVirtualTankTps(eventID, proto.virtualTankTps);
 			this.targets = proto.targets;
 			this.targetsChangeEmitter.emit(eventID);
 		});
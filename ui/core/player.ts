@@ -9,6 +9,8 @@ import { MAX_PARTY_SIZE, Party } from './party.js';
 import {
 	AuraStats as AuraStatsProto,
 	ErrorOutcomeType,
+	IsbVirtualCaster,
+	LatencyModel,
 	Player as PlayerProto,
 	PlayerStats,
 	SpellStats as SpellStatsProto,
@@ -244,6 +246,7 @@ export class Player<SpecType extends Spec> {
 	private specOptions: SpecOptions<SpecType>;
 	private reactionTime = 0;
 	private channelClipDelay = 0;
+	private latencyModel: LatencyModel | undefined = undefined;
 	private inFrontOfTarget = false;
 	private distanceFromTarget = 0;
 	private healingModel: HealingModel = HealingModel.create();
@@ -254,6 +257,7 @@ export class Player<SpecType extends Spec> {
 	private isbCrit = 25.0;
 	private isbWarlocks = 1.0;
 	private isbSpriests = 0;
+	private isbVirtualCasters: Array<IsbVirtualCaster> = [];
 
 	private readonly autoRotationGenerator: AutoRotationGenerator<SpecType> | null = null;
 	private readonly simpleRotationGenerator: SimpleRotationGenerator<SpecType> | null = null;
@@ -265,7 +269,7 @@ export class Player<SpecType extends Spec> {
 
 	readonly specTypeFunctions: SpecTypeFunctions<SpecType>;
 
-	private static readonly numEpRatios = 6;
+	private static readonly numEpRatios = 7;
 	private epRatios: Array<number> = new Array<number>(Player.numEpRatios).fill(0);
 	private epWeights: Stats = new Stats();
 	private currentStats: PlayerStats = PlayerStats.create();
@@ -883,6 +887,18 @@ export class Player<SpecType extends Spec> {
 		this.miscOptionsChangeEmitter.emit(eventID);
 	}
 
+	getLatencyModel(): LatencyModel | undefined {
+		return this.latencyModel ? LatencyModel.clone(this.latencyModel) : undefined;
+	}
+
+	setLatencyModel(eventID: EventID, newLatencyModel: LatencyModel | undefined) {
+		if (this.latencyModel == newLatencyModel) return;
+		if (this.latencyModel && newLatencyModel && LatencyModel.equals(this.latencyModel, newLatencyModel)) return;
+
+		this.latencyModel = newLatencyModel ? LatencyModel.clone(newLatencyModel) : undefined;
+		this.miscOptionsChangeEmitter.emit(eventID);
+	}
+
 	getInFrontOfTarget(): boolean {
 		return this.inFrontOfTarget;
 	}
@@ -1003,6 +1019,21 @@ export class Player<SpecType extends Spec> {
 		this.changeEmitter.emit(eventID);
 	}
 
+	getIsbVirtualCasters(): Array<IsbVirtualCaster> {
+		return this.isbVirtualCasters.map(caster => IsbVirtualCaster.clone(caster));
+	}
+
+	setIsbVirtualCasters(eventID: EventID, newIsbVirtualCasters: Array<IsbVirtualCaster>) {
+		if (
+			this.isbVirtualCasters.length == newIsbVirtualCasters.length &&
+			this.isbVirtualCasters.every((caster, i) => IsbVirtualCaster.equals(caster, newIsbVirtualCasters[i]))
+		)
+			return;
+
+		this.isbVirtualCasters = newIsbVirtualCasters.map(caster => IsbVirtualCaster.clone(caster));
+		this.changeEmitter.emit(eventID);
+	}
+
 	computeStatsEP(stats?: Stats): number {
 		if (stats === undefined) {
 			return 0;
@@ -1342,6 +1373,7 @@ export class Player<SpecType extends Spec> {
 				profession2: this.getProfession2(),
 				reactionTimeMs: this.getReactionTime(),
 				channelClipDelayMs: this.getChannelClipDelay(),
+				latencyModel: this.getLatencyModel(),
 				inFrontOfTarget: this.getInFrontOfTarget(),
 				distanceFromTarget: this.getDistanceFromTarget(),
 				healingModel: this.getHealingModel(),
@@ -1350,6 +1382,7 @@ export class Player<SpecType extends Spec> {
 				isbCrit: this.getIsbCrit(),
 				isbWarlocks: this.getIsbWarlocks(),
 				isbSpriests: this.getIsbSpriests(),
+				isbVirtualCasters: this.getIsbVirtualCasters(),
 			});
 			player = withSpecProto(this.spec, player, this.getSpecOptions());
 		}
@@ -1405,6 +1438,7 @@ export class Player<SpecType extends Spec> {
 				this.setProfession2(eventID, proto.profession2);
 				this.setReactionTime(eventID, proto.reactionTimeMs);
 				this.setChannelClipDelay(eventID, proto.channelClipDelayMs);
+				this.setLatencyModel(eventID, proto.latencyModel);
 				this.setInFrontOfTarget(eventID, proto.inFrontOfTarget);
 				this.setDistanceFromTarget(eventID, proto.distanceFromTarget);
 				this.setHealingModel(eventID, proto.healingModel || HealingModel.create());
@@ -1412,6 +1446,7 @@ export class Player<SpecType extends Spec> {
 				this.setIsbCrit(eventID, proto.isbCrit);
 				this.setIsbWarlocks(eventID, proto.isbWarlocks);
 				this.setIsbSpriests(eventID, proto.isbSpriests);
+				this.setIsbVirtualCasters(eventID, proto.isbVirtualCasters);
 			}
 			if (loadCategory(SimSettingCategories.External)) {
 				this.setBuffs(eventID, proto.buffs || IndividualBuffs.create());
@@ -134,6 +134,18 @@ export class ActionId {
 				baseName = 'Defensive Equipment';
 				iconUrl = 'https://wow.zamimg.com/images/wow/icons/large/inv_trinket_naxxramas05.jpg';
 				break;
+			case OtherAction.OtherActionEncounterPhase:
+				name = `Phase ${tag}`;
+				iconUrl = 'https://wow.zamimg.com/images/wow/icons/large/inv_misc_pocketwatch_01.jpg';
+				break;
+			case OtherAction.OtherActionEncounterDamage:
+				name = `Encounter Damage ${tag}`;
+				iconUrl = 'https://wow.zamimg.com/images/wow/icons/large/spell_fire_selfdestruct.jpg';
+				break;
+			case OtherAction.OtherActionVirtualHealer:
+				name = `Virtual Healer Spell ${tag}`;
+				iconUrl = 'https://wow.zamimg.com/images/wow/icons/large/spell_holy_heal.jpg';
+				break;
 		}
 		this.baseName = baseName;
 		this.name = name || baseName;
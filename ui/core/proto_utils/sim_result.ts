@@ -310,6 +310,12 @@ export class UnitMetrics {
 	readonly dtps: DistributionMetricsProto;
 	readonly tmi: DistributionMetricsProto;
 	readonly tto: DistributionMetricsProto;
+	readonly movementDpsLost: DistributionMetricsProto;
+	readonly effectiveHps: DistributionMetricsProto;
+	readonly overhealPercent: DistributionMetricsProto;
+	readonly ehp: DistributionMetricsProto;
+	readonly maxSpike: DistributionMetricsProto;
+	readonly timeToDeath: DistributionMetricsProto;
 	readonly actions: Array<ActionMetrics>;
 	readonly auras: Array<AuraMetrics>;
 	readonly resources: Array<ResourceMetrics>;
@@ -361,6 +367,12 @@ export class UnitMetrics {
 		this.dtps = this.metrics.dtps!;
 		this.tmi = this.metrics.tmi!;
 		this.tto = this.metrics.tto!;
+		this.movementDpsLost = this.metrics.movementDpsLost ?? DistributionMetricsProto.create();
+		this.effectiveHps = this.metrics.effectiveHps ?? DistributionMetricsProto.create();
+		this.overhealPercent = this.metrics.overhealPercent ?? DistributionMetricsProto.create();
+		this.ehp = this.metrics.ehp ?? DistributionMetricsProto.create();
+		this.maxSpike = this.metrics.maxSpike ?? DistributionMetricsProto.create();
+		this.timeToDeath = this.metrics.timeToDeath ?? DistributionMetricsProto.create();
 		this.actions = actions;
 		this.auras = auras;
 		this.resources = resources;
@@ -954,6 +966,34 @@ export class ActionMetrics {
 		return this.combinedMetrics.critHealing / this.iterations;
 	}
 
+	get overhealing() {
+		return this.combinedMetrics.overhealing;
+	}
+
+	get avgOverhealing() {
+		return this.combinedMetrics.overhealing / this.iterations;
+	}
+
+	get overhealingPercent() {
+		return this.combinedMetrics.overhealingPercent;
+	}
+
+	get avgEffectiveHealing() {
+		return this.combinedMetrics.avgEffectiveHealing;
+	}
+
+	get effectiveHps() {
+		return this.combinedMetrics.effectiveHps;
+	}
+
+	get absorbedShielding() {
+		return this.combinedMetrics.absorbedShielding;
+	}
+
+	get wastedShielding() {
+		return this.combinedMetrics.wastedShielding;
+	}
+
 	get hps() {
 		return this.combinedMetrics.hps;
 	}
@@ -1509,6 +1549,34 @@ export class TargetedActionMetrics {
 		return this.data.critHealing / this.iterations;
 	}
 
+	get overhealing() {
+		return this.data.overhealing;
+	}
+
+	get avgOverhealing() {
+		return this.data.overhealing / this.iterations;
+	}
+
+	get overhealingPercent() {
+		return (this.data.overhealing / (this.data.healing || 1)) * 100;
+	}
+
+	get avgEffectiveHealing() {
+		return (this.data.effectiveHealing + this.data.absorbedShielding) / this.iterations;
+	}
+
+	get effectiveHps() {
+		return (this.data.effectiveHealing + this.data.absorbedShielding) / this.iterations / this.duration;
+	}
+
+	get absorbedShielding() {
+		return this.data.absorbedShielding;
+	}
+
+	get wastedShielding() {
+		return this.data.wastedShielding;
+	}
+
 	get shielding() {
 		return this.data.shielding;
 	}
@@ -1770,7 +1838,11 @@ export class TargetedActionMetrics {
 				threat: sum(actions.map(a => a.data.threat)),
 				healing: sum(actions.map(a => a.data.healing)),
 				critHealing: sum(actions.map(a => a.data.critHealing)),
+				overhealing: sum(actions.map(a => a.data.overhealing)),
+				effectiveHealing: sum(actions.map(a => a.data.effectiveHealing)),
 				shielding: sum(actions.map(a => a.data.shielding)),
+				absorbedShielding: sum(actions.map(a => a.data.absorbedShielding)),
+				wastedShielding: sum(actions.map(a => a.data.wastedShielding)),
 				castTimeMs: sum(actions.map(a => a.data.castTimeMs)),
 			}),
 			{
@@ -1,6 +1,6 @@
 import { MAX_PARTY_SIZE,Party } from './party.js';
 import { Player } from './player.js';
-import { Raid as RaidProto } from './proto/api.js';
+import { Raid as RaidProto, RaidCooldownAssignment } from './proto/api.js';
 import {
 	Class,
 	Debuffs,
@@ -22,6 +22,9 @@ export class Raid {
 	private tanks: Array<UnitReference> = [];
 	private targetDummies = 0;
 	private numActiveParties = 5;
+	private simulateRaidBuffs = false;
+	private cooldownAssignments: Array<RaidCooldownAssignment> = [];
+	private simCooldownAssignmentGains = false;
 
 	// Emits when a raid member is added/removed/moved.
 	readonly compChangeEmitter = new TypedEvent<void>();
@@ -31,6 +34,9 @@ export class Raid {
 	readonly tanksChangeEmitter = new TypedEvent<void>();
 	readonly targetDummiesChangeEmitter = new TypedEvent<void>();
 	readonly numActivePartiesChangeEmitter = new TypedEvent<void>();
+	readonly simulateRaidBuffsChangeEmitter = new TypedEvent<void>();
+	readonly cooldownAssignmentsChangeEmitter = new TypedEvent<void>();
+	readonly simCooldownAssignmentGainsChangeEmitter = new TypedEvent<void>();
 
 	// Emits when anything in the raid changes.
 	readonly changeEmitter: TypedEvent<void>;
@@ -62,6 +68,9 @@ export class Raid {
 			this.debuffsChangeEmitter,
 			this.tanksChangeEmitter,
 			this.targetDummiesChangeEmitter,
+			this.simulateRaidBuffsChangeEmitter,
+			this.cooldownAssignmentsChangeEmitter,
+			this.simCooldownAssignmentGainsChangeEmitter,
 		], 'RaidChange');
 
 		this.changeEmitter.on(() => {
@@ -182,6 +191,43 @@ export class Raid {
 			this.numActivePartiesChangeEmitter.emit(eventID);
 		}
 	}
+
+	getSimulateRaidBuffs(): boolean {
+		return this.simulateRaidBuffs;
+	}
+	setSimulateRaidBuffs(eventID: EventID, newSimulateRaidBuffs: boolean) {
+		if (this.simulateRaidBuffs == newSimulateRaidBuffs) return;
+
+		this.simulateRaidBuffs = newSimulateRaidBuffs;
+		this.simulateRaidBuffsChangeEmitter.emit(eventID);
+	}
+
+	getCooldownAssignments(): Array<RaidCooldownAssignment> {
+		// Make a defensive copy
+		return this.cooldownAssignments.map(assignment => RaidCooldownAssignment.clone(assignment));
+	}
+	setCooldownAssignments(eventID: EventID, newCooldownAssignments: Array<RaidCooldownAssignment>) {
+		if (
+			this.cooldownAssignments.length == newCooldownAssignments.length &&
+			this.cooldownAssignments.every((assignment, i) => RaidCooldownAssignment.equals(assignment, newCooldownAssignments[i]))
+		)
+			return;
+
+		// Make a defensive copy
+		this.cooldownAssignments = newCooldownAssignments.map(assignment => RaidCooldownAssignment.clone(assignment));
+		this.cooldownAssignmentsChangeEmitter.emit(eventID);
+	}
+
+	getSimCooldownAssignmentGains(): boolean {
+		return this.simCooldownAssignmentGains;
+	}
+	setSimCooldownAssignmentGains(eventID: EventID, newSimCooldownAssignmentGains: boolean) {
+		if (this.simCooldownAssignmentGains == newSimCooldownAssignmentGains) return;
+
+		this.simCooldownAssignmentGains = newSimCooldownAssignmentGains;
+		this.simCooldownAssignmentGainsChangeEmitter.emit(eventID);
+	}
+
 	getActivePlayers(): Array<Player<any>> {
 		if (this.activePlayers.length == 0) {
 			const activeParties = this.getParties().filter((party, i) => i < this.numActiveParties);
@@ -201,6 +247,9 @@ export class Raid {
 			tanks: this.getTanks(),
 			targetDummies: this.getTargetDummies(),
 			numActiveParties: this.getNumActiveParties(),
+			simulateRaidBuffs: this.getSimulateRaidBuffs(),
+			cooldownAssignments: this.getCooldownAssignments(),
+			simCooldownAssignmentGains: this.getSimCooldownAssignmentGains(),
 		});
 	}
 
@@ -221,6 +270,9 @@ export class Raid {
 			this.setTanks(eventID, proto.tanks);
 			this.setTargetDummies(eventID, proto.targetDummies);
 			this.setNumActiveParties(eventID, proto.numActiveParties || 5);
+			this.setSimulateRaidBuffs(eventID, proto.simulateRaidBuffs);
+			this.setCooldownAssignments(eventID, proto.cooldownAssignments);
+			this.setSimCooldownAssignmentGains(eventID, proto.simCooldownAssignmentGains);
 
 			for (let i = 0; i < MAX_NUM_PARTIES; i++) {
 				if (proto.parties[i]) {
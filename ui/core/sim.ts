@@ -472,7 +472,8 @@ export class Sim {
 			} else {
 				return undefined;
 			}
-		} else if (ref.type == UnitType.Self) {
+		} else if (ref.type == UnitType.Self || ref.type == UnitType.LowestHealthAlly) {
+			// The lowest health ally changes during the sim, so use the player's metadata for spell and aura lookups.
 			return contextPlayer?.getMetadata();
 		} else if (ref.type == UnitType.CurrentTarget) {
 			return this.encounter.targetsMetadata.asList()[0];
@@ -120,6 +120,7 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecFeralTankDruid, {
 			OtherInputs.IncomingHps,
 			OtherInputs.HealingCadence,
 			OtherInputs.HealingCadenceVariation,
+			OtherInputs.VirtualHealers,
 			OtherInputs.BurstWindow,
 			OtherInputs.InspirationUptime,
 			OtherInputs.HpPercentForDefensives,
@@ -1,9 +1,16 @@
+import { BooleanPicker } from '../../core/components/boolean_picker.js';
 import { Component } from '../../core/components/component.js';
+import { EnumPicker } from '../../core/components/enum_picker.js';
+import { Input } from '../../core/components/input.js';
+import { ListItemPickerConfig, ListPicker } from '../../core/components/list_picker.js';
+import { NumberListPicker } from '../../core/components/number_list_picker.js';
 import { UnitReferencePicker } from '../../core/components/raid_target_picker.js';
 import { Player } from '../../core/player.js';
-import { Class, Spec, UnitReference } from '../../core/proto/common.js';
+import { RaidCooldownAssignment } from '../../core/proto/api.js';
+import { ActionID as ActionIdProto, Class, Spec, UnitReference } from '../../core/proto/common.js';
 import { PriestTalents } from '../../core/proto/priest.js';
 import { emptyUnitReference } from '../../core/proto_utils/utils.js';
+import { Raid } from '../../core/raid.js';
 import { EventID, TypedEvent } from '../../core/typed_event.js';
 import { RaidSimUI } from '../raid_sim_ui.js';
 
@@ -20,6 +27,163 @@ export class AssignmentsPicker extends Component {
 
 		this.innervatesPicker = new InnervatesPicker(this.rootElem, raidSimUI);
 		this.powerInfusionsPicker = new PowerInfusionsPicker(this.rootElem, raidSimUI);
+
+		const raid = raidSimUI.sim.raid;
+		new ListPicker<Raid, RaidCooldownAssignment>(this.rootElem, raid, {
+			extraCssClasses: ['cooldown-assignments-picker'],
+			title: 'Cooldown Assignments',
+			titleTooltip: 'Casts a cooldown from one raid member on another at fixed times, and optionally every time it comes off cooldown.',
+			itemLabel: 'Assignment',
+			changedEvent: (raid: Raid) => raid.cooldownAssignmentsChangeEmitter,
+			getValue: (raid: Raid) => raid.getCooldownAssignments(),
+			setValue: (eventID: EventID, raid: Raid, newValue: Array<RaidCooldownAssignment>) => raid.setCooldownAssignments(eventID, newValue),
+			newItem: () =>
+				RaidCooldownAssignment.create({
+					spellId: cooldownAssignmentSpells[0].actionId,
+					timings: [0],
+					repeatOnCooldown: true,
+				}),
+			copyItem: (oldItem: RaidCooldownAssignment) => RaidCooldownAssignment.clone(oldItem),
+			newItemPicker: (
+				parent: HTMLElement,
+				_listPicker: ListPicker<Raid, RaidCooldownAssignment>,
+				index: number,
+				config: ListItemPickerConfig<Raid, RaidCooldownAssignment>,
+			) => new CooldownAssignmentPicker(parent, raid, index, config),
+		});
+		new BooleanPicker<Raid>(this.rootElem, raid, {
+			id: 'cooldown-assignments-sim-gains',
+			label: 'Sim DPS Gains',
+			labelTooltip: 'Also runs the sim without each assignment to find the raid and target DPS it adds. Each assignment takes as long as the main sim.',
+			inline: true,
+			changedEvent: (raid: Raid) => raid.simCooldownAssignmentGainsChangeEmitter,
+			getValue: (raid: Raid) => raid.getSimCooldownAssignmentGains(),
+			setValue: (eventID: EventID, raid: Raid, newValue: boolean) => raid.setSimCooldownAssignmentGains(eventID, newValue),
+		});
+	}
+}
+
+const makeSpellActionId = (spellId: number): ActionIdProto =>
+	ActionIdProto.create({
+		rawId: {
+			oneofKind: 'spellId',
+			spellId: spellId,
+		},
+	});
+
+const cooldownAssignmentSpells = [
+	{ name: 'Power Infusion', actionId: makeSpellActionId(10060) },
+	{ name: 'Innervate', actionId: makeSpellActionId(29166) },
+];
+
+const getSpellId = (actionId: ActionIdProto | undefined): number => (actionId?.rawId.oneofKind == 'spellId' ? actionId.rawId.spellId : 0);
+
+class CooldownAssignmentPicker extends Input<Raid, RaidCooldownAssignment> {
+	private readonly raid: Raid;
+	private readonly assignmentIndex: number;
+
+	private readonly casterPicker: Input<null, UnitReference>;
+	private readonly spellPicker: Input<null, number>;
+	private readonly targetPicker: Input<null, UnitReference>;
+	private readonly timingsPicker: Input<null, Array<number>>;
+	private readonly repeatPicker: Input<null, boolean>;
+
+	private getAssignment(): RaidCooldownAssignment {
+		return this.raid.getCooldownAssignments()[this.assignmentIndex] || RaidCooldownAssignment.create();
+	}
+
+	private updateAssignment(eventID: EventID, update: (assignment: RaidCooldownAssignment) => void) {
+		const assignments = this.raid.getCooldownAssignments();
+		if (!assignments[this.assignmentIndex]) {
+			return;
+		}
+		update(assignments[this.assignmentIndex]);
+		this.raid.setCooldownAssignments(eventID, assignments);
+	}
+
+	constructor(parent: HTMLElement, raid: Raid, assignmentIndex: number, config: ListItemPickerConfig<Raid, RaidCooldownAssignment>) {
+		super(parent, 'cooldown-assignment-picker-root', raid, config);
+		this.raid = raid;
+		this.assignmentIndex = assignmentIndex;
+
+		this.casterPicker = new UnitReferencePicker<null>(this.rootElem, raid, null, {
+			label: 'Caster',
+			noTargetLabel: 'Unassigned',
+			compChangeEmitter: raid.compChangeEmitter,
+			changedEvent: () => raid.cooldownAssignmentsChangeEmitter,
+			getValue: () => this.getAssignment().caster || emptyUnitReference(),
+			setValue: (eventID: EventID, _: null, newValue: UnitReference) => this.updateAssignment(eventID, assignment => (assignment.caster = newValue)),
+		});
+
+		this.spellPicker = new EnumPicker<null>(this.rootElem, null, {
+			id: `cooldown-assignment-spell-${assignmentIndex}`,
+			label: 'Spell',
+			values: cooldownAssignmentSpells.map(spell => {
+				return {
+					name: spell.name,
+					value: getSpellId(spell.actionId),
+				};
+			}),
+			changedEvent: () => raid.cooldownAssignmentsChangeEmitter,
+			getValue: () => getSpellId(this.getAssignment().spellId),
+			setValue: (eventID: EventID, _: null, newValue: number) =>
+				this.updateAssignment(eventID, assignment => (assignment.spellId = makeSpellActionId(newValue))),
+		});
+
+		this.targetPicker = new UnitReferencePicker<null>(this.rootElem, raid, null, {
+			label: 'Target',
+			noTargetLabel: 'Unassigned',
+			compChangeEmitter: raid.compChangeEmitter,
+			changedEvent: () => raid.cooldownAssignmentsChangeEmitter,
+			getValue: () => this.getAssignment().target || emptyUnitReference(),
+			setValue: (eventID: EventID, _: null, newValue: UnitReference) => this.updateAssignment(eventID, assignment => (assignment.target = newValue)),
+		});
+
+		this.timingsPicker = new NumberListPicker<null>(this.rootElem, null, {
+			id: `cooldown-assignment-timings-${assignmentIndex}`,
+			label: 'Timings',
+			labelTooltip: 'Times in seconds, from the start of the fight, at which to cast the spell.',
+			placeholder: '0, 180, ...',
+			changedEvent: () => raid.cooldownAssignmentsChangeEmitter,
+			getValue: () => this.getAssignment().timings,
+			setValue: (eventID: EventID, _: null, newValue: Array<number>) => this.updateAssignment(eventID, assignment => (assignment.timings = newValue)),
+		});
+
+		this.repeatPicker = new BooleanPicker<null>(this.rootElem, null, {
+			id: `cooldown-assignment-repeat-${assignmentIndex}`,
+			label: 'Repeat on Cooldown',
+			labelTooltip: 'After the listed timings, cast the spell again every time it comes off cooldown.',
+			inline: true,
+			changedEvent: () => raid.cooldownAssignmentsChangeEmitter,
+			getValue: () => this.getAssignment().repeatOnCooldown,
+			setValue: (eventID: EventID, _: null, newValue: boolean) => this.updateAssignment(eventID, assignment => (assignment.repeatOnCooldown = newValue)),
+		});
+
+		this.init();
+	}
+
+	getInputElem(): HTMLElement | null {
+		return null;
+	}
+	getInputValue(): RaidCooldownAssignment {
+		return RaidCooldownAssignment.create({
+			caster: this.casterPicker.getInputValue(),
+			spellId: makeSpellActionId(this.spellPicker.getInputValue()),
+			target: this.targetPicker.getInputValue(),
+			timings: this.timingsPicker.getInputValue(),
+			repeatOnCooldown: this.repeatPicker.getInputValue(),
+			condition: this.getAssignment().condition,
+		});
+	}
+	setInputValue(newValue: RaidCooldownAssignment) {
+		if (!newValue) {
+			return;
+		}
+		this.casterPicker.setInputValue(newValue.caster || emptyUnitReference());
+		this.spellPicker.setInputValue(getSpellId(newValue.spellId));
+		this.targetPicker.setInputValue(newValue.target || emptyUnitReference());
+		this.timingsPicker.setInputValue(newValue.timings);
+		this.repeatPicker.setInputValue(newValue.repeatOnCooldown);
 	}
 }
 
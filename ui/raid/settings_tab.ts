@@ -1,3 +1,4 @@
+import { BooleanPicker } from '../core/components/boolean_picker';
 import { ContentBlock } from '../core/components/content_block';
 import { EncounterPicker } from '../core/components/encounter_picker';
 import { IconPicker } from '../core/components/icon_picker';
@@ -84,10 +85,20 @@ export class SettingsTab extends SimTab {
 	}
 
 	private buildOtherSettings() {
-		// const raid = this.simUI.sim.raid;
-		// const contentBlock = new ContentBlock(this.column1, 'other-settings', {
-		//   header: {title: 'Other'}
-		// });
+		const contentBlock = new ContentBlock(this.column1, 'other-settings', {
+			header: { title: 'Other' },
+		});
+		new BooleanPicker(contentBlock.bodyElement, this.simUI.sim.raid, {
+			id: 'raid-simulate-raid-buffs',
+			label: 'Simulate Raid Buffs',
+			labelTooltip:
+				'Buffs, totems and debuffs from players in the raid come from their actual spells, e.g. Battle Shout, Sunder Armor, Hunter\'s Mark and Strength of Earth, Grace of Air, Windfury and Mana Spring Totems. Uptime then follows their rotations, and party-wide buffs only reach their party. The buff toggles still apply for classes not in the raid.',
+			changedEvent: (raid: Raid) => raid.simulateRaidBuffsChangeEmitter,
+			getValue: (raid: Raid) => raid.getSimulateRaidBuffs(),
+			setValue: (eventID: EventID, raid: Raid, newValue: boolean) => {
+				raid.setSimulateRaidBuffs(eventID, newValue);
+			},
+		});
 		// new BooleanPicker(contentBlock.bodyElement, this.simUI.sim.raid, {
 		// 	label: 'Stagger Stormstrikes',
 		// 	labelTooltip: 'When there are multiple Enhancement Shaman in the raid, causes them to coordinate their Stormstrike casts for optimal SS charge usage.',
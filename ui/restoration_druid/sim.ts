@@ -36,7 +36,7 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecRestorationDruid, {
 		// Default consumes settings.
 		consumes: Presets.DefaultConsumes,
 		// Default talents.
-		talents: Presets.CelestialFocusTalents.data,
+		talents: Presets.StandardTalents.data,
 		// Default spec-specific settings.
 		specOptions: Presets.DefaultOptions,
 		// Default raid/party buffs settings.
@@ -67,7 +67,7 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecRestorationDruid, {
 
 	presets: {
 		// Preset talents that the user can quickly select.
-		talents: [Presets.CelestialFocusTalents, Presets.ThiccRestoTalents],
+		talents: [Presets.StandardTalents],
 		rotations: [],
 		// Preset gear configurations that the user can quickly select.
 		gear: [Presets.DefaultGear],
@@ -84,7 +84,7 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecRestorationDruid, {
 			defaultName: 'Restoration',
 			iconUrl: getSpecIcon(Class.ClassDruid, 2),
 
-			talents: Presets.CelestialFocusTalents.data,
+			talents: Presets.StandardTalents.data,
 			specOptions: Presets.DefaultOptions,
 			consumes: Presets.DefaultConsumes,
 			defaultFactionRaces: {
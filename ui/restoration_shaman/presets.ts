@@ -12,16 +12,10 @@ export const DefaultGear = PresetUtils.makePresetGear('Blank', BlankGear);
 
 // Default talents. Uses the wowhead calculator format, make the talents on
 // https://wowhead.com/classic/talent-calc and copy the numbers in the url.
-export const TankHealingTalents = {
-	name: 'Tank Healing',
+export const StandardTalents = {
+	name: 'Standard',
 	data: SavedTalents.create({
-		talentsString: '-30205033-05005331335010501122331251',
-	}),
-};
-export const RaidHealingTalents = {
-	name: 'Raid Healing',
-	data: SavedTalents.create({
-		talentsString: '-3020503-50005331335310501122331251',
+		talentsString: '-5005-510303310053151',
 	}),
 };
 
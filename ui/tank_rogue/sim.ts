@@ -117,6 +117,7 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecTankRogue, {
 			OtherInputs.IncomingHps,
 			OtherInputs.HealingCadence,
 			OtherInputs.HealingCadenceVariation,
+			OtherInputs.VirtualHealers,
 			OtherInputs.BurstWindow,
 			OtherInputs.HpPercentForDefensives,
 			OtherInputs.InspirationUptime,
@@ -126,6 +126,7 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecTankWarlock, {
 			OtherInputs.IncomingHps,
 			OtherInputs.HealingCadence,
 			OtherInputs.HealingCadenceVariation,
+			OtherInputs.VirtualHealers,
 			OtherInputs.BurstWindow,
 			OtherInputs.HpPercentForDefensives,
 			OtherInputs.InspirationUptime,
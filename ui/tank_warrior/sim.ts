@@ -120,6 +120,7 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecTankWarrior, {
 			OtherInputs.IncomingHps,
 			OtherInputs.HealingCadence,
 			OtherInputs.HealingCadenceVariation,
+			OtherInputs.VirtualHealers,
 			OtherInputs.BurstWindow,
 			OtherInputs.HpPercentForDefensives,
 			OtherInputs.InspirationUptime,